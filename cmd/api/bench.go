@@ -0,0 +1,287 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"os/signal"
+	"slices"
+	"strconv"
+	"sync"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/cache"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/config"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/service"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/utils/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+const benchExitError = 1
+
+// benchResult is the outcome of the benchmark of one concurrency mode
+type benchResult struct {
+	mode      service.BalanceConcurrency
+	events    int
+	failed    int
+	duration  time.Duration
+	latencies []time.Duration
+	retries   float64
+	exhausted float64
+	// balanceChanges is the signed sum of the processed transactions by player ID
+	balanceChanges map[string]float64
+	// balancesOK is false when a stored balance differs from the starting
+	// balance plus the processed transactions
+	balancesOK bool
+}
+
+// runBenchCommand plays the same bet and result load against the configured
+// storage once per balance concurrency mode and compares them. Every mode gets
+// its own players, all rounds of a mode are spread over them, so fewer players
+// mean more contention.
+func runBenchCommand(args []string) int {
+	flags := flag.NewFlagSet("bench", flag.ContinueOnError)
+	playerCount := flags.Int("players", 1, "number of players the rounds are spread over")
+	workers := flags.Int("workers", 32, "number of rounds played concurrently")
+	rounds := flags.Int("rounds", 1000, "number of rounds per mode, every round is a bet and a result")
+	modes := flags.String("modes", "locking,optimistic", "comma separated balance concurrency modes to compare")
+	operatorID := flags.String("operator", entities.DefaultOperatorID, "operator the players are created for")
+	if err := flags.Parse(args); err != nil {
+		return benchExitError
+	}
+	if *playerCount < 1 || *workers < 1 || *rounds < 1 {
+		fmt.Fprintln(os.Stderr, "-players, -workers and -rounds must be positive")
+		return benchExitError
+	}
+	var concurrencies []service.BalanceConcurrency
+	for _, mode := range config.ParseList(*modes) {
+		concurrency := service.BalanceConcurrency(mode)
+		if !concurrency.IsValid() {
+			fmt.Fprintf(os.Stderr, "invalid balance concurrency mode %q\n", mode)
+			return benchExitError
+		}
+		concurrencies = append(concurrencies, concurrency)
+	}
+
+	cfg := config.NewConfig()
+	logger.InitLogger(cfg.LogLevel)
+	storage := openStorage(cfg)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	operator, err := storage.operatorRepo.GetByID(ctx, *operatorID, nil)
+	if err != nil {
+		zap.L().Error("Failed to load operator", zap.String("operator_id", *operatorID), zap.Error(err))
+		return benchExitError
+	}
+	if len(operator.Currencies) == 0 {
+		fmt.Fprintf(os.Stderr, "operator %s has no currencies\n", operator.ID)
+		return benchExitError
+	}
+	amount := max(operator.MinBet, 1)
+	if !operator.IsBetAllowed(amount) {
+		fmt.Fprintf(os.Stderr, "operator %s allows no bet of %.2f\n", operator.ID, amount)
+		return benchExitError
+	}
+
+	// The run ID keeps the players and request IDs of repeated runs apart
+	runID := strconv.FormatInt(time.Now().UnixNano(), 36)
+	fmt.Printf("Benchmark %s: %d rounds per mode over %d players with %d workers on %s storage\n\n",
+		runID, *rounds, *playerCount, *workers, cfg.StorageBackend)
+
+	var results []*benchResult
+	for _, concurrency := range concurrencies {
+		players := make([]*entities.Player, *playerCount)
+		for i := range players {
+			id := fmt.Sprintf("bench-%s-%s-%d", runID, concurrency, i)
+			players[i] = &entities.Player{
+				OperatorID: operator.ID,
+				ID:         id,
+				WalletID:   id,
+				// Every round bets first, so each player can cover all rounds at once
+				Balance:  amount * float64(*rounds),
+				Currency: operator.Currencies[0],
+			}
+			if err := storage.playerRepo.Create(ctx, players[i], nil); err != nil {
+				zap.L().Error("Failed to create benchmark player", zap.String("player_id", id), zap.Error(err))
+				return benchExitError
+			}
+		}
+
+		walletService := service.NewWalletService(storage.playerRepo, storage.operatorRepo, storage.unitOfWork, cache.NewNopBalanceCache(), concurrency)
+		result := runBenchMode(ctx, walletService, players, runID, concurrency, amount, *rounds, *workers)
+		if err := ctx.Err(); err != nil {
+			fmt.Fprintln(os.Stderr, "benchmark interrupted")
+			return benchExitError
+		}
+
+		// Only processed transactions change the balance, a player that ended
+		// elsewhere lost or doubled an update
+		result.balancesOK = true
+		for _, player := range players {
+			stored, err := storage.playerRepo.GetByID(ctx, player.OperatorID, player.ID, nil)
+			if err != nil {
+				zap.L().Error("Failed to read benchmark player", zap.String("player_id", player.ID), zap.Error(err))
+				return benchExitError
+			}
+			expected := math.Round((player.Balance+result.balanceChanges[player.ID])*100) / 100
+			if stored.Balance != expected {
+				result.balancesOK = false
+			}
+		}
+		results = append(results, result)
+	}
+
+	printBenchResults(results)
+	for _, result := range results {
+		if !result.balancesOK {
+			return benchExitError
+		}
+	}
+	return 0
+}
+
+// runBenchMode plays the rounds with the given number of workers, round i is played by player i modulo the players
+func runBenchMode(ctx context.Context, walletService service.IWalletService, players []*entities.Player, runID string, concurrency service.BalanceConcurrency, amount float64, rounds, workers int) *benchResult {
+	retries, exhausted := gatherRetryCounts()
+	result := &benchResult{mode: concurrency, balanceChanges: make(map[string]float64)}
+
+	next := make(chan int)
+	go func() {
+		defer close(next)
+		for round := 0; round < rounds; round++ {
+			select {
+			case next <- round:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	startedAt := time.Now()
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var latencies []time.Duration
+			failed := 0
+			balanceChanges := make(map[string]float64)
+			for round := range next {
+				player := players[round%len(players)]
+				roundID := fmt.Sprintf("bench-%s-%s-%d", runID, concurrency, round)
+				for _, transactionType := range []entities.TransactionType{entities.TransactionTypeBet, entities.TransactionTypeResult} {
+					transaction := &entities.Transaction{
+						OperatorID: player.OperatorID,
+						ProviderID: "bench",
+						ReqID:      roundID + "-" + string(transactionType),
+						PlayerID:   player.ID,
+						WalletID:   player.WalletID,
+						RoundID:    roundID,
+						SessionID:  runID,
+						GameCode:   "bench",
+						Type:       transactionType,
+						Amount:     amount,
+						Currency:   player.Currency,
+					}
+					start := time.Now()
+					err := walletService.ProcessTransaction(ctx, transaction)
+					latencies = append(latencies, time.Since(start))
+					if err != nil {
+						failed++
+						if !errors.Is(err, context.Canceled) {
+							zap.L().Warn("Benchmark transaction failed", zap.String("req_id", transaction.ReqID), zap.Error(err))
+						}
+						// A result without its bet would only fail as well
+						break
+					}
+					if transactionType == entities.TransactionTypeBet {
+						balanceChanges[player.ID] -= amount
+					} else {
+						balanceChanges[player.ID] += amount
+					}
+				}
+			}
+			mu.Lock()
+			result.latencies = append(result.latencies, latencies...)
+			result.failed += failed
+			for playerID, change := range balanceChanges {
+				result.balanceChanges[playerID] += change
+			}
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	result.duration = time.Since(startedAt)
+	result.events = len(result.latencies)
+	slices.Sort(result.latencies)
+	retriesAfter, exhaustedAfter := gatherRetryCounts()
+	result.retries = retriesAfter - retries
+	result.exhausted = exhaustedAfter - exhausted
+	return result
+}
+
+// gatherRetryCounts sums the transaction retry counters over all reasons
+func gatherRetryCounts() (retries, exhausted float64) {
+	families, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		return 0, 0
+	}
+	for _, family := range families {
+		var total *float64
+		switch family.GetName() {
+		case "wallet_db_transaction_retries_total":
+			total = &retries
+		case "wallet_db_transaction_retries_exhausted_total":
+			total = &exhausted
+		default:
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			*total += metric.GetCounter().GetValue()
+		}
+	}
+	return retries, exhausted
+}
+
+// percentile returns the latency below which the share p of the sorted latencies are
+func percentile(latencies []time.Duration, p float64) time.Duration {
+	if len(latencies) == 0 {
+		return 0
+	}
+	return latencies[min(int(float64(len(latencies))*p), len(latencies)-1)]
+}
+
+func printBenchResults(results []*benchResult) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "MODE\tEVENTS\tFAILED\tDURATION\tEVENTS/S\tP50\tP95\tP99\tRETRIES\tEXHAUSTED\tBALANCES")
+	for _, result := range results {
+		balances := "ok"
+		if !result.balancesOK {
+			balances = "mismatch"
+		}
+		fmt.Fprintf(writer, "%s\t%d\t%d\t%s\t%.0f\t%s\t%s\t%s\t%.0f\t%.0f\t%s\n",
+			result.mode,
+			result.events,
+			result.failed,
+			result.duration.Round(time.Millisecond),
+			float64(result.events)/result.duration.Seconds(),
+			percentile(result.latencies, 0.50).Round(time.Microsecond),
+			percentile(result.latencies, 0.95).Round(time.Microsecond),
+			percentile(result.latencies, 0.99).Round(time.Microsecond),
+			result.retries,
+			result.exhausted,
+			balances)
+	}
+	writer.Flush()
+}
@@ -2,23 +2,37 @@ package main
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/archive"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/cache"
 	"github.com/BarisKilicGsu/casino-wallet-service/internal/config"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/events"
 	"github.com/BarisKilicGsu/casino-wallet-service/internal/handler"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/middleware"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/providerstatement"
 	"github.com/BarisKilicGsu/casino-wallet-service/internal/repository"
-	"github.com/BarisKilicGsu/casino-wallet-service/internal/seed"
 	"github.com/BarisKilicGsu/casino-wallet-service/internal/service"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/stream"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/tracing"
 	"github.com/BarisKilicGsu/casino-wallet-service/internal/utils/logger"
+	"github.com/glebarez/sqlite"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 	"go.uber.org/zap"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	gormLogger "gorm.io/gorm/logger"
+	otelgorm "gorm.io/plugin/opentelemetry/tracing"
 )
 
 func connectWithRetry(dsn string, maxRetries int) (*gorm.DB, error) {
@@ -30,6 +44,10 @@ func connectWithRetry(dsn string, maxRetries int) (*gorm.DB, error) {
 			Logger: gormLogger.Default.LogMode(gormLogger.Silent),
 		})
 		if err == nil {
+			// Every query becomes a span below the repository span that issued it
+			if err := db.Use(otelgorm.NewPlugin(otelgorm.WithoutMetrics(), otelgorm.WithoutQueryVariables())); err != nil {
+				return nil, fmt.Errorf("failed to register tracing plugin: %w", err)
+			}
 			return db, nil
 		}
 		zap.L().Warn("Failed to connect to PostgreSQL, retrying...",
@@ -42,7 +60,120 @@ func connectWithRetry(dsn string, maxRetries int) (*gorm.DB, error) {
 	return nil, fmt.Errorf("failed to connect after %d attempts: %w", maxRetries, err)
 }
 
+// openSQLite opens the SQLite database at path, creating it when missing
+func openSQLite(path string) (*gorm.DB, error) {
+	sqlDB, err := sql.Open(sqlite.DriverName, repository.SQLiteDSN(path))
+	if err != nil {
+		return nil, err
+	}
+	db, err := gorm.Open(repository.NewSQLiteDialector(sqlDB), &gorm.Config{
+		Logger: gormLogger.Default.LogMode(gormLogger.Silent),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Use(otelgorm.NewPlugin(otelgorm.WithoutMetrics(), otelgorm.WithoutQueryVariables())); err != nil {
+		return nil, fmt.Errorf("failed to register tracing plugin: %w", err)
+	}
+	return db, nil
+}
+
+// connectReplica opens a read replica without waiting for it, the lag checks
+// keep reads away from a replica that is not reachable
+func connectReplica(dsn string) (*gorm.DB, error) {
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+		Logger:               gormLogger.Default.LogMode(gormLogger.Silent),
+		DisableAutomaticPing: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Use(otelgorm.NewPlugin(otelgorm.WithoutMetrics(), otelgorm.WithoutQueryVariables())); err != nil {
+		return nil, fmt.Errorf("failed to register tracing plugin: %w", err)
+	}
+	return db, nil
+}
+
+// newReplicaSet connects the configured read replicas, it returns nil when there are none
+func newReplicaSet(cfg *config.Config) *repository.ReplicaSet {
+	if len(cfg.ReplicaDSNs) == 0 {
+		return nil
+	}
+	if cfg.ReadYourWritesWindow > 0 && cfg.ReadYourWritesWindow <= cfg.ReplicaMaxLag {
+		zap.L().Warn("Read-your-writes window is not longer than the replica lag limit, reads after a write may miss it",
+			zap.Duration("window", cfg.ReadYourWritesWindow),
+			zap.Duration("max_lag", cfg.ReplicaMaxLag))
+	}
+	replicas := make(map[string]*gorm.DB, len(cfg.ReplicaDSNs))
+	for i, dsn := range cfg.ReplicaDSNs {
+		name := fmt.Sprintf("replica-%d", i+1)
+		db, err := connectReplica(dsn)
+		if err != nil {
+			zap.L().Fatal("Failed to open read replica", zap.String("replica", name), zap.Error(err))
+		}
+		sqlDB, err := db.DB()
+		if err != nil {
+			zap.L().Fatal("Failed to get underlying *sql.DB", zap.String("replica", name), zap.Error(err))
+		}
+		prometheus.MustRegister(collectors.NewDBStatsCollector(sqlDB, name))
+		replicas[name] = db
+	}
+	return repository.NewReplicaSet(replicas, repository.ReplicaConfig{
+		MaxLag:               cfg.ReplicaMaxLag,
+		CheckInterval:        cfg.ReplicaCheckInterval,
+		ReadYourWritesWindow: cfg.ReadYourWritesWindow,
+	})
+}
+
+// newUnitOfWork runs units of work with the configured isolation level and retries
+func newUnitOfWork(cfg *config.Config, gormRepository repository.IGormRepository, replicas *repository.ReplicaSet) repository.IUnitOfWork {
+	if !repository.IsValidIsolationLevel(cfg.TxIsolationLevel) {
+		zap.L().Fatal("Invalid transaction isolation level", zap.String("isolation_level", cfg.TxIsolationLevel))
+	}
+	transactionRunner := repository.NewTransactionRunner(gormRepository, repository.TransactionRunnerConfig{
+		IsolationLevel: cfg.TxIsolationLevel,
+		MaxAttempts:    cfg.TxMaxAttempts,
+		BaseBackoff:    cfg.TxRetryBaseDelay,
+		MaxBackoff:     cfg.TxRetryMaxDelay,
+	})
+	return repository.NewUnitOfWorkWithReplicas(transactionRunner, replicas)
+}
+
+// balanceConcurrency returns how the wallet service keeps the balance updates of a player apart
+func balanceConcurrency(cfg *config.Config) service.BalanceConcurrency {
+	concurrency := service.BalanceConcurrency(cfg.BalanceConcurrency)
+	if !concurrency.IsValid() {
+		zap.L().Fatal("Invalid balance concurrency mode", zap.String("balance_concurrency", cfg.BalanceConcurrency))
+	}
+	return concurrency
+}
+
+func newBalanceCacheConfig(cfg *config.Config) cache.Config {
+	return cache.Config{
+		Backend:       cfg.BalanceCacheBackend,
+		TTL:           cfg.BalanceCacheTTL,
+		RedisAddr:     cfg.RedisAddr,
+		RedisPassword: cfg.RedisPassword,
+		RedisDB:       cfg.RedisDB,
+	}
+}
+
 func main() {
+	// Subcommands run once and exit instead of starting the server
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "reconcile":
+			os.Exit(runReconcileCommand(os.Args[2:]))
+		case "migrate":
+			os.Exit(runMigrateCommand(os.Args[2:]))
+		case "bench":
+			os.Exit(runBenchCommand(os.Args[2:]))
+		default:
+			fmt.Fprintf(os.Stderr, "unknown command %q, available commands: reconcile, migrate, bench\n", os.Args[1])
+			os.Exit(2)
+		}
+	}
+
 	// Load configuration
 	cfg := config.NewConfig()
 
@@ -50,43 +181,188 @@ func main() {
 	logger.InitLogger(cfg.LogLevel)
 	zap.L().Info("Application starting")
 
-	// PostgreSQL connection with retry
-	db, err := connectWithRetry(cfg.GetDSN(), 5)
+	shutdownTracing, err := tracing.Init(context.Background(), tracing.Config{
+		ServiceName:  "casino-wallet-service",
+		Exporter:     cfg.TracingExporter,
+		OTLPEndpoint: cfg.TracingOTLPEndpoint,
+		OTLPInsecure: cfg.TracingOTLPInsecure,
+		FilePath:     cfg.TracingFilePath,
+		SampleRatio:  cfg.TracingSampleRatio,
+	})
 	if err != nil {
-		zap.L().Fatal("Failed to connect to PostgreSQL", zap.Error(err))
+		zap.L().Fatal("Failed to initialize tracing", zap.Error(err))
 	}
 
-	// Get underlying *sql.DB for health check
-	sqlDB, err := db.DB()
+	storage := openStorage(cfg)
+
+	// Balances are read far more often than they change, the cache is dropped after every committed change
+	balanceCache, err := cache.NewBalanceCache(newBalanceCacheConfig(cfg))
 	if err != nil {
-		zap.L().Fatal("Failed to get underlying *sql.DB", zap.Error(err))
+		zap.L().Fatal("Failed to create balance cache", zap.Error(err))
 	}
 
-	// Add sample players
-	if err := seed.SeedPlayers(db); err != nil {
-		zap.L().Error("Error during seed operation", zap.Error(err))
-	}
+	// Create service
+	walletService := service.NewWalletService(storage.playerRepo, storage.operatorRepo, storage.unitOfWork, balanceCache, balanceConcurrency(cfg))
+	apiKeyService := service.NewAPIKeyService(storage.apiKeyRepo)
+	operatorService := service.NewOperatorService(storage.operatorRepo)
 
-	gormRepository := repository.NewGormRepository(db)
+	// Make sure the configured bootstrap admin key can be used to issue the other keys
+	if cfg.BootstrapAdminAPIKey != "" {
+		if err := apiKeyService.EnsureKey(context.Background(), entities.DefaultOperatorID, "bootstrap-admin", cfg.BootstrapAdminAPIKey, entities.APIKeyRoleAdmin); err != nil {
+			zap.L().Error("Error while creating bootstrap admin api key", zap.Error(err))
+		}
+	}
 
-	// Create repositories
-	playerRepo := repository.NewPlayerRepository(gormRepository)
-	transactionRepo := repository.NewTransactionRepository(gormRepository)
+	// Publish the outbox written by the wallet service to downstream systems
+	publisher, err := events.NewPublisher(events.Config{
+		Publisher:    cfg.EventPublisher,
+		FilePath:     cfg.EventsFilePath,
+		KafkaBrokers: cfg.KafkaBrokers,
+		KafkaTopic:   cfg.KafkaTopic,
+		NATSURL:      cfg.NATSURL,
+		NATSSubject:  cfg.NATSSubject,
+	})
+	if err != nil {
+		zap.L().Fatal("Failed to create event publisher", zap.Error(err))
+	}
+	outboxRelay := service.NewOutboxRelay(storage.unitOfWork, storage.outboxRepo, publisher, service.OutboxRelayConfig{
+		PollInterval:   cfg.OutboxPollInterval,
+		BatchSize:      cfg.OutboxBatchSize,
+		MaxAttempts:    cfg.OutboxMaxAttempts,
+		RetryBaseDelay: cfg.OutboxRetryBaseDelay,
+		RetryMaxDelay:  cfg.OutboxRetryMaxDelay,
+	})
 
-	// Create service
-	walletService := service.NewWalletService(playerRepo, transactionRepo, gormRepository)
+	// Background workers run until shutdown
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	var workers sync.WaitGroup
+	runWorker := func(run func(ctx context.Context)) {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			run(workerCtx)
+		}()
+	}
+	runWorker(outboxRelay.Run)
 
 	// Create handlers
-	walletHandler := handler.NewWalletHandler(walletService)
-	healthHandler := handler.NewHealthHandler(sqlDB)
+	// The optional handlers are set below when the storage supports them
+	handlers := &Handlers{
+		Wallet:   handler.NewWalletHandler(walletService),
+		Health:   handler.NewHealthHandler(storage.pinger),
+		APIKey:   handler.NewAPIKeyHandler(apiKeyService),
+		Operator: handler.NewOperatorHandler(operatorService),
+	}
+	if storage.db != nil {
+		gormRepository := storage.gormRepository
+		reconciliationService := service.NewReconciliationService(storage.unitOfWork, repository.NewReconciliationRepository(gormRepository), balanceCache)
+		reconciliationJob := service.NewReconciliationJob(reconciliationService, cfg.ReconciliationInterval)
+		statementFormats, err := providerstatement.LoadFormats(cfg.StatementFormatsFile)
+		if err != nil {
+			zap.L().Fatal("Failed to load statement formats", zap.Error(err))
+		}
+		// Statements are imported per provider, for the operator the provider's callbacks belong to
+		providerOperators := make(map[string]string, len(cfg.ProviderSecrets))
+		for providerID := range cfg.ProviderSecrets {
+			if operatorID, ok := cfg.ProviderOperators[providerID]; ok {
+				providerOperators[providerID] = operatorID
+			} else if cfg.DefaultOperatorFallback {
+				providerOperators[providerID] = entities.DefaultOperatorID
+			}
+		}
+		statementService := service.NewStatementService(repository.NewStatementRepository(gormRepository), repository.NewTransactionRepository(gormRepository), repository.NewTransactionArchiveRepository(gormRepository), providerOperators, statementFormats)
+		webhookRepo := repository.NewWebhookRepository(gormRepository)
+		webhookService := service.NewWebhookService(webhookRepo)
+		webhookDispatcher := service.NewWebhookDispatcher(storage.unitOfWork, webhookRepo, service.WebhookDispatcherConfig{
+			PollInterval:   cfg.WebhookPollInterval,
+			BatchSize:      cfg.WebhookBatchSize,
+			Concurrency:    cfg.WebhookConcurrency,
+			Timeout:        cfg.WebhookTimeout,
+			MaxAttempts:    cfg.WebhookMaxAttempts,
+			RetryBaseDelay: cfg.WebhookRetryBaseDelay,
+			RetryMaxDelay:  cfg.WebhookRetryMaxDelay,
+		})
+
+		runWorker(webhookDispatcher.Run)
+		runWorker(reconciliationJob.Run)
+
+		handlers.Webhook = handler.NewWebhookHandler(webhookService)
+		handlers.Reconciliation = handler.NewReconciliationHandler(reconciliationService)
+		handlers.Statement = handler.NewStatementHandler(statementService, cfg.StatementMaxBytes)
+		handlers.PlayerStatement = handler.NewPlayerStatementHandler(service.NewPlayerStatementService(repository.NewPlayerStatementRepository(gormRepository), archive.NewStore(cfg.TransactionArchiveDir)))
+	}
+	if storage.postgres {
+		gormRepository := storage.gormRepository
+		reportService := service.NewReportService(storage.unitOfWork, repository.NewReportRepository(gormRepository), service.ReportConfig{
+			RollupLookbackDays: cfg.GGRRollupLookbackDays,
+			MaxReportDays:      cfg.ReportMaxDays,
+		})
+		ggrRollupJob := service.NewGGRRollupJob(reportService, cfg.GGRRollupInterval)
+		balanceHistoryService := service.NewBalanceHistoryService(storage.unitOfWork, repository.NewBalanceCheckpointRepository(gormRepository), repository.NewTransactionArchiveRepository(gormRepository), cfg.BalanceCheckpointLag)
+		balanceCheckpointJob := service.NewBalanceCheckpointJob(balanceHistoryService, cfg.BalanceCheckpointInterval)
+		// Transactions are partitioned by month, old months are moved into compressed files
+		transactionArchiveService := service.NewTransactionArchiveService(storage.unitOfWork, archive.NewStore(cfg.TransactionArchiveDir), service.TransactionArchiveConfig{
+			MonthsAhead:     cfg.TransactionPartitionMonthsAhead,
+			RetentionMonths: cfg.TransactionRetentionMonths,
+		})
+		transactionPartitionJob := service.NewTransactionPartitionJob(transactionArchiveService, cfg.TransactionPartitionInterval)
+
+		// Balance streams are fed by Postgres notifications, so they work across replicas
+		balanceHub := stream.NewHub(cfg.GetDSN(), cfg.StreamMaxSubscribers)
+
+		runWorker(balanceHub.Run)
+		runWorker(ggrRollupJob.Run)
+		runWorker(balanceCheckpointJob.Run)
+		runWorker(transactionPartitionJob.Run)
+		if storage.replicas != nil {
+			runWorker(storage.replicas.Run)
+		}
+
+		handlers.Report = handler.NewReportHandler(reportService)
+		handlers.BalanceHistory = handler.NewBalanceHistoryHandler(balanceHistoryService)
+		handlers.Stream = handler.NewStreamHandler(walletService, balanceHub, cfg.StreamHeartbeat)
+	}
+
+	// Provider callbacks must be signed with a per-provider shared secret
+	if len(cfg.ProviderSecrets) == 0 {
+		zap.L().Warn("No provider secrets configured, all /event requests will be rejected")
+	}
+	fallbackOperatorID := ""
+	if cfg.DefaultOperatorFallback {
+		fallbackOperatorID = entities.DefaultOperatorID
+	}
+	for providerID := range cfg.ProviderSecrets {
+		if _, ok := cfg.ProviderOperators[providerID]; !ok && !cfg.DefaultOperatorFallback {
+			zap.L().Warn("Provider has no operator mapping, its requests will be rejected", zap.String("provider_id", providerID))
+		}
+	}
+	signatureVerifier := middleware.NewSignatureVerifier(cfg.ProviderSecrets, cfg.ProviderOperators, fallbackOperatorID, cfg.SignatureWindow, storage.nonceRepo)
+	authenticator := middleware.NewAPIKeyAuthenticator(apiKeyService)
+	rateLimiter := middleware.NewRateLimiter(middleware.RateLimitConfig{
+		ClientRate:   cfg.RateLimitClientRPS,
+		ClientBurst:  cfg.RateLimitClientBurst,
+		PlayerRate:   cfg.RateLimitPlayerRPS,
+		PlayerBurst:  cfg.RateLimitPlayerBurst,
+		MaxInFlight:  cfg.MaxInFlightWrites,
+		InFlightWait: cfg.InFlightWriteWait,
+	})
+	requestTimeout := middleware.NewRequestTimeout(cfg.RequestTimeout, cfg.RouteTimeouts)
 
 	// Set up router
-	router := InitRouter(walletHandler, healthHandler)
+	router := InitRouter(handlers, signatureVerifier, authenticator, rateLimiter, requestTimeout)
+
+	// Request contexts derive from baseCtx, cancelling it aborts the database
+	// work of requests that are still running when the shutdown grace period ends
+	baseCtx, cancelRequests := context.WithCancel(context.Background())
+	defer cancelRequests()
 
 	// Start HTTP server
 	server := &http.Server{
 		Addr:    fmt.Sprintf(":%v", cfg.ApplicationPort),
 		Handler: router,
+		BaseContext: func(net.Listener) context.Context {
+			return baseCtx
+		},
 	}
 
 	// Create channel for graceful shutdown
@@ -113,6 +389,23 @@ func main() {
 	if err := server.Shutdown(ctx); err != nil {
 		zap.L().Error("Error while shutting down server", zap.Error(err))
 	}
+	cancelRequests()
+
+	// Stop the workers, an event that is being published is rolled back and sent
+	// again on the next start, a webhook being sent is retried once its lease ran out
+	stopWorkers()
+	workers.Wait()
+	if err := publisher.Close(); err != nil {
+		zap.L().Error("Error while closing event publisher", zap.Error(err))
+	}
+	if err := balanceCache.Close(); err != nil {
+		zap.L().Error("Error while closing balance cache", zap.Error(err))
+	}
+
+	// Flush spans that are still buffered
+	if err := shutdownTracing(ctx); err != nil {
+		zap.L().Error("Error while shutting down tracing", zap.Error(err))
+	}
 
 	zap.L().Info("Application closed")
 }
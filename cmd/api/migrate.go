@@ -0,0 +1,221 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"text/tabwriter"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/config"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/migration"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/utils/logger"
+	"github.com/BarisKilicGsu/casino-wallet-service/migrations"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	migrateExitError = 1
+	migrateExitUsage = 2
+)
+
+const migrateUsage = `usage: migrate <command>
+
+commands:
+  up              apply all pending migrations
+  down [N|all]    revert the last N applied migrations, 1 by default
+  status          list the migrations and whether they are applied
+  version         print the version the database is at`
+
+// newMigrator returns the migrator of the embedded migrations of the storage backend
+func newMigrator(backend string, db *sql.DB) (*migration.Migrator, error) {
+	var loaded []migration.Migration
+	var err error
+	var dialect migration.Dialect
+	switch backend {
+	case config.StorageBackendPostgres:
+		loaded, err = migration.Load(migrations.Postgres, ".")
+		dialect = migration.Postgres
+	case config.StorageBackendSQLite:
+		loaded, err = migration.Load(migrations.SQLite, "sqlite")
+		dialect = migration.SQLite
+	default:
+		return nil, fmt.Errorf("storage backend %q has no migrations", backend)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load migrations: %w", err)
+	}
+	return migration.New(db, loaded, dialect), nil
+}
+
+// prepareSchema applies the pending migrations when MIGRATE_ON_STARTUP is set.
+// Otherwise it only makes sure the schema was created, pending migrations are
+// logged and left to the migrate command.
+func prepareSchema(cfg *config.Config, db *gorm.DB) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		zap.L().Fatal("Failed to get underlying *sql.DB", zap.Error(err))
+	}
+	migrator, err := newMigrator(cfg.StorageBackend, sqlDB)
+	if err != nil {
+		zap.L().Fatal("Failed to prepare migrations", zap.Error(err))
+	}
+	ctx := context.Background()
+
+	if cfg.MigrateOnStartup {
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			zap.L().Fatal("Failed to apply migrations", zap.Error(err))
+		}
+		version, _, err := migrator.Version(ctx)
+		if err != nil {
+			zap.L().Fatal("Failed to read schema version", zap.Error(err))
+		}
+		zap.L().Info("Database schema is up to date", zap.Int("applied", applied), zap.Uint64("version", version))
+		return
+	}
+
+	_, dirty, err := migrator.Version(ctx)
+	if err != nil {
+		zap.L().Fatal("Failed to read schema version", zap.Error(err))
+	}
+	if dirty {
+		zap.L().Fatal("Database schema is dirty, fix the failed migration by hand", zap.Error(migration.ErrDirty))
+	}
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		zap.L().Fatal("Failed to read migration status", zap.Error(err))
+	}
+	pending := 0
+	for _, status := range statuses {
+		if !status.Applied {
+			pending++
+		}
+	}
+	if pending == len(statuses) {
+		zap.L().Fatal("Database schema is missing, run the migrate up command or set MIGRATE_ON_STARTUP=true")
+	}
+	if pending > 0 {
+		zap.L().Warn("Database schema has pending migrations, run the migrate up command", zap.Int("pending", pending))
+	}
+}
+
+// runMigrateCommand moves the schema of the configured database with the
+// migrations embedded in the binary
+func runMigrateCommand(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return migrateExitUsage
+	}
+	command, args := args[0], args[1:]
+	steps := 1
+	switch command {
+	case "up", "status", "version":
+		if len(args) != 0 {
+			fmt.Fprintln(os.Stderr, migrateUsage)
+			return migrateExitUsage
+		}
+	case "down":
+		if len(args) > 1 {
+			fmt.Fprintln(os.Stderr, migrateUsage)
+			return migrateExitUsage
+		}
+		if len(args) == 1 {
+			if args[0] == "all" {
+				steps = 0
+			} else if n, err := strconv.Atoi(args[0]); err == nil && n > 0 {
+				steps = n
+			} else {
+				fmt.Fprintf(os.Stderr, "invalid number of migrations %q\n", args[0])
+				return migrateExitUsage
+			}
+		}
+	default:
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return migrateExitUsage
+	}
+
+	cfg := config.NewConfig()
+	logger.InitLogger(cfg.LogLevel)
+	var db *gorm.DB
+	var err error
+	switch cfg.StorageBackend {
+	case config.StorageBackendPostgres:
+		db, err = connectWithRetry(cfg.GetDSN(), 5)
+	case config.StorageBackendSQLite:
+		db, err = openSQLite(cfg.SQLitePath)
+	default:
+		fmt.Fprintln(os.Stderr, "migrate needs the postgres or sqlite storage backend")
+		return migrateExitError
+	}
+	if err != nil {
+		zap.L().Error("Failed to open the database", zap.Error(err))
+		return migrateExitError
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		zap.L().Error("Failed to get underlying *sql.DB", zap.Error(err))
+		return migrateExitError
+	}
+	defer sqlDB.Close()
+	migrator, err := newMigrator(cfg.StorageBackend, sqlDB)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return migrateExitError
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	switch command {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "migrate up failed after %d migrations: %v\n", applied, err)
+			return migrateExitError
+		}
+		fmt.Printf("Applied %d migrations\n", applied)
+	case "down":
+		reverted, err := migrator.Down(ctx, steps)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "migrate down failed after %d migrations: %v\n", reverted, err)
+			return migrateExitError
+		}
+		fmt.Printf("Reverted %d migrations\n", reverted)
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to read migration status: %v\n", err)
+			return migrateExitError
+		}
+		printMigrationStatus(statuses)
+	case "version":
+		version, dirty, err := migrator.Version(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to read schema version: %v\n", err)
+			return migrateExitError
+		}
+		switch {
+		case version == 0:
+			fmt.Println("none")
+		case dirty:
+			fmt.Printf("%d (dirty)\n", version)
+		default:
+			fmt.Println(version)
+		}
+	}
+	return 0
+}
+
+func printMigrationStatus(statuses []migration.Status) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "VERSION\tNAME\tAPPLIED")
+	for _, status := range statuses {
+		fmt.Fprintf(writer, "%d\t%s\t%t\n", status.Version, status.Name, status.Applied)
+	}
+	writer.Flush()
+}
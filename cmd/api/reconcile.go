@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"text/tabwriter"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/cache"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/config"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/repository"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/service"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/utils/logger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	reconcileExitError = 1
+	// reconcileExitDrift lets cron jobs and scripts alert on drift without parsing the report
+	reconcileExitDrift = 3
+)
+
+// runReconcileCommand reconciles the wallets once and prints the report, or
+// approves the correction of a discrepancy with -approve
+func runReconcileCommand(args []string) int {
+	flags := flag.NewFlagSet("reconcile", flag.ContinueOnError)
+	operatorID := flags.String("operator", "", "only reconcile the wallets of this operator")
+	jsonOutput := flags.Bool("json", false, "print the report as JSON")
+	approveID := flags.Uint64("approve", 0, "approve the correction of this discrepancy, requires -operator, -reason and -approved-by")
+	reason := flags.String("reason", "", "reason recorded with the approved correction")
+	approvedBy := flags.String("approved-by", "", "person approving the correction")
+	if err := flags.Parse(args); err != nil {
+		return reconcileExitError
+	}
+
+	cfg := config.NewConfig()
+	logger.InitLogger(cfg.LogLevel)
+	var db *gorm.DB
+	var err error
+	switch cfg.StorageBackend {
+	case config.StorageBackendPostgres:
+		db, err = connectWithRetry(cfg.GetDSN(), 5)
+	case config.StorageBackendSQLite:
+		db, err = openSQLite(cfg.SQLitePath)
+	default:
+		fmt.Fprintln(os.Stderr, "reconcile needs the postgres or sqlite storage backend")
+		return reconcileExitError
+	}
+	if err != nil {
+		zap.L().Error("Failed to open the database", zap.Error(err))
+		return reconcileExitError
+	}
+	prepareSchema(cfg, db)
+	gormRepository := repository.NewGormRepository(db)
+	// Approved corrections drop the balance from a shared cache, a cache that can not be
+	// reached is skipped and replicas serve the old balance until it expires
+	balanceCache, err := cache.NewBalanceCache(newBalanceCacheConfig(cfg))
+	if err != nil {
+		zap.L().Warn("Balance cache not available", zap.Error(err))
+		balanceCache = cache.NewNopBalanceCache()
+	}
+	defer balanceCache.Close()
+	reconciliationService := service.NewReconciliationService(newUnitOfWork(cfg, gormRepository, nil), repository.NewReconciliationRepository(gormRepository), balanceCache)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if *approveID != 0 {
+		if *operatorID == "" || *reason == "" || *approvedBy == "" {
+			fmt.Fprintln(os.Stderr, "-approve requires -operator, -reason and -approved-by")
+			return reconcileExitError
+		}
+		adjustment, err := reconciliationService.ApproveCorrection(ctx, *operatorID, *approveID, *reason, *approvedBy)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "correction not applied: %v\n", err)
+			return reconcileExitError
+		}
+		fmt.Printf("Balance of player %s corrected from %.2f to %.2f %s (adjustment %d)\n",
+			adjustment.PlayerID, adjustment.PreviousBalance, adjustment.NewBalance, adjustment.Currency, adjustment.ID)
+		return 0
+	}
+
+	run, err := reconciliationService.Reconcile(ctx, *operatorID, entities.ReconciliationTriggerCommand)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "reconciliation failed: %v\n", err)
+		return reconcileExitError
+	}
+
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(run); err != nil {
+			return reconcileExitError
+		}
+	} else {
+		printReconciliationReport(run)
+	}
+
+	if run.DiscrepancyCount > 0 {
+		return reconcileExitDrift
+	}
+	return 0
+}
+
+func printReconciliationReport(run *entities.ReconciliationRun) {
+	fmt.Printf("Reconciliation run %d: %d wallets checked, %d discrepancies\n\n",
+		run.ID, run.WalletsChecked, run.DiscrepancyCount)
+	if run.DiscrepancyCount == 0 {
+		return
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "DISCREPANCY\tOPERATOR\tPLAYER\tCURRENCY\tEXPECTED\tACTUAL\tDRIFT\tTRANSACTIONS")
+	for _, discrepancy := range run.Discrepancies {
+		fmt.Fprintf(writer, "%d\t%s\t%s\t%s\t%.2f\t%.2f\t%+.2f\t%v\n",
+			discrepancy.ID,
+			discrepancy.OperatorID,
+			discrepancy.PlayerID,
+			discrepancy.Currency,
+			discrepancy.ExpectedBalance,
+			discrepancy.ActualBalance,
+			discrepancy.Drift,
+			discrepancy.TransactionIDs)
+	}
+	writer.Flush()
+}
@@ -3,21 +3,100 @@ package main
 import (
 	"net/http"
 
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
 	"github.com/BarisKilicGsu/casino-wallet-service/internal/handler"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/middleware"
 	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
-func InitRouter(walletHandler *handler.WalletHandler, healthHandler *handler.HealthHandler) *mux.Router {
+// Handlers holds the handlers of the routes. The optional ones need a database,
+// the report, balance history and stream handlers Postgres, their
+// routes are not registered when they are nil.
+type Handlers struct {
+	Wallet   *handler.WalletHandler
+	Health   *handler.HealthHandler
+	APIKey   *handler.APIKeyHandler
+	Operator *handler.OperatorHandler
+
+	Webhook         *handler.WebhookHandler
+	Stream          *handler.StreamHandler
+	Reconciliation  *handler.ReconciliationHandler
+	Statement       *handler.StatementHandler
+	Report          *handler.ReportHandler
+	PlayerStatement *handler.PlayerStatementHandler
+	BalanceHistory  *handler.BalanceHistoryHandler
+}
+
+func InitRouter(handlers *Handlers,
+	signatureVerifier *middleware.SignatureVerifier, authenticator *middleware.APIKeyAuthenticator, rateLimiter *middleware.RateLimiter, requestTimeout *middleware.RequestTimeout) *mux.Router {
 	router := mux.NewRouter()
+	router.Use(middleware.Metrics, middleware.Tracing, requestTimeout.Middleware)
+
+	readRoles := authenticator.Require(entities.APIKeyRoleProvider, entities.APIKeyRoleSupport, entities.APIKeyRoleFinance, entities.APIKeyRoleAdmin)
+	backofficeRoles := authenticator.Require(entities.APIKeyRoleSupport, entities.APIKeyRoleFinance, entities.APIKeyRoleAdmin)
+	reportRoles := authenticator.Require(entities.APIKeyRoleFinance, entities.APIKeyRoleAdmin)
+	adminRoles := authenticator.Require(entities.APIKeyRoleAdmin)
 
 	router.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte("ok"))
 	}).Methods(http.MethodGet)
 
-	router.HandleFunc("/wallet/{player_id}", walletHandler.GetPlayerBalance).Methods(http.MethodGet)
-	router.HandleFunc("/players", walletHandler.GetAllPlayers).Methods(http.MethodGet)
-	router.HandleFunc("/event", walletHandler.ProcessEvent).Methods(http.MethodPost)
-	router.HandleFunc("/health", healthHandler.HealthCheck).Methods(http.MethodGet)
+	router.Handle("/wallet/{player_id}", readRoles(rateLimiter.LimitClient(rateLimiter.LimitPlayer(
+		http.HandlerFunc(handlers.Wallet.GetPlayerBalance))))).Methods(http.MethodGet)
+	router.Handle("/players", backofficeRoles(rateLimiter.LimitClient(
+		http.HandlerFunc(handlers.Wallet.ListPlayers)))).Methods(http.MethodGet)
+	if handlers.Stream != nil {
+		router.Handle("/wallet/{player_id}/stream", readRoles(rateLimiter.LimitClient(rateLimiter.LimitPlayer(
+			http.HandlerFunc(handlers.Stream.StreamBalance))))).Methods(http.MethodGet)
+	}
+	if handlers.PlayerStatement != nil {
+		router.Handle("/wallet/{player_id}/statement", backofficeRoles(rateLimiter.LimitClient(rateLimiter.LimitPlayer(
+			http.HandlerFunc(handlers.PlayerStatement.GetStatement))))).Methods(http.MethodGet)
+	}
+	if handlers.BalanceHistory != nil {
+		router.Handle("/wallet/{player_id}/balance", backofficeRoles(rateLimiter.LimitClient(rateLimiter.LimitPlayer(
+			http.HandlerFunc(handlers.BalanceHistory.GetBalanceAt))))).Methods(http.MethodGet)
+	}
+	if handlers.Report != nil {
+		router.Handle("/reports/ggr", reportRoles(rateLimiter.LimitClient(
+			http.HandlerFunc(handlers.Report.GetGGR)))).Methods(http.MethodGet)
+		router.Handle("/reports/ggr/export", reportRoles(rateLimiter.LimitClient(
+			http.HandlerFunc(handlers.Report.ExportGGR)))).Methods(http.MethodGet)
+	}
+	router.Handle("/event", signatureVerifier.Middleware(rateLimiter.LimitClient(rateLimiter.LimitPlayer(rateLimiter.LimitInFlight(
+		http.HandlerFunc(handlers.Wallet.ProcessEvent)))))).Methods(http.MethodPost)
+	router.HandleFunc("/health", handlers.Health.HealthCheck).Methods(http.MethodGet)
+	router.Handle("/metrics", promhttp.Handler()).Methods(http.MethodGet)
+
+	admin := router.PathPrefix("/admin").Subrouter()
+	admin.Use(adminRoles, rateLimiter.LimitClient)
+	admin.HandleFunc("/api-keys", handlers.APIKey.ListAPIKeys).Methods(http.MethodGet)
+	admin.HandleFunc("/api-keys", handlers.APIKey.IssueAPIKey).Methods(http.MethodPost)
+	admin.HandleFunc("/api-keys/{id}", handlers.APIKey.RevokeAPIKey).Methods(http.MethodDelete)
+	admin.HandleFunc("/operator", handlers.Operator.GetOperator).Methods(http.MethodGet)
+	admin.HandleFunc("/operator", handlers.Operator.UpdateOperator).Methods(http.MethodPut)
+	if handlers.Webhook != nil {
+		admin.HandleFunc("/webhooks", handlers.Webhook.ListWebhooks).Methods(http.MethodGet)
+		admin.HandleFunc("/webhooks", handlers.Webhook.CreateWebhook).Methods(http.MethodPost)
+		admin.HandleFunc("/webhooks/{id}", handlers.Webhook.UpdateWebhook).Methods(http.MethodPut)
+		admin.HandleFunc("/webhooks/{id}", handlers.Webhook.DeleteWebhook).Methods(http.MethodDelete)
+		admin.HandleFunc("/webhooks/{id}/deliveries", handlers.Webhook.ListDeliveries).Methods(http.MethodGet)
+		admin.HandleFunc("/webhook-deliveries/{id}", handlers.Webhook.GetDelivery).Methods(http.MethodGet)
+		admin.HandleFunc("/webhook-deliveries/{id}/redeliver", handlers.Webhook.Redeliver).Methods(http.MethodPost)
+	}
+	if handlers.Reconciliation != nil {
+		admin.HandleFunc("/reconciliation/runs", handlers.Reconciliation.Reconcile).Methods(http.MethodPost)
+		admin.HandleFunc("/reconciliation/runs/{id}", handlers.Reconciliation.GetRun).Methods(http.MethodGet)
+		admin.HandleFunc("/reconciliation/discrepancies", handlers.Reconciliation.ListDiscrepancies).Methods(http.MethodGet)
+		admin.HandleFunc("/reconciliation/discrepancies/{id}/approve", handlers.Reconciliation.ApproveCorrection).Methods(http.MethodPost)
+	}
+	if handlers.Statement != nil {
+		admin.HandleFunc("/statement-imports", handlers.Statement.ListImports).Methods(http.MethodGet)
+		admin.HandleFunc("/statement-imports", handlers.Statement.Import).Methods(http.MethodPost)
+		admin.HandleFunc("/statement-imports/{id}", handlers.Statement.GetImport).Methods(http.MethodGet)
+		admin.HandleFunc("/statement-imports/{id}/export", handlers.Statement.Export).Methods(http.MethodGet)
+	}
 
 	return router
 }
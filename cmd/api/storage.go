@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/config"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/handler"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/repository"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/seed"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// storage holds what the server needs from the configured storage backend
+type storage struct {
+	pinger       handler.Pinger
+	playerRepo   repository.IPlayerRepository
+	apiKeyRepo   repository.IAPIKeyRepository
+	operatorRepo repository.IOperatorRepository
+	outboxRepo   repository.IOutboxRepository
+	nonceRepo    repository.INonceRepository
+	unitOfWork   repository.IUnitOfWork
+
+	// db and gormRepository are nil for the in-memory backend, the features
+	// built on database queries are left out then
+	db             *gorm.DB
+	gormRepository repository.IGormRepository
+	// postgres is false on SQLite, reports, balance history and balance streams
+	// rely on Postgres queries and notifications and are left out there
+	postgres bool
+	// replicas is nil when no replicas are configured
+	replicas *repository.ReplicaSet
+}
+
+func openStorage(cfg *config.Config) *storage {
+	switch cfg.StorageBackend {
+	case config.StorageBackendPostgres:
+		return openPostgresStorage(cfg)
+	case config.StorageBackendSQLite:
+		return openSQLiteStorage(cfg)
+	case config.StorageBackendMemory:
+		return openMemoryStorage()
+	default:
+		zap.L().Fatal("Invalid storage backend", zap.String("storage_backend", cfg.StorageBackend))
+		return nil
+	}
+}
+
+func openPostgresStorage(cfg *config.Config) *storage {
+	// PostgreSQL connection with retry
+	db, err := connectWithRetry(cfg.GetDSN(), 5)
+	if err != nil {
+		zap.L().Fatal("Failed to connect to PostgreSQL", zap.Error(err))
+	}
+
+	// Get underlying *sql.DB for health check
+	sqlDB, err := db.DB()
+	if err != nil {
+		zap.L().Fatal("Failed to get underlying *sql.DB", zap.Error(err))
+	}
+
+	// Expose connection pool statistics next to the application metrics
+	prometheus.MustRegister(collectors.NewDBStatsCollector(sqlDB, cfg.PostgresDB))
+
+	prepareSchema(cfg, db)
+
+	// Add sample players
+	if err := seed.SeedPlayers(db); err != nil {
+		zap.L().Error("Error during seed operation", zap.Error(err))
+	}
+
+	// Reads outside of transactions go to the replicas when there are any
+	replicaSet := newReplicaSet(cfg)
+	gormRepository := repository.NewGormRepository(db)
+	if replicaSet != nil {
+		gormRepository = repository.NewGormRepositoryWithReplicas(db, replicaSet)
+	}
+
+	return &storage{
+		pinger:         sqlDB,
+		playerRepo:     repository.NewPlayerRepository(gormRepository),
+		apiKeyRepo:     repository.NewAPIKeyRepository(gormRepository),
+		operatorRepo:   repository.NewOperatorRepository(gormRepository),
+		outboxRepo:     repository.NewOutboxRepository(gormRepository),
+		nonceRepo:      repository.NewNonceRepository(gormRepository),
+		unitOfWork:     newUnitOfWork(cfg, gormRepository, replicaSet),
+		db:             db,
+		gormRepository: gormRepository,
+		postgres:       true,
+		replicas:       replicaSet,
+	}
+}
+
+// openSQLiteStorage keeps the data in a SQLite file
+func openSQLiteStorage(cfg *config.Config) *storage {
+	zap.L().Warn("Using SQLite storage, writes are serialized and reports, balance history and balance streams are disabled",
+		zap.String("path", cfg.SQLitePath))
+	db, err := openSQLite(cfg.SQLitePath)
+	if err != nil {
+		zap.L().Fatal("Failed to open SQLite database", zap.Error(err))
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		zap.L().Fatal("Failed to get underlying *sql.DB", zap.Error(err))
+	}
+	prometheus.MustRegister(collectors.NewDBStatsCollector(sqlDB, "sqlite"))
+
+	prepareSchema(cfg, db)
+
+	// Add sample players
+	if err := seed.SeedPlayers(db); err != nil {
+		zap.L().Error("Error during seed operation", zap.Error(err))
+	}
+
+	gormRepository := repository.NewGormRepository(db)
+	return &storage{
+		pinger:         sqlDB,
+		playerRepo:     repository.NewPlayerRepository(gormRepository),
+		apiKeyRepo:     repository.NewAPIKeyRepository(gormRepository),
+		operatorRepo:   repository.NewOperatorRepository(gormRepository),
+		outboxRepo:     repository.NewOutboxRepository(gormRepository),
+		nonceRepo:      repository.NewNonceRepository(gormRepository),
+		unitOfWork:     newUnitOfWork(cfg, gormRepository, nil),
+		db:             db,
+		gormRepository: gormRepository,
+	}
+}
+
+// openMemoryStorage keeps all data in process memory, it is lost on restart
+func openMemoryStorage() *storage {
+	zap.L().Warn("Using in-memory storage, data is lost on restart and reconciliation, reports, statements, balance history, webhooks and balance streams are disabled")
+	store := repository.NewMemoryStore()
+	playerRepo := repository.NewMemoryPlayerRepository(store)
+
+	// Add sample players
+	samplePlayers := seed.SamplePlayers()
+	for i := range samplePlayers {
+		if err := playerRepo.Create(context.Background(), &samplePlayers[i], nil); err != nil {
+			zap.L().Error("Error during seed operation", zap.Error(err))
+		}
+	}
+
+	return &storage{
+		pinger:       store,
+		playerRepo:   playerRepo,
+		apiKeyRepo:   repository.NewMemoryAPIKeyRepository(store),
+		operatorRepo: repository.NewMemoryOperatorRepository(store),
+		outboxRepo:   repository.NewMemoryOutboxRepository(store),
+		nonceRepo:    repository.NewMemoryNonceRepository(),
+		unitOfWork:   repository.NewMemoryUnitOfWork(store),
+	}
+}
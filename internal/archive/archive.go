@@ -0,0 +1,164 @@
+// Package archive keeps the transactions of archived months in gzip compressed
+// files with one JSON encoded transaction per line, in created_at order. Soft
+// deleted transactions are kept with their deleted_at.
+package archive
+
+import (
+	"bufio"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"gorm.io/gorm"
+)
+
+// ErrNotFound is returned when the file of an archived month is not in the archive directory
+var ErrNotFound = errors.New("archive file not found")
+
+// record is a line of an archive file. The transaction leaves DeletedAt out of
+// its JSON, the record adds it back.
+type record struct {
+	*entities.Transaction
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// FileName returns the name of the file of the month starting at month
+func FileName(month time.Time) string {
+	return fmt.Sprintf("transactions_%s.jsonl.gz", month.UTC().Format("2006_01"))
+}
+
+// Store reads and writes the archive files of a directory
+type Store struct {
+	dir string
+}
+
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Create starts the file name. It is written under a temporary name and only
+// replaces an existing file when Close succeeds.
+func (s *Store) Create(name string) (*Writer, error) {
+	if err := os.MkdirAll(s.dir, 0o750); err != nil {
+		return nil, err
+	}
+	file, err := os.CreateTemp(s.dir, name+".*.tmp")
+	if err != nil {
+		return nil, err
+	}
+	checksum := sha256.New()
+	buffered := bufio.NewWriter(io.MultiWriter(file, checksum))
+	compressed := gzip.NewWriter(buffered)
+	return &Writer{
+		file:       file,
+		path:       filepath.Join(s.dir, name),
+		checksum:   checksum,
+		buffered:   buffered,
+		compressed: compressed,
+		encoder:    json.NewEncoder(compressed),
+	}, nil
+}
+
+// Read passes the transactions of the file name to each in the order they were written
+func (s *Store) Read(name string, each func(transaction *entities.Transaction) error) error {
+	file, err := os.Open(filepath.Join(s.dir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	compressed, err := gzip.NewReader(bufio.NewReader(file))
+	if err != nil {
+		return err
+	}
+	defer compressed.Close()
+
+	decoder := json.NewDecoder(compressed)
+	for {
+		line := record{Transaction: &entities.Transaction{}}
+		if err := decoder.Decode(&line); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if line.DeletedAt != nil {
+			line.Transaction.DeletedAt = gorm.DeletedAt{Time: *line.DeletedAt, Valid: true}
+		}
+		if err := each(line.Transaction); err != nil {
+			return err
+		}
+	}
+}
+
+// Exists reports whether the file name is in the directory
+func (s *Store) Exists(name string) (bool, error) {
+	_, err := os.Stat(filepath.Join(s.dir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// Writer writes the transactions of one archive file
+type Writer struct {
+	file       *os.File
+	path       string
+	checksum   hash.Hash
+	buffered   *bufio.Writer
+	compressed *gzip.Writer
+	encoder    *json.Encoder
+	count      int64
+}
+
+func (w *Writer) Write(transaction *entities.Transaction) error {
+	line := record{Transaction: transaction}
+	if transaction.DeletedAt.Valid {
+		line.DeletedAt = &transaction.DeletedAt.Time
+	}
+	if err := w.encoder.Encode(line); err != nil {
+		return err
+	}
+	w.count++
+	return nil
+}
+
+// Close syncs the file to disk and moves it to its name. It returns the number
+// of transactions and the hex encoded SHA-256 of the file.
+func (w *Writer) Close() (int64, string, error) {
+	err := w.compressed.Close()
+	if err == nil {
+		err = w.buffered.Flush()
+	}
+	if err == nil {
+		err = w.file.Sync()
+	}
+	if closeErr := w.file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(w.file.Name(), w.path)
+	}
+	if err != nil {
+		os.Remove(w.file.Name())
+		return 0, "", err
+	}
+	return w.count, hex.EncodeToString(w.checksum.Sum(nil)), nil
+}
+
+// Abort removes the temporary file, a file written before under the same name is kept
+func (w *Writer) Abort() {
+	w.file.Close()
+	os.Remove(w.file.Name())
+}
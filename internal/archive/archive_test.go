@@ -0,0 +1,50 @@
+package archive
+
+import (
+	"testing"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestStoreKeepsSoftDeletedTransactions(t *testing.T) {
+	createdAt := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	deletedAt := createdAt.Add(time.Hour)
+	transactions := []*entities.Transaction{
+		{ID: 1, OperatorID: "default", ReqID: "req-1", PlayerID: "player-1", Type: entities.TransactionTypeBet, Amount: 10, CreatedAt: createdAt},
+		{ID: 2, OperatorID: "default", ReqID: "req-2", PlayerID: "player-1", Type: entities.TransactionTypeResult, Amount: 5, CreatedAt: createdAt,
+			DeletedAt: gorm.DeletedAt{Time: deletedAt, Valid: true}},
+	}
+
+	store := NewStore(t.TempDir())
+	name := FileName(createdAt)
+	writer, err := store.Create(name)
+	require.NoError(t, err)
+	for _, transaction := range transactions {
+		require.NoError(t, writer.Write(transaction))
+	}
+	count, checksum, err := writer.Close()
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+	assert.Len(t, checksum, 64)
+
+	var read []*entities.Transaction
+	require.NoError(t, store.Read(name, func(transaction *entities.Transaction) error {
+		read = append(read, transaction)
+		return nil
+	}))
+	require.Len(t, read, 2)
+	assert.Equal(t, "req-1", read[0].ReqID)
+	assert.False(t, read[0].DeletedAt.Valid)
+	assert.Equal(t, "req-2", read[1].ReqID)
+	assert.True(t, read[1].DeletedAt.Valid)
+	assert.True(t, deletedAt.Equal(read[1].DeletedAt.Time))
+}
+
+func TestStoreReadMissingFile(t *testing.T) {
+	err := NewStore(t.TempDir()).Read(FileName(time.Now()), func(*entities.Transaction) error { return nil })
+	assert.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,251 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/metrics"
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+)
+
+// balanceCacheStripes is the number of locks the keys are spread over
+const balanceCacheStripes = 256
+
+// balanceLoadTimeout bounds a shared load, it does not end with the request that started it
+const balanceLoadTimeout = 5 * time.Second
+
+// LoadFunc reads the player from the database on a cache miss
+type LoadFunc func(ctx context.Context) (*entities.Player, error)
+
+// IBalanceCache is a cache-aside layer for player balances
+type IBalanceCache interface {
+	// Get returns the cached player. On a miss concurrent callers of the same
+	// player share one call of load and its result is cached.
+	Get(ctx context.Context, operatorID, playerID string, load LoadFunc) (*entities.Player, error)
+	// Invalidate drops the cached player, it has to be called after the balance
+	// change committed. Once it returns Get on this replica never serves the old balance.
+	Invalidate(ctx context.Context, operatorID, playerID string)
+	Close() error
+}
+
+// balanceStripe orders the fills of its keys against their invalidation. The
+// lock only guards the counters, the store is never called while it is held.
+//
+// A fill that started before an invalidation of the stripe is dropped, since
+// the balance it loaded may predate the commit. A fill whose write was already
+// under way is stale once the generation moves on: until it deleted what it
+// wrote, cached values of the stripe are not trusted.
+type balanceStripe struct {
+	mu         sync.Mutex
+	generation uint64
+	// writes counts the fills writing to the store by the generation they loaded at
+	writes map[uint64]int
+	// cleanups counts the stale writes that finished, a read that overlapped
+	// one may have seen its value
+	cleanups uint64
+}
+
+// staleWrites reports whether a fill of an older generation may still be
+// writing to the store, the caller holds mu
+func (s *balanceStripe) staleWrites() bool {
+	for generation := range s.writes {
+		if generation != s.generation {
+			return true
+		}
+	}
+	return false
+}
+
+type balanceCache struct {
+	store   Store
+	ttl     time.Duration
+	group   singleflight.Group
+	stripes [balanceCacheStripes]balanceStripe
+
+	// bypass holds the keys whose invalidation failed until their cached value expires
+	bypassMu sync.Mutex
+	bypass   map[string]time.Time
+}
+
+func newBalanceCache(store Store, ttl time.Duration) *balanceCache {
+	return &balanceCache{
+		store:  store,
+		ttl:    ttl,
+		bypass: make(map[string]time.Time),
+	}
+}
+
+func balanceKey(operatorID, playerID string) string {
+	return fmt.Sprintf("wallet:balance:%s:%s", operatorID, playerID)
+}
+
+func (c *balanceCache) stripe(key string) *balanceStripe {
+	hash := fnv.New32a()
+	hash.Write([]byte(key))
+	return &c.stripes[hash.Sum32()%balanceCacheStripes]
+}
+
+func (c *balanceCache) Get(ctx context.Context, operatorID, playerID string, load LoadFunc) (*entities.Player, error) {
+	key := balanceKey(operatorID, playerID)
+	if c.bypassed(key) {
+		metrics.BalanceCacheRequests.WithLabelValues("bypass").Inc()
+		return load(ctx)
+	}
+
+	stripe := c.stripe(key)
+	stripe.mu.Lock()
+	cleanups := stripe.cleanups
+	stripe.mu.Unlock()
+
+	value, ok, err := c.store.Get(ctx, key)
+	if ok && err == nil {
+		// The value may come from a fill that lost against an invalidation
+		stripe.mu.Lock()
+		ok = !stripe.staleWrites() && stripe.cleanups == cleanups
+		stripe.mu.Unlock()
+	}
+	if err != nil {
+		metrics.BalanceCacheRequests.WithLabelValues("error").Inc()
+		zap.L().Warn("Balance cache read failed", zap.String("key", key), zap.Error(err))
+	} else if ok {
+		var player entities.Player
+		if err := json.Unmarshal(value, &player); err == nil {
+			metrics.BalanceCacheRequests.WithLabelValues("hit").Inc()
+			return &player, nil
+		}
+	}
+	if err == nil {
+		metrics.BalanceCacheRequests.WithLabelValues("miss").Inc()
+	}
+
+	stripe.mu.Lock()
+	generation := stripe.generation
+	stripe.mu.Unlock()
+
+	// Callers that arrive after an invalidation do not join a load that started before it.
+	// The load is shared, so it runs detached from the caller that happened to
+	// start it and every caller only stops waiting when its own ctx ends.
+	results := c.group.DoChan(fmt.Sprintf("%s#%d", key, generation), func() (interface{}, error) {
+		loadCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), balanceLoadTimeout)
+		defer cancel()
+		player, err := load(loadCtx)
+		if err != nil {
+			return nil, err
+		}
+		c.fill(loadCtx, key, stripe, generation, player)
+		return player, nil
+	})
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result := <-results:
+		if result.Err != nil {
+			return nil, result.Err
+		}
+		player := *result.Val.(*entities.Player)
+		return &player, nil
+	}
+}
+
+func (c *balanceCache) fill(ctx context.Context, key string, stripe *balanceStripe, generation uint64, player *entities.Player) {
+	value, err := json.Marshal(player)
+	if err != nil {
+		return
+	}
+	stripe.mu.Lock()
+	if stripe.generation != generation {
+		stripe.mu.Unlock()
+		return
+	}
+	if stripe.writes == nil {
+		stripe.writes = make(map[uint64]int)
+	}
+	stripe.writes[generation]++
+	stripe.mu.Unlock()
+
+	if err := c.store.Set(ctx, key, value, c.ttl); err != nil {
+		zap.L().Warn("Balance cache write failed", zap.String("key", key), zap.Error(err))
+	}
+
+	stripe.mu.Lock()
+	stale := stripe.generation != generation
+	stripe.mu.Unlock()
+	// An invalidation ran during the write and may have deleted the key before it was written
+	if stale {
+		c.delete(ctx, key)
+	}
+
+	stripe.mu.Lock()
+	if stripe.writes[generation]--; stripe.writes[generation] == 0 {
+		delete(stripe.writes, generation)
+	}
+	if stale {
+		stripe.cleanups++
+	}
+	stripe.mu.Unlock()
+}
+
+func (c *balanceCache) Invalidate(ctx context.Context, operatorID, playerID string) {
+	key := balanceKey(operatorID, playerID)
+	stripe := c.stripe(key)
+	stripe.mu.Lock()
+	stripe.generation++
+	stripe.mu.Unlock()
+	// The balance already committed, a cancelled request must not leave the old value behind
+	c.delete(context.WithoutCancel(ctx), key)
+}
+
+// delete removes the key from the store, when that fails the key is read
+// through until the entry expires
+func (c *balanceCache) delete(ctx context.Context, key string) {
+	if err := c.store.Delete(ctx, key); err != nil {
+		zap.L().Warn("Balance cache invalidation failed, reading through until the entry expires",
+			zap.String("key", key), zap.Error(err))
+		c.bypassMu.Lock()
+		c.bypass[key] = time.Now().Add(c.ttl)
+		c.bypassMu.Unlock()
+	}
+}
+
+func (c *balanceCache) bypassed(key string) bool {
+	c.bypassMu.Lock()
+	defer c.bypassMu.Unlock()
+	if len(c.bypass) == 0 {
+		return false
+	}
+	now := time.Now()
+	for k, until := range c.bypass {
+		if !now.Before(until) {
+			delete(c.bypass, k)
+		}
+	}
+	_, ok := c.bypass[key]
+	return ok
+}
+
+func (c *balanceCache) Close() error {
+	return c.store.Close()
+}
+
+type nopBalanceCache struct{}
+
+// NewNopBalanceCache returns a cache that keeps nothing and always calls the loader
+func NewNopBalanceCache() IBalanceCache {
+	return nopBalanceCache{}
+}
+
+func (nopBalanceCache) Get(ctx context.Context, operatorID, playerID string, load LoadFunc) (*entities.Player, error) {
+	return load(ctx)
+}
+
+func (nopBalanceCache) Invalidate(ctx context.Context, operatorID, playerID string) {}
+
+func (nopBalanceCache) Close() error {
+	return nil
+}
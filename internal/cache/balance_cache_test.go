@@ -0,0 +1,179 @@
+package cache
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// hookedStore calls the hooks before the calls of the same name, a hook can stop the call
+type hookedStore struct {
+	*MemoryStore
+	beforeSet    func()
+	beforeDelete func()
+}
+
+func (s *hookedStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	s.beforeSet()
+	return s.MemoryStore.Set(ctx, key, value, ttl)
+}
+
+func (s *hookedStore) Delete(ctx context.Context, key string) error {
+	s.beforeDelete()
+	return s.MemoryStore.Delete(ctx, key)
+}
+
+// slowStore delays every call by up to a millisecond, so the calls of concurrent readers and writers interleave
+type slowStore struct {
+	*MemoryStore
+}
+
+func (s slowStore) pause() {
+	time.Sleep(time.Duration(rand.N(1000)) * time.Microsecond)
+}
+
+func (s slowStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	s.pause()
+	return s.MemoryStore.Get(ctx, key)
+}
+
+func (s slowStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	s.pause()
+	return s.MemoryStore.Set(ctx, key, value, ttl)
+}
+
+func (s slowStore) Delete(ctx context.Context, key string) error {
+	s.pause()
+	return s.MemoryStore.Delete(ctx, key)
+}
+
+func loadBalance(balance *atomic.Int64) LoadFunc {
+	return func(ctx context.Context) (*entities.Player, error) {
+		return &entities.Player{OperatorID: "default", ID: "player-1", Balance: float64(balance.Load())}, nil
+	}
+}
+
+func TestBalanceCacheInvalidateDuringFill(t *testing.T) {
+	ctx := context.Background()
+	var balance atomic.Int64
+	balance.Store(100)
+
+	// The first write and the second delete, the one after the stale write,
+	// wait until the test lets them go on
+	var sets, deletes atomic.Int64
+	setStarted, setResume := make(chan struct{}), make(chan struct{})
+	deleteStarted, deleteResume := make(chan struct{}), make(chan struct{})
+	store := &hookedStore{
+		MemoryStore: NewMemoryStore(),
+		beforeSet: func() {
+			if sets.Add(1) == 1 {
+				close(setStarted)
+				<-setResume
+			}
+		},
+		beforeDelete: func() {
+			if deletes.Add(1) == 2 {
+				close(deleteStarted)
+				<-deleteResume
+			}
+		},
+	}
+	balanceCache := newBalanceCache(store, time.Minute)
+
+	// The first read loads 100 and stops before it writes it to the store
+	filled := make(chan *entities.Player)
+	go func() {
+		player, err := balanceCache.Get(ctx, "default", "player-1", loadBalance(&balance))
+		assert.NoError(t, err)
+		filled <- player
+	}()
+	<-setStarted
+
+	// The debit commits and invalidates while the write is under way, the
+	// invalidation does not wait for it
+	balance.Store(90)
+	invalidated := make(chan struct{})
+	go func() {
+		balanceCache.Invalidate(ctx, "default", "player-1")
+		close(invalidated)
+	}()
+	select {
+	case <-invalidated:
+	case <-time.After(time.Second):
+		t.Fatal("invalidation waited for the store write")
+	}
+
+	// The stale 100 is written after the invalidation and is still in the
+	// store until the fill deletes it again, reads must not return it
+	close(setResume)
+	<-deleteStarted
+	value, ok, err := store.MemoryStore.Get(ctx, balanceKey("default", "player-1"))
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Contains(t, string(value), `"balance":100`)
+	player, err := balanceCache.Get(ctx, "default", "player-1", loadBalance(&balance))
+	require.NoError(t, err)
+	assert.Equal(t, 90.0, player.Balance)
+
+	close(deleteResume)
+	assert.Equal(t, 100.0, (<-filled).Balance)
+	_, ok, err = store.MemoryStore.Get(ctx, balanceKey("default", "player-1"))
+	require.NoError(t, err)
+	assert.False(t, ok, "the stale write was deleted")
+
+	player, err = balanceCache.Get(ctx, "default", "player-1", loadBalance(&balance))
+	require.NoError(t, err)
+	assert.Equal(t, 90.0, player.Balance)
+}
+
+func TestBalanceCacheNeverServesInvalidatedBalances(t *testing.T) {
+	ctx := context.Background()
+	balanceCache := newBalanceCache(slowStore{NewMemoryStore()}, time.Minute)
+	// balance only grows, committed is the balance the last finished invalidation was for
+	var balance, committed atomic.Int64
+
+	var writer sync.WaitGroup
+	stop := make(chan struct{})
+	writer.Add(1)
+	go func() {
+		defer writer.Done()
+		for i := int64(1); ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			balance.Store(i)
+			balanceCache.Invalidate(ctx, "default", "player-1")
+			committed.Store(i)
+			time.Sleep(time.Duration(rand.N(500)) * time.Microsecond)
+		}
+	}()
+
+	var readers sync.WaitGroup
+	var stale atomic.Int64
+	for range 8 {
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			for range 300 {
+				floor := committed.Load()
+				player, err := balanceCache.Get(ctx, "default", "player-1", loadBalance(&balance))
+				if err != nil || int64(player.Balance) < floor {
+					stale.Add(1)
+				}
+			}
+		}()
+	}
+	readers.Wait()
+	close(stop)
+	writer.Wait()
+	assert.Zero(t, stale.Load())
+}
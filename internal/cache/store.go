@@ -0,0 +1,51 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Store keeps cached values by key. Get reports a missing or expired key with
+// false and no error, errors are reserved for an unreachable backend.
+type Store interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, key string) error
+	Close() error
+}
+
+type Config struct {
+	// Backend is one of none, memory or redis
+	Backend       string
+	TTL           time.Duration
+	RedisAddr     string
+	RedisPassword string
+	RedisDB       int
+}
+
+// NewBalanceCache creates the balance cache selected in the configuration,
+// none returns a cache that always reads through to the loader
+func NewBalanceCache(cfg Config) (IBalanceCache, error) {
+	var store Store
+	switch strings.ToLower(cfg.Backend) {
+	case "", "none":
+		return NewNopBalanceCache(), nil
+	case "memory":
+		store = NewMemoryStore()
+	case "redis":
+		redisStore, err := NewRedisStore(cfg.RedisAddr, cfg.RedisPassword, cfg.RedisDB)
+		if err != nil {
+			return nil, err
+		}
+		store = redisStore
+	default:
+		return nil, fmt.Errorf("unknown cache backend %q", cfg.Backend)
+	}
+	if cfg.TTL <= 0 {
+		store.Close()
+		return nil, fmt.Errorf("cache ttl must be positive, got %s", cfg.TTL)
+	}
+	return newBalanceCache(store, cfg.TTL), nil
+}
@@ -3,12 +3,40 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 	"go.uber.org/zap"
 )
 
+const (
+	StorageBackendPostgres = "postgres"
+	// StorageBackendMemory keeps everything in process memory, for tests and local development
+	StorageBackendMemory = "memory"
+	// StorageBackendSQLite keeps the data in a SQLite file, for demo and QA environments
+	StorageBackendSQLite = "sqlite"
+)
+
+// DefaultRouteTimeouts are the deadlines of the routes that need more or less
+// time than REQUEST_TIMEOUT_MS, ROUTE_TIMEOUTS_MS overrides single routes
+var DefaultRouteTimeouts = map[string]time.Duration{
+	"/event":                        5 * time.Second,
+	"/wallet/{player_id}":           2 * time.Second,
+	"/wallet/{player_id}/stream":    0,
+	"/wallet/{player_id}/statement": 10 * time.Minute,
+	"/admin/reconciliation/runs":    5 * time.Minute,
+	"/admin/statement-imports":      2 * time.Minute,
+}
+
 type Config struct {
+	// StorageBackend is postgres, sqlite or memory, the Postgres settings are only required for postgres
+	StorageBackend string
+	// SQLitePath is the database file of the sqlite backend, it is created when missing
+	SQLitePath string
+	// MigrateOnStartup applies pending migrations on startup, otherwise they are only checked
+	MigrateOnStartup bool
 	PostgresHost     string
 	PostgresPort     string
 	PostgresUser     string
@@ -17,18 +45,215 @@ type Config struct {
 	Debug            bool
 	ApplicationPort  string
 	LogLevel         string
+
+	// ProviderSecrets maps provider IDs to the shared secrets used to sign /event requests
+	ProviderSecrets map[string]string
+	// ProviderOperators maps provider IDs to the operator their events belong to
+	ProviderOperators map[string]string
+	// DefaultOperatorFallback lets unmapped providers act for the default
+	// operator, without it their requests are rejected
+	DefaultOperatorFallback bool
+	// SignatureWindow is the maximum allowed clock skew of a signed request and
+	// also how long nonces are remembered for replay protection
+	SignatureWindow time.Duration
+	// BootstrapAdminAPIKey is stored as an admin key on startup so the first keys can be issued
+	BootstrapAdminAPIKey string
+
+	// Token bucket rates are requests per second, bursts are bucket sizes
+	RateLimitClientRPS   float64
+	RateLimitClientBurst int
+	RateLimitPlayerRPS   float64
+	RateLimitPlayerBurst int
+	// MaxInFlightWrites caps concurrent /event requests, each of them holds a database transaction
+	MaxInFlightWrites int
+	InFlightWriteWait time.Duration
+
+	// TracingExporter is one of none, otlp, stdout or file
+	TracingExporter     string
+	TracingOTLPEndpoint string
+	TracingOTLPInsecure bool
+	TracingFilePath     string
+	TracingSampleRatio  float64
+
+	// RequestTimeout is the deadline of every request without a route specific one.
+	// RouteTimeouts is keyed by route template, e.g. /wallet/{player_id}, zero disables the deadline.
+	// It holds DefaultRouteTimeouts with the configured routes replaced.
+	RequestTimeout time.Duration
+	RouteTimeouts  map[string]time.Duration
+
+	// StreamMaxSubscribers caps open balance streams per replica
+	StreamHeartbeat      time.Duration
+	StreamMaxSubscribers int
+
+	// TxIsolationLevel is used for wallet transactions, aborted transactions are
+	// retried up to TxMaxAttempts times with a jittered backoff between the bounds
+	TxIsolationLevel string
+	TxMaxAttempts    int
+	TxRetryBaseDelay time.Duration
+	TxRetryMaxDelay  time.Duration
+	// BalanceConcurrency is locking or optimistic, optimistic balance updates
+	// that lose to another transaction are retried like aborted transactions
+	BalanceConcurrency string
+
+	// EventPublisher is one of log, file, kafka or nats and receives the outbox events
+	EventPublisher       string
+	EventsFilePath       string
+	KafkaBrokers         []string
+	KafkaTopic           string
+	NATSURL              string
+	NATSSubject          string
+	OutboxPollInterval   time.Duration
+	OutboxBatchSize      int
+	OutboxMaxAttempts    int
+	OutboxRetryBaseDelay time.Duration
+	OutboxRetryMaxDelay  time.Duration
+
+	WebhookPollInterval   time.Duration
+	WebhookBatchSize      int
+	WebhookConcurrency    int
+	WebhookTimeout        time.Duration
+	WebhookMaxAttempts    int
+	WebhookRetryBaseDelay time.Duration
+	WebhookRetryMaxDelay  time.Duration
+
+	// ReconciliationInterval is the time between scheduled reconciliation runs, zero disables them
+	ReconciliationInterval time.Duration
+
+	// StatementFormatsFile is the JSON file with the statement format of each provider
+	StatementFormatsFile string
+	// StatementMaxBytes caps the size of an uploaded provider statement
+	StatementMaxBytes int64
+
+	// GGRRollupInterval is the time between GGR rollups, zero disables them
+	GGRRollupInterval time.Duration
+	// GGRRollupLookbackDays is the number of days every GGR rollup recomputes
+	GGRRollupLookbackDays int
+	// ReportMaxDays caps the days a single report covers
+	ReportMaxDays int
+
+	// BalanceCheckpointInterval is the time between balance checkpoint runs, zero disables them
+	BalanceCheckpointInterval time.Duration
+	// BalanceCheckpointLag keeps checkpoints behind now so uncommitted transactions are not skipped
+	BalanceCheckpointLag time.Duration
+
+	// TransactionPartitionInterval is the time between transaction partition
+	// maintenance runs, which create the coming months and archive old ones
+	TransactionPartitionInterval    time.Duration
+	TransactionPartitionMonthsAhead int
+	// TransactionRetentionMonths is the number of full months kept in the database, zero disables archiving
+	TransactionRetentionMonths int
+	// TransactionArchiveDir holds the compressed files of the archived months
+	TransactionArchiveDir string
+
+	// BalanceCacheBackend is one of none, memory or redis and caches player balances
+	BalanceCacheBackend string
+	BalanceCacheTTL     time.Duration
+	RedisAddr           string
+	RedisPassword       string
+	RedisDB             int
+
+	// ReplicaDSNs are postgres:// URLs of read replicas, reads outside of transactions go to them
+	ReplicaDSNs          []string
+	ReplicaMaxLag        time.Duration
+	ReplicaCheckInterval time.Duration
+	// ReadYourWritesWindow keeps the reads of a player on the primary after its writes, zero turns it off
+	ReadYourWritesWindow time.Duration
 }
 
 func NewConfig() *Config {
+	storageBackend := ParseEnv("STORAGE_BACKEND", false, StorageBackendPostgres)
+	postgresRequired := storageBackend == StorageBackendPostgres
 	return &Config{
-		PostgresHost:     ParseEnv("POSTGRES_HOST", true, "localhost"),
-		PostgresPort:     ParseEnv("POSTGRES_PORT", true, "5432"),
-		PostgresUser:     ParseEnv("POSTGRES_USER", true, "postgres"),
-		PostgresPassword: ParseEnv("POSTGRES_PASSWORD", true, "postgres"),
-		PostgresDB:       ParseEnv("POSTGRES_DB", true, "casino_wallet"),
+		StorageBackend:   storageBackend,
+		SQLitePath:       ParseEnv("SQLITE_PATH", false, "casino_wallet.db"),
+		MigrateOnStartup: ParseEnv("MIGRATE_ON_STARTUP", false, strconv.FormatBool(storageBackend == StorageBackendSQLite)) == "true",
+		PostgresHost:     ParseEnv("POSTGRES_HOST", postgresRequired, "localhost"),
+		PostgresPort:     ParseEnv("POSTGRES_PORT", postgresRequired, "5432"),
+		PostgresUser:     ParseEnv("POSTGRES_USER", postgresRequired, "postgres"),
+		PostgresPassword: ParseEnv("POSTGRES_PASSWORD", postgresRequired, "postgres"),
+		PostgresDB:       ParseEnv("POSTGRES_DB", postgresRequired, "casino_wallet"),
 		Debug:            ParseEnv("DEBUG", false, "false") == "true",
 		ApplicationPort:  ParseEnv("APPLICATION_PORT", false, "8080"),
 		LogLevel:         ParseEnv("LOG_LEVEL", false, "info"),
+
+		ProviderSecrets:         ParseKeyValueList(ParseEnv("PROVIDER_SECRETS", false, "")),
+		ProviderOperators:       ParseKeyValueList(ParseEnv("PROVIDER_OPERATORS", false, "")),
+		DefaultOperatorFallback: ParseEnv("DEFAULT_OPERATOR_FALLBACK", false, "false") == "true",
+		SignatureWindow:         ParseDurationSeconds(ParseEnv("SIGNATURE_WINDOW_SECONDS", false, "300")),
+		BootstrapAdminAPIKey:    ParseEnv("BOOTSTRAP_ADMIN_API_KEY", false, ""),
+
+		RateLimitClientRPS:   ParseFloat(ParseEnv("RATE_LIMIT_CLIENT_RPS", false, "200")),
+		RateLimitClientBurst: ParseInt(ParseEnv("RATE_LIMIT_CLIENT_BURST", false, "400")),
+		RateLimitPlayerRPS:   ParseFloat(ParseEnv("RATE_LIMIT_PLAYER_RPS", false, "10")),
+		RateLimitPlayerBurst: ParseInt(ParseEnv("RATE_LIMIT_PLAYER_BURST", false, "20")),
+		MaxInFlightWrites:    ParseInt(ParseEnv("MAX_INFLIGHT_WRITES", false, "50")),
+		InFlightWriteWait:    ParseDurationMillis(ParseEnv("INFLIGHT_WRITE_WAIT_MS", false, "100")),
+
+		TracingExporter:     ParseEnv("TRACING_EXPORTER", false, "none"),
+		TracingOTLPEndpoint: ParseEnv("TRACING_OTLP_ENDPOINT", false, "localhost:4318"),
+		TracingOTLPInsecure: ParseEnv("TRACING_OTLP_INSECURE", false, "false") == "true",
+		TracingFilePath:     ParseEnv("TRACING_FILE_PATH", false, "traces.jsonl"),
+		TracingSampleRatio:  ParseFloat(ParseEnv("TRACING_SAMPLE_RATIO", false, "1")),
+
+		RequestTimeout:       ParseDurationMillis(ParseEnv("REQUEST_TIMEOUT_MS", false, "10000")),
+		RouteTimeouts:        routeTimeouts(ParseDurationMillisList(ParseEnv("ROUTE_TIMEOUTS_MS", false, ""))),
+		StreamHeartbeat:      ParseDurationSeconds(ParseEnv("STREAM_HEARTBEAT_SECONDS", false, "15")),
+		StreamMaxSubscribers: ParseInt(ParseEnv("STREAM_MAX_SUBSCRIBERS", false, "10000")),
+
+		TxIsolationLevel: strings.ToUpper(ParseEnv("TX_ISOLATION_LEVEL", false, "READ COMMITTED")),
+		TxMaxAttempts:    ParseInt(ParseEnv("TX_MAX_ATTEMPTS", false, "5")),
+		TxRetryBaseDelay: ParseDurationMillis(ParseEnv("TX_RETRY_BASE_DELAY_MS", false, "10")),
+		TxRetryMaxDelay:  ParseDurationMillis(ParseEnv("TX_RETRY_MAX_DELAY_MS", false, "500")),
+
+		BalanceConcurrency: ParseEnv("BALANCE_CONCURRENCY", false, "locking"),
+
+		EventPublisher:       ParseEnv("EVENT_PUBLISHER", false, "log"),
+		EventsFilePath:       ParseEnv("EVENTS_FILE_PATH", false, "events.jsonl"),
+		KafkaBrokers:         ParseList(ParseEnv("KAFKA_BROKERS", false, "localhost:9092")),
+		KafkaTopic:           ParseEnv("KAFKA_TOPIC", false, "wallet.transactions"),
+		NATSURL:              ParseEnv("NATS_URL", false, "nats://localhost:4222"),
+		NATSSubject:          ParseEnv("NATS_SUBJECT", false, "wallet"),
+		OutboxPollInterval:   ParseDurationMillis(ParseEnv("OUTBOX_POLL_INTERVAL_MS", false, "500")),
+		OutboxBatchSize:      ParseInt(ParseEnv("OUTBOX_BATCH_SIZE", false, "100")),
+		OutboxMaxAttempts:    ParseInt(ParseEnv("OUTBOX_MAX_ATTEMPTS", false, "10")),
+		OutboxRetryBaseDelay: ParseDurationMillis(ParseEnv("OUTBOX_RETRY_BASE_DELAY_MS", false, "1000")),
+		OutboxRetryMaxDelay:  ParseDurationMillis(ParseEnv("OUTBOX_RETRY_MAX_DELAY_MS", false, "300000")),
+
+		WebhookPollInterval:   ParseDurationMillis(ParseEnv("WEBHOOK_POLL_INTERVAL_MS", false, "1000")),
+		WebhookBatchSize:      ParseInt(ParseEnv("WEBHOOK_BATCH_SIZE", false, "50")),
+		WebhookConcurrency:    ParseInt(ParseEnv("WEBHOOK_CONCURRENCY", false, "8")),
+		WebhookTimeout:        ParseDurationMillis(ParseEnv("WEBHOOK_TIMEOUT_MS", false, "5000")),
+		WebhookMaxAttempts:    ParseInt(ParseEnv("WEBHOOK_MAX_ATTEMPTS", false, "8")),
+		WebhookRetryBaseDelay: ParseDurationMillis(ParseEnv("WEBHOOK_RETRY_BASE_DELAY_MS", false, "10000")),
+		WebhookRetryMaxDelay:  ParseDurationMillis(ParseEnv("WEBHOOK_RETRY_MAX_DELAY_MS", false, "3600000")),
+
+		ReconciliationInterval: ParseDurationSeconds(ParseEnv("RECONCILIATION_INTERVAL_SECONDS", false, "3600")),
+
+		StatementFormatsFile: ParseEnv("STATEMENT_FORMATS_FILE", false, ""),
+		StatementMaxBytes:    int64(ParseInt(ParseEnv("STATEMENT_MAX_BYTES", false, "33554432"))),
+
+		GGRRollupInterval:     ParseDurationSeconds(ParseEnv("GGR_ROLLUP_INTERVAL_SECONDS", false, "300")),
+		GGRRollupLookbackDays: ParseInt(ParseEnv("GGR_ROLLUP_LOOKBACK_DAYS", false, "2")),
+		ReportMaxDays:         ParseInt(ParseEnv("REPORT_MAX_DAYS", false, "366")),
+
+		BalanceCheckpointInterval: ParseDurationSeconds(ParseEnv("BALANCE_CHECKPOINT_INTERVAL_SECONDS", false, "3600")),
+		BalanceCheckpointLag:      ParseDurationSeconds(ParseEnv("BALANCE_CHECKPOINT_LAG_SECONDS", false, "300")),
+
+		TransactionPartitionInterval:    ParseDurationSeconds(ParseEnv("TRANSACTION_PARTITION_INTERVAL_SECONDS", false, "3600")),
+		TransactionPartitionMonthsAhead: ParseInt(ParseEnv("TRANSACTION_PARTITION_MONTHS_AHEAD", false, "3")),
+		TransactionRetentionMonths:      ParseInt(ParseEnv("TRANSACTION_RETENTION_MONTHS", false, "0")),
+		TransactionArchiveDir:           ParseEnv("TRANSACTION_ARCHIVE_DIR", false, "archive"),
+
+		BalanceCacheBackend: ParseEnv("BALANCE_CACHE", false, "none"),
+		BalanceCacheTTL:     ParseDurationSeconds(ParseEnv("BALANCE_CACHE_TTL_SECONDS", false, "30")),
+		RedisAddr:           ParseEnv("REDIS_ADDR", false, "localhost:6379"),
+		RedisPassword:       ParseEnv("REDIS_PASSWORD", false, ""),
+		RedisDB:             ParseInt(ParseEnv("REDIS_DB", false, "0")),
+
+		ReplicaDSNs:          ParseList(ParseEnv("REPLICA_DSNS", false, "")),
+		ReplicaMaxLag:        ParseDurationMillis(ParseEnv("REPLICA_MAX_LAG_MS", false, "1000")),
+		ReplicaCheckInterval: ParseDurationMillis(ParseEnv("REPLICA_CHECK_INTERVAL_MS", false, "1000")),
+		ReadYourWritesWindow: ParseDurationMillis(ParseEnv("READ_YOUR_WRITES_WINDOW_MS", false, "5000")),
 	}
 }
 
@@ -49,3 +274,91 @@ func ParseEnv(key string, required bool, dft string) string {
 	}
 	return value
 }
+
+// ParseKeyValueList parses values in the form "key1:value1,key2:value2"
+func ParseKeyValueList(value string) map[string]string {
+	result := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, val, found := strings.Cut(pair, ":")
+		if !found || key == "" || val == "" {
+			// Values may be secrets, so only the key is logged
+			zap.L().Panic("Invalid key value pair in environment variable",
+				zap.String("key", key),
+			)
+		}
+		result[strings.TrimSpace(key)] = strings.TrimSpace(val)
+	}
+	return result
+}
+
+// ParseList parses comma separated values
+func ParseList(value string) []string {
+	var result []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// ParseDurationSeconds parses a number of seconds into a time.Duration
+func ParseDurationSeconds(value string) time.Duration {
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds < 0 {
+		zap.L().Panic("Invalid duration in environment variable",
+			zap.String("value", value),
+		)
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// ParseDurationMillis parses a number of milliseconds into a time.Duration
+func ParseDurationMillis(value string) time.Duration {
+	return time.Duration(ParseInt(value)) * time.Millisecond
+}
+
+// ParseDurationMillisList parses values in the form "key1:millis1,key2:millis2"
+func ParseDurationMillisList(value string) map[string]time.Duration {
+	result := make(map[string]time.Duration)
+	for key, millis := range ParseKeyValueList(value) {
+		result[key] = ParseDurationMillis(millis)
+	}
+	return result
+}
+
+// routeTimeouts returns DefaultRouteTimeouts with the given routes replaced
+func routeTimeouts(overrides map[string]time.Duration) map[string]time.Duration {
+	timeouts := make(map[string]time.Duration, len(DefaultRouteTimeouts)+len(overrides))
+	for route, timeout := range DefaultRouteTimeouts {
+		timeouts[route] = timeout
+	}
+	for route, timeout := range overrides {
+		timeouts[route] = timeout
+	}
+	return timeouts
+}
+
+func ParseInt(value string) int {
+	number, err := strconv.Atoi(value)
+	if err != nil || number < 0 {
+		zap.L().Panic("Invalid integer in environment variable",
+			zap.String("value", value),
+		)
+	}
+	return number
+}
+
+func ParseFloat(value string) float64 {
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || number < 0 {
+		zap.L().Panic("Invalid number in environment variable",
+			zap.String("value", value),
+		)
+	}
+	return number
+}
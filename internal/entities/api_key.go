@@ -0,0 +1,58 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/models"
+	"gorm.io/gorm"
+)
+
+type APIKeyRole string
+
+const (
+	APIKeyRoleProvider APIKeyRole = "provider"
+	APIKeyRoleSupport  APIKeyRole = "support"
+	APIKeyRoleFinance  APIKeyRole = "finance"
+	APIKeyRoleAdmin    APIKeyRole = "admin"
+)
+
+func (r APIKeyRole) IsValid() bool {
+	switch r {
+	case APIKeyRoleProvider, APIKeyRoleSupport, APIKeyRoleFinance, APIKeyRoleAdmin:
+		return true
+	}
+	return false
+}
+
+// APIKey only stores the SHA-256 hash of the key, the plain key is shown once when issued
+type APIKey struct {
+	ID         uint64         `json:"id" gorm:"primaryKey;AUTO_INCREMENT"`
+	OperatorID string         `json:"operator_id" gorm:"index"`
+	Name       string         `json:"name"`
+	Prefix     string         `json:"prefix"`
+	KeyHash    string         `json:"-" gorm:"uniqueIndex"`
+	Role       APIKeyRole     `json:"role"`
+	RevokedAt  *time.Time     `json:"revoked_at"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+func (k *APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+func (k *APIKey) ToApiResponse() *models.APIKeyResponse {
+	response := &models.APIKeyResponse{
+		ID:         k.ID,
+		OperatorID: k.OperatorID,
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		Role:       string(k.Role),
+		CreatedAt:  k.CreatedAt.Format(time.RFC3339),
+	}
+	if k.RevokedAt != nil {
+		response.RevokedAt = k.RevokedAt.Format(time.RFC3339)
+	}
+	return response
+}
@@ -0,0 +1,50 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/models"
+)
+
+// BalanceCheckpoint is the balance of a wallet after every transaction stored
+// at or before CheckpointAt. Historical balances are replayed from the latest
+// checkpoint instead of the first transaction.
+type BalanceCheckpoint struct {
+	OperatorID        string    `json:"operator_id" gorm:"primaryKey"`
+	PlayerID          string    `json:"player_id" gorm:"primaryKey"`
+	CheckpointAt      time.Time `json:"checkpoint_at" gorm:"primaryKey"`
+	Balance           float64   `json:"balance"`
+	LastTransactionID *uint64   `json:"last_transaction_id"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+// HistoricalBalance is the balance of a wallet at a past time. CheckpointAt is
+// nil when the balance was replayed from the opening balance, LastTransaction
+// is nil when no transaction was stored at or before At.
+type HistoricalBalance struct {
+	PlayerID          string       `json:"player_id"`
+	WalletID          string       `json:"wallet_id"`
+	Currency          string       `json:"currency"`
+	At                time.Time    `json:"at" gorm:"-"`
+	Balance           float64      `json:"balance"`
+	CheckpointAt      *time.Time   `json:"checkpoint_at"`
+	LastTransactionID *uint64      `json:"-"`
+	LastTransaction   *Transaction `json:"last_transaction" gorm:"-"`
+}
+
+func (b *HistoricalBalance) ToApiResponse() *models.HistoricalBalanceResponse {
+	response := &models.HistoricalBalanceResponse{
+		PlayerID: b.PlayerID,
+		WalletID: b.WalletID,
+		Currency: b.Currency,
+		At:       b.At.Format(time.RFC3339Nano),
+		Balance:  b.Balance,
+	}
+	if b.CheckpointAt != nil {
+		response.CheckpointAt = b.CheckpointAt.Format(time.RFC3339Nano)
+	}
+	if b.LastTransaction != nil {
+		response.LastTransaction = b.LastTransaction.ToApiResponse()
+	}
+	return response
+}
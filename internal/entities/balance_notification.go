@@ -0,0 +1,24 @@
+package entities
+
+import "time"
+
+// BalanceNotificationChannel is the Postgres NOTIFY channel balance changes are
+// published on. Every replica listens on it and forwards the notifications to
+// the balance streams of its clients.
+const BalanceNotificationChannel = "wallet_balance"
+
+// BalanceNotification is sent with NOTIFY inside the wallet transaction, so
+// listeners only receive it once the change is committed. NOTIFY payloads are
+// limited to 8000 bytes, keep it small.
+type BalanceNotification struct {
+	OperatorID      string          `json:"operator_id"`
+	PlayerID        string          `json:"player_id"`
+	WalletID        string          `json:"wallet_id"`
+	Balance         float64         `json:"balance"`
+	Currency        string          `json:"currency"`
+	TransactionID   uint64          `json:"transaction_id"`
+	TransactionType TransactionType `json:"transaction_type"`
+	RoundID         string          `json:"round_id"`
+	Amount          float64         `json:"amount"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+}
@@ -0,0 +1,68 @@
+package entities
+
+import (
+	"slices"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/models"
+	"gorm.io/gorm"
+)
+
+// DefaultOperatorID owns all data that existed before multi-tenancy was introduced
+const DefaultOperatorID = "default"
+
+type BalanceBucket string
+
+const (
+	// BalanceBucketCash is the only balance players hold today, bonus buckets
+	// can be added to the spend order once they exist
+	BalanceBucketCash BalanceBucket = "cash"
+)
+
+func (b BalanceBucket) IsValid() bool {
+	return b == BalanceBucketCash
+}
+
+// Operator is a brand running on this deployment. Every player, transaction
+// and API key belongs to exactly one operator.
+type Operator struct {
+	ID         string          `json:"id" gorm:"primaryKey"`
+	Name       string          `json:"name"`
+	Currencies []string        `json:"currencies" gorm:"serializer:json"`
+	MinBet     float64         `json:"min_bet"`
+	MaxBet     float64         `json:"max_bet"`
+	SpendOrder []BalanceBucket `json:"spend_order" gorm:"serializer:json"`
+	CreatedAt  time.Time       `json:"created_at"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt  `json:"-" gorm:"index"`
+}
+
+func (o *Operator) SupportsCurrency(currency string) bool {
+	return slices.Contains(o.Currencies, currency)
+}
+
+// IsBetAllowed checks the bet amount against the operator limits, a zero limit means no limit
+func (o *Operator) IsBetAllowed(amount float64) bool {
+	if o.MinBet > 0 && amount < o.MinBet {
+		return false
+	}
+	if o.MaxBet > 0 && amount > o.MaxBet {
+		return false
+	}
+	return true
+}
+
+func (o *Operator) ToApiResponse() *models.OperatorResponse {
+	spendOrder := make([]string, len(o.SpendOrder))
+	for i, bucket := range o.SpendOrder {
+		spendOrder[i] = string(bucket)
+	}
+	return &models.OperatorResponse{
+		ID:         o.ID,
+		Name:       o.Name,
+		Currencies: o.Currencies,
+		MinBet:     o.MinBet,
+		MaxBet:     o.MaxBet,
+		SpendOrder: spendOrder,
+	}
+}
@@ -0,0 +1,93 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	EventTypeTransactionBet    = "transaction.bet"
+	EventTypeTransactionResult = "transaction.result"
+)
+
+// OutboxEvent is written together with the change it describes and published
+// afterwards by the outbox relay. Events with the same AggregateKey are
+// published in ID order.
+type OutboxEvent struct {
+	ID            uint64          `json:"id" gorm:"primaryKey;AUTO_INCREMENT"`
+	OperatorID    string          `json:"operator_id"`
+	AggregateKey  string          `json:"aggregate_key"`
+	EventType     string          `json:"event_type"`
+	Payload       json.RawMessage `json:"payload" gorm:"type:jsonb"`
+	Attempts      int             `json:"attempts"`
+	LastError     *string         `json:"last_error"`
+	NextAttemptAt time.Time       `json:"next_attempt_at"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+// OutboxDeadLetter keeps an event the relay gave up on, so it can be inspected and replayed
+type OutboxDeadLetter struct {
+	ID             uint64          `json:"id" gorm:"primaryKey;AUTO_INCREMENT"`
+	OutboxEventID  uint64          `json:"outbox_event_id"`
+	OperatorID     string          `json:"operator_id"`
+	AggregateKey   string          `json:"aggregate_key"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload" gorm:"type:jsonb"`
+	Attempts       int             `json:"attempts"`
+	LastError      *string         `json:"last_error"`
+	EventCreatedAt time.Time       `json:"event_created_at"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+// TransactionEvent is the payload published for every processed bet and result
+type TransactionEvent struct {
+	TransactionID uint64          `json:"transaction_id"`
+	OperatorID    string          `json:"operator_id"`
+	ReqID         string          `json:"req_id"`
+	PlayerID      string          `json:"player_id"`
+	WalletID      string          `json:"wallet_id"`
+	RoundID       string          `json:"round_id"`
+	SessionID     string          `json:"session_id"`
+	GameCode      string          `json:"game_code"`
+	Type          TransactionType `json:"type"`
+	Amount        float64         `json:"amount"`
+	Currency      string          `json:"currency"`
+	BalanceAfter  float64         `json:"balance_after"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+// NewTransactionOutboxEvent builds the outbox event of a stored transaction,
+// it is keyed by wallet so consumers see the wallet's events in order
+func NewTransactionOutboxEvent(transaction *Transaction, balanceAfter float64) (*OutboxEvent, error) {
+	payload, err := json.Marshal(TransactionEvent{
+		TransactionID: transaction.ID,
+		OperatorID:    transaction.OperatorID,
+		ReqID:         transaction.ReqID,
+		PlayerID:      transaction.PlayerID,
+		WalletID:      transaction.WalletID,
+		RoundID:       transaction.RoundID,
+		SessionID:     transaction.SessionID,
+		GameCode:      transaction.GameCode,
+		Type:          transaction.Type,
+		Amount:        transaction.Amount,
+		Currency:      transaction.Currency,
+		BalanceAfter:  balanceAfter,
+		CreatedAt:     transaction.CreatedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	eventType := EventTypeTransactionBet
+	if transaction.Type == TransactionTypeResult {
+		eventType = EventTypeTransactionResult
+	}
+	return &OutboxEvent{
+		OperatorID:    transaction.OperatorID,
+		AggregateKey:  transaction.WalletID,
+		EventType:     eventType,
+		Payload:       payload,
+		NextAttemptAt: transaction.CreatedAt,
+		CreatedAt:     transaction.CreatedAt,
+	}, nil
+}
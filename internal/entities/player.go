@@ -7,21 +7,109 @@ import (
 	"gorm.io/gorm"
 )
 
+// PlayerStatus is the account status of a player
+type PlayerStatus string
+
+const (
+	PlayerStatusActive    PlayerStatus = "active"
+	PlayerStatusSuspended PlayerStatus = "suspended"
+	PlayerStatusClosed    PlayerStatus = "closed"
+)
+
+func (s PlayerStatus) IsValid() bool {
+	switch s {
+	case PlayerStatusActive, PlayerStatusSuspended, PlayerStatusClosed:
+		return true
+	}
+	return false
+}
+
 type Player struct {
-	ID        string         `json:"id" gorm:"primaryKey"`
-	WalletID  string         `json:"wallet_id" gorm:"uniqueIndex"`
-	Balance   float64        `json:"balance"`
-	Currency  string         `json:"currency"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+	OperatorID string  `json:"operator_id" gorm:"primaryKey;uniqueIndex:players_operator_wallet_key"`
+	ID         string  `json:"id" gorm:"primaryKey"`
+	WalletID   string  `json:"wallet_id" gorm:"uniqueIndex:players_operator_wallet_key"`
+	Balance    float64 `json:"balance"`
+	// InitialBalance is the opening balance, reconciliation replays the transactions on top of it
+	InitialBalance float64        `json:"initial_balance"`
+	Currency       string         `json:"currency"`
+	Status         PlayerStatus   `json:"status" gorm:"default:active"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `json:"-" gorm:"index"`
+	// Version is incremented by every balance change, optimistic updates are conditional on it
+	Version int64 `json:"-"`
 }
 
 func (p *Player) ToApiResponse() *models.PlayerResponse {
 	return &models.PlayerResponse{
-		ID:       p.ID,
-		WalletID: p.WalletID,
-		Balance:  p.Balance,
-		Currency: p.Currency,
+		ID:        p.ID,
+		WalletID:  p.WalletID,
+		Balance:   p.Balance,
+		Currency:  p.Currency,
+		Status:    string(p.Status),
+		CreatedAt: p.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// PlayerSortField is a column the players listing can be ordered by
+type PlayerSortField string
+
+const (
+	PlayerSortCreatedAt PlayerSortField = "created_at"
+	PlayerSortBalance   PlayerSortField = "balance"
+	PlayerSortID        PlayerSortField = "id"
+)
+
+func (f PlayerSortField) IsValid() bool {
+	switch f {
+	case PlayerSortCreatedAt, PlayerSortBalance, PlayerSortID:
+		return true
+	}
+	return false
+}
+
+// PlayerOrder orders the players listing, the id breaks ties in the same direction
+type PlayerOrder struct {
+	Field      PlayerSortField
+	Descending bool
+}
+
+// PlayerCursor is the position after the last player of a page, players are
+// ordered by the sort field and then by id
+type PlayerCursor struct {
+	CreatedAt time.Time
+	Balance   float64
+	ID        string
+}
+
+// PlayerListFilter selects the players of one operator, the zero value of a
+// field does not filter
+type PlayerListFilter struct {
+	OperatorID  string
+	Currency    string
+	Status      PlayerStatus
+	MinBalance  *float64
+	MaxBalance  *float64
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+}
+
+// PlayerPage is one page of the players listing, NextCursor is empty on the last page
+type PlayerPage struct {
+	Players    []*Player
+	NextCursor string
+	// TotalCount is only set when the count was asked for
+	TotalCount *int64
+}
+
+func (p *PlayerPage) ToApiResponse() *models.AllPlayersResponse {
+	players := make([]*models.PlayerResponse, len(p.Players))
+	for i, player := range p.Players {
+		players[i] = player.ToApiResponse()
+	}
+	return &models.AllPlayersResponse{
+		Players:    players,
+		NextCursor: p.NextCursor,
+		TotalCount: p.TotalCount,
 	}
 }
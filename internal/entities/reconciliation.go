@@ -0,0 +1,178 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/models"
+)
+
+// EventTypeBalanceAdjusted is published when an approved correction changed a wallet balance
+const EventTypeBalanceAdjusted = "balance.adjusted"
+
+type ReconciliationTrigger string
+
+const (
+	ReconciliationTriggerAPI      ReconciliationTrigger = "api"
+	ReconciliationTriggerCommand  ReconciliationTrigger = "command"
+	ReconciliationTriggerSchedule ReconciliationTrigger = "schedule"
+)
+
+type DiscrepancyStatus string
+
+const (
+	DiscrepancyStatusOpen DiscrepancyStatus = "open"
+	// DiscrepancyStatusCorrected discrepancies were fixed by an approved balance adjustment
+	DiscrepancyStatusCorrected DiscrepancyStatus = "corrected"
+	// DiscrepancyStatusResolved discrepancies had no drift left when a correction was approved
+	DiscrepancyStatusResolved DiscrepancyStatus = "resolved"
+)
+
+func (s DiscrepancyStatus) IsValid() bool {
+	switch s {
+	case DiscrepancyStatusOpen, DiscrepancyStatusCorrected, DiscrepancyStatusResolved:
+		return true
+	}
+	return false
+}
+
+// ReconciliationRun is one check of the stored balances against the transactions.
+// OperatorID is nil when the run covered every operator.
+type ReconciliationRun struct {
+	ID               uint64                      `json:"id" gorm:"primaryKey;AUTO_INCREMENT"`
+	OperatorID       *string                     `json:"operator_id"`
+	TriggeredBy      ReconciliationTrigger       `json:"triggered_by"`
+	WalletsChecked   int                         `json:"wallets_checked"`
+	DiscrepancyCount int                         `json:"discrepancy_count"`
+	StartedAt        time.Time                   `json:"started_at"`
+	FinishedAt       time.Time                   `json:"finished_at"`
+	Discrepancies    []ReconciliationDiscrepancy `json:"discrepancies" gorm:"foreignKey:RunID"`
+}
+
+func (r *ReconciliationRun) ToApiResponse() *models.ReconciliationRunResponse {
+	discrepancies := make([]*models.ReconciliationDiscrepancyResponse, len(r.Discrepancies))
+	for i := range r.Discrepancies {
+		discrepancies[i] = r.Discrepancies[i].ToApiResponse()
+	}
+	return &models.ReconciliationRunResponse{
+		ID:               r.ID,
+		TriggeredBy:      string(r.TriggeredBy),
+		WalletsChecked:   int64(r.WalletsChecked),
+		DiscrepancyCount: int64(r.DiscrepancyCount),
+		StartedAt:        r.StartedAt.Format(time.RFC3339),
+		FinishedAt:       r.FinishedAt.Format(time.RFC3339),
+		Discrepancies:    discrepancies,
+	}
+}
+
+// ReconciliationDiscrepancy is a wallet whose stored balance differs from the
+// balance its transactions add up to. TransactionIDs lists the transactions
+// that look wrong on their own or were stored after the wallet was last found
+// consistent.
+type ReconciliationDiscrepancy struct {
+	ID              uint64            `json:"id" gorm:"primaryKey;AUTO_INCREMENT"`
+	RunID           uint64            `json:"run_id"`
+	OperatorID      string            `json:"operator_id"`
+	PlayerID        string            `json:"player_id"`
+	WalletID        string            `json:"wallet_id"`
+	Currency        string            `json:"currency"`
+	InitialBalance  float64           `json:"initial_balance"`
+	ExpectedBalance float64           `json:"expected_balance"`
+	ActualBalance   float64           `json:"actual_balance"`
+	Drift           float64           `json:"drift"`
+	TransactionIDs  []uint64          `json:"transaction_ids" gorm:"serializer:json"`
+	Status          DiscrepancyStatus `json:"status"`
+	ResolvedBy      *string           `json:"resolved_by"`
+	ResolvedAt      *time.Time        `json:"resolved_at"`
+	CreatedAt       time.Time         `json:"created_at"`
+}
+
+func (d *ReconciliationDiscrepancy) ToApiResponse() *models.ReconciliationDiscrepancyResponse {
+	response := &models.ReconciliationDiscrepancyResponse{
+		ID:              d.ID,
+		RunID:           d.RunID,
+		PlayerID:        d.PlayerID,
+		WalletID:        d.WalletID,
+		Currency:        d.Currency,
+		InitialBalance:  d.InitialBalance,
+		ExpectedBalance: d.ExpectedBalance,
+		ActualBalance:   d.ActualBalance,
+		Drift:           d.Drift,
+		TransactionIds:  d.TransactionIDs,
+		Status:          string(d.Status),
+		CreatedAt:       d.CreatedAt.Format(time.RFC3339),
+	}
+	if response.TransactionIds == nil {
+		response.TransactionIds = []uint64{}
+	}
+	if d.ResolvedBy != nil {
+		response.ResolvedBy = *d.ResolvedBy
+	}
+	if d.ResolvedAt != nil {
+		response.ResolvedAt = d.ResolvedAt.Format(time.RFC3339)
+	}
+	return response
+}
+
+// WalletBalanceCheck is the stored and the recomputed balance of one wallet
+type WalletBalanceCheck struct {
+	OperatorID      string
+	PlayerID        string
+	WalletID        string
+	Currency        string
+	InitialBalance  float64
+	ExpectedBalance float64
+	ActualBalance   float64
+}
+
+// BalanceAdjustment records a manual change of a wallet balance and who approved it
+type BalanceAdjustment struct {
+	ID              uint64    `json:"id" gorm:"primaryKey;AUTO_INCREMENT"`
+	OperatorID      string    `json:"operator_id"`
+	PlayerID        string    `json:"player_id"`
+	WalletID        string    `json:"wallet_id"`
+	DiscrepancyID   *uint64   `json:"discrepancy_id"`
+	PreviousBalance float64   `json:"previous_balance"`
+	NewBalance      float64   `json:"new_balance"`
+	Amount          float64   `json:"amount"`
+	Currency        string    `json:"currency"`
+	Reason          string    `json:"reason"`
+	ApprovedBy      string    `json:"approved_by"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+func (a *BalanceAdjustment) ToApiResponse() *models.BalanceAdjustmentResponse {
+	response := &models.BalanceAdjustmentResponse{
+		ID:              a.ID,
+		PlayerID:        a.PlayerID,
+		WalletID:        a.WalletID,
+		PreviousBalance: a.PreviousBalance,
+		NewBalance:      a.NewBalance,
+		Amount:          a.Amount,
+		Currency:        a.Currency,
+		Reason:          a.Reason,
+		ApprovedBy:      a.ApprovedBy,
+		CreatedAt:       a.CreatedAt.Format(time.RFC3339),
+	}
+	if a.DiscrepancyID != nil {
+		response.DiscrepancyID = *a.DiscrepancyID
+	}
+	return response
+}
+
+// NewBalanceAdjustedOutboxEvent builds the outbox event of an applied adjustment,
+// it shares the wallet key with the transaction events
+func NewBalanceAdjustedOutboxEvent(adjustment *BalanceAdjustment) (*OutboxEvent, error) {
+	payload, err := json.Marshal(adjustment)
+	if err != nil {
+		return nil, err
+	}
+	return &OutboxEvent{
+		OperatorID:    adjustment.OperatorID,
+		AggregateKey:  adjustment.WalletID,
+		EventType:     EventTypeBalanceAdjusted,
+		Payload:       payload,
+		NextAttemptAt: adjustment.CreatedAt,
+		CreatedAt:     adjustment.CreatedAt,
+	}, nil
+}
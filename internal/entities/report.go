@@ -0,0 +1,96 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/models"
+)
+
+// ReportDateLayout is the layout of report days, days are counted in UTC
+const ReportDateLayout = "2006-01-02"
+
+// GGRDimension is a column the GGR report can be grouped by
+type GGRDimension string
+
+const (
+	GGRDimensionDay      GGRDimension = "day"
+	GGRDimensionProvider GGRDimension = "provider_id"
+	GGRDimensionGame     GGRDimension = "game_code"
+	GGRDimensionCurrency GGRDimension = "currency"
+)
+
+// GGRDimensions are all dimensions in report order, reports group by all of them by default
+var GGRDimensions = []GGRDimension{GGRDimensionDay, GGRDimensionProvider, GGRDimensionGame, GGRDimensionCurrency}
+
+func (d GGRDimension) IsValid() bool {
+	switch d {
+	case GGRDimensionDay, GGRDimensionProvider, GGRDimensionGame, GGRDimensionCurrency:
+		return true
+	}
+	return false
+}
+
+// GGRReportFilter selects the days From to To, both inclusive, of one operator
+type GGRReportFilter struct {
+	OperatorID string
+	From       time.Time
+	To         time.Time
+	GroupBy    []GGRDimension
+	ProviderID string
+	GameCode   string
+	Currency   string
+}
+
+// GGRReportRow is one group of the GGR report, the dimensions that are not
+// grouped by are left empty
+type GGRReportRow struct {
+	Day           *time.Time `json:"day"`
+	ProviderID    string     `json:"provider_id"`
+	GameCode      string     `json:"game_code"`
+	Currency      string     `json:"currency"`
+	Turnover      float64    `json:"turnover"`
+	Wins          float64    `json:"wins"`
+	GGR           float64    `json:"ggr" gorm:"column:ggr"`
+	RoundCount    int64      `json:"round_count"`
+	UniquePlayers int64      `json:"unique_players"`
+}
+
+func (r *GGRReportRow) ToApiResponse() *models.GGRReportRowResponse {
+	response := &models.GGRReportRowResponse{
+		ProviderID:    r.ProviderID,
+		GameCode:      r.GameCode,
+		Currency:      r.Currency,
+		Turnover:      r.Turnover,
+		Wins:          r.Wins,
+		Ggr:           r.GGR,
+		RoundCount:    r.RoundCount,
+		UniquePlayers: r.UniquePlayers,
+	}
+	if r.Day != nil {
+		response.Day = r.Day.Format(ReportDateLayout)
+	}
+	return response
+}
+
+// GGRReport is the result of a GGR report request
+type GGRReport struct {
+	Filter *GGRReportFilter
+	Rows   []*GGRReportRow
+}
+
+func (r *GGRReport) ToApiResponse() *models.GGRReportResponse {
+	groupBy := make([]string, len(r.Filter.GroupBy))
+	for i, dimension := range r.Filter.GroupBy {
+		groupBy[i] = string(dimension)
+	}
+	rows := make([]*models.GGRReportRowResponse, len(r.Rows))
+	for i, row := range r.Rows {
+		rows[i] = row.ToApiResponse()
+	}
+	return &models.GGRReportResponse{
+		From:    r.Filter.From.Format(ReportDateLayout),
+		To:      r.Filter.To.Format(ReportDateLayout),
+		GroupBy: groupBy,
+		Rows:    rows,
+	}
+}
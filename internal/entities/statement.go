@@ -0,0 +1,108 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/models"
+)
+
+// StatementDateLayout is the layout of the day a provider statement covers
+const StatementDateLayout = "2006-01-02"
+
+type StatementMismatchKind string
+
+const (
+	// StatementMismatchMissingOnOurSide rows are in the statement but have no transaction
+	StatementMismatchMissingOnOurSide StatementMismatchKind = "missing_on_our_side"
+	// StatementMismatchMissingOnProviderSide transactions were sent by the provider on
+	// the statement day but are not in the statement
+	StatementMismatchMissingOnProviderSide StatementMismatchKind = "missing_on_provider_side"
+	StatementMismatchAmount                StatementMismatchKind = "amount_mismatch"
+)
+
+func (k StatementMismatchKind) IsValid() bool {
+	switch k {
+	case StatementMismatchMissingOnOurSide, StatementMismatchMissingOnProviderSide, StatementMismatchAmount:
+		return true
+	}
+	return false
+}
+
+// StatementImport is the result of matching one provider statement against our transactions
+type StatementImport struct {
+	ID                         uint64              `json:"id" gorm:"primaryKey;AUTO_INCREMENT"`
+	OperatorID                 string              `json:"operator_id"`
+	ProviderID                 string              `json:"provider_id"`
+	StatementDate              time.Time           `json:"statement_date" gorm:"type:date"`
+	Format                     string              `json:"format"`
+	FileName                   string              `json:"file_name"`
+	RowCount                   int                 `json:"row_count"`
+	MatchedCount               int                 `json:"matched_count"`
+	MissingOnOurSideCount      int                 `json:"missing_on_our_side_count"`
+	MissingOnProviderSideCount int                 `json:"missing_on_provider_side_count"`
+	AmountMismatchCount        int                 `json:"amount_mismatch_count"`
+	ImportedBy                 string              `json:"imported_by"`
+	CreatedAt                  time.Time           `json:"created_at"`
+	Mismatches                 []StatementMismatch `json:"mismatches" gorm:"foreignKey:ImportID"`
+}
+
+// ToApiResponse leaves out the mismatches unless withMismatches is set
+func (i *StatementImport) ToApiResponse(withMismatches bool) *models.StatementImportResponse {
+	response := &models.StatementImportResponse{
+		ID:                         i.ID,
+		ProviderID:                 i.ProviderID,
+		StatementDate:              i.StatementDate.Format(StatementDateLayout),
+		Format:                     i.Format,
+		FileName:                   i.FileName,
+		RowCount:                   int64(i.RowCount),
+		MatchedCount:               int64(i.MatchedCount),
+		MissingOnOurSideCount:      int64(i.MissingOnOurSideCount),
+		MissingOnProviderSideCount: int64(i.MissingOnProviderSideCount),
+		AmountMismatchCount:        int64(i.AmountMismatchCount),
+		ImportedBy:                 i.ImportedBy,
+		CreatedAt:                  i.CreatedAt.Format(time.RFC3339),
+	}
+	if withMismatches {
+		response.Mismatches = make([]*models.StatementMismatchResponse, len(i.Mismatches))
+		for j := range i.Mismatches {
+			response.Mismatches[j] = i.Mismatches[j].ToApiResponse()
+		}
+	}
+	return response
+}
+
+// StatementMismatch is a statement row or a transaction that could not be matched.
+// Amounts are nil on the side the row or transaction is missing from.
+type StatementMismatch struct {
+	ID             uint64                `json:"id" gorm:"primaryKey;AUTO_INCREMENT"`
+	ImportID       uint64                `json:"import_id"`
+	Kind           StatementMismatchKind `json:"kind"`
+	Line           int                   `json:"line"`
+	TransactionID  *uint64               `json:"transaction_id"`
+	ReqID          string                `json:"req_id"`
+	RoundID        string                `json:"round_id"`
+	PlayerID       string                `json:"player_id"`
+	Type           TransactionType       `json:"type"`
+	Currency       string                `json:"currency"`
+	ProviderAmount *float64              `json:"provider_amount"`
+	OurAmount      *float64              `json:"our_amount"`
+}
+
+func (m *StatementMismatch) ToApiResponse() *models.StatementMismatchResponse {
+	response := &models.StatementMismatchResponse{
+		ID:             m.ID,
+		Kind:           string(m.Kind),
+		Line:           int64(m.Line),
+		ReqID:          m.ReqID,
+		RoundID:        m.RoundID,
+		PlayerID:       m.PlayerID,
+		Type:           string(m.Type),
+		Currency:       m.Currency,
+		ProviderAmount: m.ProviderAmount,
+		OurAmount:      m.OurAmount,
+	}
+	if m.TransactionID != nil {
+		response.TransactionID = *m.TransactionID
+	}
+	return response
+}
@@ -15,22 +15,28 @@ const (
 )
 
 type Transaction struct {
-	ID        uint64          `json:"id" gorm:"primaryKey;AUTO_INCREMENT"`
-	ReqID     string          `json:"req_id" gorm:"uniqueIndex"`
-	PlayerID  string          `json:"player_id" gorm:"index"`
-	WalletID  string          `json:"wallet_id" gorm:"index"`
-	RoundID   string          `json:"round_id" gorm:"index"`
-	SessionID string          `json:"session_id" gorm:"index"`
-	GameCode  string          `json:"game_code" gorm:"index"`
-	Type      TransactionType `json:"type"`
-	Amount    float64         `json:"amount"`
-	Currency  string          `json:"currency"`
-	CreatedAt time.Time       `json:"created_at"`
-	UpdatedAt time.Time       `json:"updated_at"`
-	DeletedAt gorm.DeletedAt  `json:"-" gorm:"index"`
+	ID         uint64          `json:"id" gorm:"primaryKey;AUTO_INCREMENT"`
+	OperatorID string          `json:"operator_id" gorm:"uniqueIndex:transactions_operator_req_key"`
+	ProviderID string          `json:"provider_id"`
+	ReqID      string          `json:"req_id" gorm:"uniqueIndex:transactions_operator_req_key"`
+	PlayerID   string          `json:"player_id" gorm:"index"`
+	WalletID   string          `json:"wallet_id" gorm:"index"`
+	RoundID    string          `json:"round_id" gorm:"index"`
+	SessionID  string          `json:"session_id" gorm:"index"`
+	GameCode   string          `json:"game_code" gorm:"index"`
+	Type       TransactionType `json:"type"`
+	Amount     float64         `json:"amount"`
+	Currency   string          `json:"currency"`
+	CreatedAt  time.Time       `json:"created_at"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt  `json:"-" gorm:"index"`
 }
 
-func (t *Transaction) CreateFromEventRequest(eventRequest models.EventRequest) {
+// CreateFromEventRequest fills the transaction from a provider event. The operator
+// and provider come from the request signature, never from the request body.
+func (t *Transaction) CreateFromEventRequest(operatorID, providerID string, eventRequest models.EventRequest) {
+	t.OperatorID = operatorID
+	t.ProviderID = providerID
 	t.ReqID = *eventRequest.ReqID
 	t.PlayerID = *eventRequest.PlayerID
 	t.WalletID = *eventRequest.WalletID
@@ -41,3 +47,18 @@ func (t *Transaction) CreateFromEventRequest(eventRequest models.EventRequest) {
 	t.Amount = *eventRequest.Amount
 	t.Currency = *eventRequest.Currency
 }
+
+func (t *Transaction) ToApiResponse() *models.TransactionResponse {
+	return &models.TransactionResponse{
+		ID:         t.ID,
+		ProviderID: t.ProviderID,
+		ReqID:      t.ReqID,
+		RoundID:    t.RoundID,
+		SessionID:  t.SessionID,
+		GameCode:   t.GameCode,
+		Type:       string(t.Type),
+		Amount:     t.Amount,
+		Currency:   t.Currency,
+		CreatedAt:  t.CreatedAt.Format(time.RFC3339Nano),
+	}
+}
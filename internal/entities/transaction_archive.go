@@ -0,0 +1,25 @@
+package entities
+
+import "time"
+
+// TransactionArchive is a month of transactions that was moved out of the
+// database into a compressed file. Month is the first day of the month in UTC,
+// the transactions of [Month, End()) are in the file.
+type TransactionArchive struct {
+	Month            time.Time `json:"month" gorm:"primaryKey"`
+	FileName         string    `json:"file_name"`
+	TransactionCount int64     `json:"transaction_count"`
+	// Checksum is the hex encoded SHA-256 of the file
+	Checksum   string    `json:"checksum"`
+	ArchivedAt time.Time `json:"archived_at"`
+}
+
+// End returns the start of the month after the archived one
+func (a *TransactionArchive) End() time.Time {
+	return a.Month.AddDate(0, 1, 0)
+}
+
+// Covers reports whether at falls into the archived month
+func (a *TransactionArchive) Covers(at time.Time) bool {
+	return !at.Before(a.Month) && at.Before(a.End())
+}
@@ -0,0 +1,139 @@
+package entities
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/models"
+	"gorm.io/gorm"
+)
+
+// EventTypeBalanceUpdated is only sent to webhooks, it follows every processed bet and result
+const EventTypeBalanceUpdated = "balance.updated"
+
+// WebhookEventTypes are the event types a webhook can subscribe to
+var WebhookEventTypes = []string{EventTypeTransactionBet, EventTypeTransactionResult, EventTypeBalanceUpdated}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryStatusDelivered WebhookDeliveryStatus = "delivered"
+	// WebhookDeliveryStatusFailed deliveries ran out of attempts, they can be redelivered manually
+	WebhookDeliveryStatusFailed WebhookDeliveryStatus = "failed"
+)
+
+// Webhook is an HTTP endpoint of an operator that receives signed events
+type Webhook struct {
+	ID         uint64         `json:"id" gorm:"primaryKey;AUTO_INCREMENT"`
+	OperatorID string         `json:"operator_id" gorm:"index"`
+	URL        string         `json:"url"`
+	Secret     string         `json:"-"`
+	EventTypes []string       `json:"event_types" gorm:"serializer:json"`
+	Active     bool           `json:"active"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+func IsValidWebhookEventType(eventType string) bool {
+	return slices.Contains(WebhookEventTypes, eventType)
+}
+
+func (w *Webhook) ToApiResponse() *models.WebhookResponse {
+	return &models.WebhookResponse{
+		ID:         w.ID,
+		OperatorID: w.OperatorID,
+		URL:        w.URL,
+		EventTypes: w.EventTypes,
+		Active:     w.Active,
+		CreatedAt:  w.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:  w.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+type WebhookDelivery struct {
+	ID             uint64                   `json:"id" gorm:"primaryKey;AUTO_INCREMENT"`
+	WebhookID      uint64                   `json:"webhook_id"`
+	OperatorID     string                   `json:"operator_id"`
+	EventType      string                   `json:"event_type"`
+	Payload        json.RawMessage          `json:"payload" gorm:"type:jsonb"`
+	Status         WebhookDeliveryStatus    `json:"status"`
+	Attempts       int                      `json:"attempts"`
+	LastStatusCode *int                     `json:"last_status_code"`
+	LastError      *string                  `json:"last_error"`
+	NextAttemptAt  time.Time                `json:"next_attempt_at"`
+	DeliveredAt    *time.Time               `json:"delivered_at"`
+	CreatedAt      time.Time                `json:"created_at"`
+	UpdatedAt      time.Time                `json:"updated_at"`
+	AttemptLog     []WebhookDeliveryAttempt `json:"attempt_log" gorm:"foreignKey:DeliveryID"`
+	// Webhook is loaded for dispatching, it holds the target URL and signing secret
+	Webhook *Webhook `json:"-" gorm:"foreignKey:WebhookID"`
+}
+
+// ToApiResponse leaves out the payload and attempt log unless withDetails is set
+func (d *WebhookDelivery) ToApiResponse(withDetails bool) *models.WebhookDeliveryResponse {
+	response := &models.WebhookDeliveryResponse{
+		ID:        d.ID,
+		WebhookID: d.WebhookID,
+		EventType: d.EventType,
+		Status:    string(d.Status),
+		Attempts:  int64(d.Attempts),
+		CreatedAt: d.CreatedAt.Format(time.RFC3339),
+	}
+	if d.LastStatusCode != nil {
+		response.LastStatusCode = int64(*d.LastStatusCode)
+	}
+	if d.LastError != nil {
+		response.LastError = *d.LastError
+	}
+	if d.Status == WebhookDeliveryStatusPending {
+		response.NextAttemptAt = d.NextAttemptAt.Format(time.RFC3339)
+	}
+	if d.DeliveredAt != nil {
+		response.DeliveredAt = d.DeliveredAt.Format(time.RFC3339)
+	}
+	if withDetails {
+		response.Payload = d.Payload
+		response.AttemptLog = make([]*models.WebhookDeliveryAttemptResponse, len(d.AttemptLog))
+		for i, attempt := range d.AttemptLog {
+			response.AttemptLog[i] = attempt.ToApiResponse()
+		}
+	}
+	return response
+}
+
+type WebhookDeliveryAttempt struct {
+	ID         uint64    `json:"id" gorm:"primaryKey;AUTO_INCREMENT"`
+	DeliveryID uint64    `json:"delivery_id"`
+	Attempt    int       `json:"attempt"`
+	StatusCode int       `json:"status_code"`
+	Error      *string   `json:"error"`
+	DurationMs int64     `json:"duration_ms"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func (a *WebhookDeliveryAttempt) ToApiResponse() *models.WebhookDeliveryAttemptResponse {
+	response := &models.WebhookDeliveryAttemptResponse{
+		Attempt:    int64(a.Attempt),
+		StatusCode: int64(a.StatusCode),
+		DurationMs: a.DurationMs,
+		CreatedAt:  a.CreatedAt.Format(time.RFC3339),
+	}
+	if a.Error != nil {
+		response.Error = *a.Error
+	}
+	return response
+}
+
+// BalanceEvent is the payload of balance.updated webhooks
+type BalanceEvent struct {
+	OperatorID    string    `json:"operator_id"`
+	PlayerID      string    `json:"player_id"`
+	WalletID      string    `json:"wallet_id"`
+	Balance       float64   `json:"balance"`
+	Currency      string    `json:"currency"`
+	TransactionID uint64    `json:"transaction_id"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
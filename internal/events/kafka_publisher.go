@@ -0,0 +1,49 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// KafkaPublisher writes every message to one topic. Messages are keyed by
+// operator and aggregate key, so all events of a wallet land on the same
+// partition and keep their order.
+type KafkaPublisher struct {
+	writer *kafka.Writer
+}
+
+func NewKafkaPublisher(brokers []string, topic string) (*KafkaPublisher, error) {
+	if len(brokers) == 0 || topic == "" {
+		return nil, errors.New("kafka publisher needs brokers and a topic")
+	}
+	return &KafkaPublisher{
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(brokers...),
+			Topic:        topic,
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireAll,
+			// The relay already publishes one message at a time per key
+			BatchSize: 1,
+		},
+	}, nil
+}
+
+func (p *KafkaPublisher) Publish(ctx context.Context, message Message) error {
+	return p.writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(message.OperatorID + ":" + message.Key),
+		Value: message.Payload,
+		Time:  message.CreatedAt,
+		Headers: []kafka.Header{
+			{Key: "event_id", Value: []byte(strconv.FormatUint(message.ID, 10))},
+			{Key: "event_type", Value: []byte(message.Type)},
+			{Key: "operator_id", Value: []byte(message.OperatorID)},
+		},
+	})
+}
+
+func (p *KafkaPublisher) Close() error {
+	return p.writer.Close()
+}
@@ -0,0 +1,81 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// LogPublisher writes messages to the application log, it is meant for local runs
+type LogPublisher struct{}
+
+func NewLogPublisher() *LogPublisher {
+	return &LogPublisher{}
+}
+
+func (p *LogPublisher) Publish(_ context.Context, message Message) error {
+	zap.L().Info("Event published",
+		zap.Uint64("event_id", message.ID),
+		zap.String("operator_id", message.OperatorID),
+		zap.String("key", message.Key),
+		zap.String("type", message.Type),
+		zap.ByteString("payload", message.Payload))
+	return nil
+}
+
+func (p *LogPublisher) Close() error {
+	return nil
+}
+
+// FilePublisher appends messages as JSON lines to a file, so tests and offline
+// tools can consume the event stream without a broker
+type FilePublisher struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+type fileRecord struct {
+	ID         uint64          `json:"id"`
+	OperatorID string          `json:"operator_id"`
+	Key        string          `json:"key"`
+	Type       string          `json:"type"`
+	Payload    json.RawMessage `json:"payload"`
+	CreatedAt  string          `json:"created_at"`
+}
+
+func NewFilePublisher(path string) (*FilePublisher, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &FilePublisher{file: file}, nil
+}
+
+func (p *FilePublisher) Publish(_ context.Context, message Message) error {
+	line, err := json.Marshal(fileRecord{
+		ID:         message.ID,
+		OperatorID: message.OperatorID,
+		Key:        message.Key,
+		Type:       message.Type,
+		Payload:    message.Payload,
+		CreatedAt:  message.CreatedAt.UTC().Format("2006-01-02T15:04:05.000000Z07:00"),
+	})
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, err := p.file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	// The message counts as delivered, so it has to survive a crash
+	return p.file.Sync()
+}
+
+func (p *FilePublisher) Close() error {
+	return p.file.Close()
+}
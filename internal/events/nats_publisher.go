@@ -0,0 +1,49 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// NATSPublisher publishes to JetStream, which acknowledges stored messages. A
+// stream has to cover "<subject>.>". The event id is used as the message id, so
+// JetStream drops duplicates that arrive within its deduplication window.
+type NATSPublisher struct {
+	conn    *nats.Conn
+	js      jetstream.JetStream
+	subject string
+}
+
+func NewNATSPublisher(url, subject string) (*NATSPublisher, error) {
+	if url == "" || subject == "" {
+		return nil, errors.New("nats publisher needs a url and a subject")
+	}
+	conn, err := nats.Connect(url, nats.Name("casino-wallet-service"))
+	if err != nil {
+		return nil, err
+	}
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return &NATSPublisher{conn: conn, js: js, subject: subject}, nil
+}
+
+func (p *NATSPublisher) Publish(ctx context.Context, message Message) error {
+	msg := nats.NewMsg(p.subject + "." + message.Type)
+	msg.Data = message.Payload
+	msg.Header.Set("Event-Id", strconv.FormatUint(message.ID, 10))
+	msg.Header.Set("Operator-Id", message.OperatorID)
+	msg.Header.Set("Event-Key", message.Key)
+	_, err := p.js.PublishMsg(ctx, msg, jetstream.WithMsgID(strconv.FormatUint(message.ID, 10)))
+	return err
+}
+
+func (p *NATSPublisher) Close() error {
+	return p.conn.Drain()
+}
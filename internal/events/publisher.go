@@ -0,0 +1,55 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Message is an outbox event on its way to a downstream system
+type Message struct {
+	ID         uint64
+	OperatorID string
+	// Key orders messages, brokers that partition use it as the partition key
+	Key       string
+	Type      string
+	Payload   []byte
+	CreatedAt time.Time
+}
+
+// Publisher delivers messages to a downstream system. Publish must only return
+// nil once the message is durably accepted, delivery is at least once, so the
+// same message may be published again after a failure or restart and consumers
+// deduplicate by Message.ID.
+type Publisher interface {
+	Publish(ctx context.Context, message Message) error
+	Close() error
+}
+
+type Config struct {
+	// Publisher is one of log, file, kafka or nats
+	Publisher    string
+	FilePath     string
+	KafkaBrokers []string
+	KafkaTopic   string
+	NATSURL      string
+	// NATSSubject is the subject prefix, the event type is appended to it
+	NATSSubject string
+}
+
+// NewPublisher creates the publisher selected in the configuration
+func NewPublisher(cfg Config) (Publisher, error) {
+	switch strings.ToLower(cfg.Publisher) {
+	case "", "log":
+		return NewLogPublisher(), nil
+	case "file":
+		return NewFilePublisher(cfg.FilePath)
+	case "kafka":
+		return NewKafkaPublisher(cfg.KafkaBrokers, cfg.KafkaTopic)
+	case "nats":
+		return NewNATSPublisher(cfg.NATSURL, cfg.NATSSubject)
+	default:
+		return nil, fmt.Errorf("unknown event publisher %q", cfg.Publisher)
+	}
+}
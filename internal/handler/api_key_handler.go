@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/middleware"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/service"
+	httpUtils "github.com/BarisKilicGsu/casino-wallet-service/internal/utils/http"
+	"github.com/BarisKilicGsu/casino-wallet-service/models"
+	"github.com/go-openapi/strfmt"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+type APIKeyHandler struct {
+	apiKeyService service.IAPIKeyService
+}
+
+func NewAPIKeyHandler(apiKeyService service.IAPIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyService: apiKeyService,
+	}
+}
+
+func (h *APIKeyHandler) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	operatorID, ok := middleware.OperatorIDFromContext(r.Context())
+	if !ok {
+		httpUtils.ErrorResponse(w, http.StatusUnauthorized, middleware.ErrMissingOperator)
+		return
+	}
+
+	apiKeys, err := h.apiKeyService.ListKeys(r.Context(), operatorID)
+	if err != nil {
+		zap.L().Error("Error while listing api keys", zap.Error(err))
+		httpUtils.ErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	apiResponses := make([]*models.APIKeyResponse, len(apiKeys))
+	for i, apiKey := range apiKeys {
+		apiResponses[i] = apiKey.ToApiResponse()
+	}
+
+	httpUtils.JSONResponse(w, http.StatusOK, models.AllAPIKeysResponse{
+		APIKeys: apiResponses,
+	})
+}
+
+func (h *APIKeyHandler) IssueAPIKey(w http.ResponseWriter, r *http.Request) {
+	operatorID, ok := middleware.OperatorIDFromContext(r.Context())
+	if !ok {
+		httpUtils.ErrorResponse(w, http.StatusUnauthorized, middleware.ErrMissingOperator)
+		return
+	}
+
+	var request models.APIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		zap.L().Info("Failed to decode api key request",
+			zap.String("url path", r.URL.Path),
+			zap.Error(err))
+		httpUtils.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := request.Validate(strfmt.Default); err != nil {
+		httpUtils.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	apiKey, rawKey, err := h.apiKeyService.IssueKey(r.Context(), operatorID, *request.Name, entities.APIKeyRole(*request.Role))
+	if err != nil {
+		if err == service.ErrInvalidRole {
+			httpUtils.ErrorResponse(w, http.StatusBadRequest, err)
+			return
+		}
+		httpUtils.ErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	httpUtils.JSONResponse(w, http.StatusCreated, models.IssuedAPIKeyResponse{
+		Key:    rawKey,
+		APIKey: apiKey.ToApiResponse(),
+	})
+}
+
+func (h *APIKeyHandler) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	operatorID, ok := middleware.OperatorIDFromContext(r.Context())
+	if !ok {
+		httpUtils.ErrorResponse(w, http.StatusUnauthorized, middleware.ErrMissingOperator)
+		return
+	}
+
+	id, err := strconv.ParseUint(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		httpUtils.ErrorResponse(w, http.StatusBadRequest, service.ErrInvalidRequest)
+		return
+	}
+
+	if err := h.apiKeyService.RevokeKey(r.Context(), operatorID, id); err != nil {
+		if err == service.ErrAPIKeyNotFound {
+			httpUtils.ErrorResponse(w, http.StatusNotFound, err)
+			return
+		}
+		httpUtils.ErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	httpUtils.JSONResponseNoData(w, http.StatusOK)
+}
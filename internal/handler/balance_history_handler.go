@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/middleware"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/service"
+	httpUtils "github.com/BarisKilicGsu/casino-wallet-service/internal/utils/http"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+type BalanceHistoryHandler struct {
+	balanceHistoryService service.IBalanceHistoryService
+}
+
+func NewBalanceHistoryHandler(balanceHistoryService service.IBalanceHistoryService) *BalanceHistoryHandler {
+	return &BalanceHistoryHandler{
+		balanceHistoryService: balanceHistoryService,
+	}
+}
+
+func (h *BalanceHistoryHandler) GetBalanceAt(w http.ResponseWriter, r *http.Request) {
+	operatorID, ok := middleware.OperatorIDFromContext(r.Context())
+	if !ok {
+		httpUtils.ErrorResponse(w, http.StatusUnauthorized, middleware.ErrMissingOperator)
+		return
+	}
+
+	playerID := mux.Vars(r)["player_id"]
+	if playerID == "" {
+		httpUtils.ErrorResponse(w, http.StatusBadRequest, service.ErrInvalidRequest)
+		return
+	}
+
+	balance, err := h.balanceHistoryService.GetBalanceAt(r.Context(), operatorID, playerID, r.URL.Query().Get("at"))
+	if err != nil {
+		switch err {
+		case service.ErrInvalidBalanceTime, service.ErrBalanceTimeInFuture:
+			httpUtils.ErrorResponse(w, http.StatusBadRequest, err)
+		case service.ErrPlayerNotFound:
+			httpUtils.ErrorResponse(w, http.StatusNotFound, err)
+		case service.ErrBalanceTimeArchived:
+			httpUtils.ErrorResponse(w, http.StatusGone, err)
+		default:
+			if contextErrorResponse(w, r) {
+				return
+			}
+			zap.L().Error("Error while handling historical balance request", zap.Error(err))
+			httpUtils.ErrorResponse(w, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	httpUtils.JSONResponse(w, http.StatusOK, balance.ToApiResponse())
+}
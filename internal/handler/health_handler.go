@@ -1,7 +1,7 @@
 package handler
 
 import (
-	"database/sql"
+	"context"
 	"encoding/json"
 	"net/http"
 	"time"
@@ -9,11 +9,16 @@ import (
 	"github.com/BarisKilicGsu/casino-wallet-service/models"
 )
 
+// Pinger checks that the storage backend is reachable, *sql.DB implements it
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
 type HealthHandler struct {
-	db *sql.DB
+	db Pinger
 }
 
-func NewHealthHandler(db *sql.DB) *HealthHandler {
+func NewHealthHandler(db Pinger) *HealthHandler {
 	return &HealthHandler{
 		db: db,
 	}
@@ -27,7 +32,7 @@ func (h *HealthHandler) HealthCheck(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Veritabanı bağlantısını kontrol et
-	err := h.db.Ping()
+	err := h.db.PingContext(r.Context())
 	if err != nil {
 		response.Status = "error"
 		response.Database = "error"
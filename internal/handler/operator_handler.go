@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/middleware"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/service"
+	httpUtils "github.com/BarisKilicGsu/casino-wallet-service/internal/utils/http"
+	"github.com/BarisKilicGsu/casino-wallet-service/models"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"go.uber.org/zap"
+)
+
+type OperatorHandler struct {
+	operatorService service.IOperatorService
+}
+
+func NewOperatorHandler(operatorService service.IOperatorService) *OperatorHandler {
+	return &OperatorHandler{
+		operatorService: operatorService,
+	}
+}
+
+func (h *OperatorHandler) GetOperator(w http.ResponseWriter, r *http.Request) {
+	operatorID, ok := middleware.OperatorIDFromContext(r.Context())
+	if !ok {
+		httpUtils.ErrorResponse(w, http.StatusUnauthorized, middleware.ErrMissingOperator)
+		return
+	}
+
+	operator, err := h.operatorService.GetOperator(r.Context(), operatorID)
+	if err != nil {
+		zap.L().Error("Error while getting operator",
+			zap.String("operator_id", operatorID),
+			zap.Error(err))
+		httpUtils.ErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	httpUtils.JSONResponse(w, http.StatusOK, operator.ToApiResponse())
+}
+
+func (h *OperatorHandler) UpdateOperator(w http.ResponseWriter, r *http.Request) {
+	operatorID, ok := middleware.OperatorIDFromContext(r.Context())
+	if !ok {
+		httpUtils.ErrorResponse(w, http.StatusUnauthorized, middleware.ErrMissingOperator)
+		return
+	}
+
+	var request models.OperatorRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		zap.L().Info("Failed to decode operator request",
+			zap.String("url path", r.URL.Path),
+			zap.Error(err))
+		httpUtils.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := request.Validate(strfmt.Default); err != nil {
+		httpUtils.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	spendOrder := make([]entities.BalanceBucket, len(request.SpendOrder))
+	for i, bucket := range request.SpendOrder {
+		spendOrder[i] = entities.BalanceBucket(bucket)
+	}
+
+	operator, err := h.operatorService.UpdateOperator(r.Context(), &entities.Operator{
+		ID:         operatorID,
+		Name:       *request.Name,
+		Currencies: request.Currencies,
+		MinBet:     swag.Float64Value(request.MinBet),
+		MaxBet:     swag.Float64Value(request.MaxBet),
+		SpendOrder: spendOrder,
+	})
+	if err != nil {
+		if err == service.ErrInvalidOperatorConfig {
+			httpUtils.ErrorResponse(w, http.StatusBadRequest, err)
+			return
+		}
+		httpUtils.ErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	httpUtils.JSONResponse(w, http.StatusOK, operator.ToApiResponse())
+}
@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/middleware"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/playerstatement"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/service"
+	httpUtils "github.com/BarisKilicGsu/casino-wallet-service/internal/utils/http"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+type PlayerStatementHandler struct {
+	statementService service.IPlayerStatementService
+}
+
+func NewPlayerStatementHandler(statementService service.IPlayerStatementService) *PlayerStatementHandler {
+	return &PlayerStatementHandler{
+		statementService: statementService,
+	}
+}
+
+func (h *PlayerStatementHandler) GetStatement(w http.ResponseWriter, r *http.Request) {
+	operatorID, ok := middleware.OperatorIDFromContext(r.Context())
+	if !ok {
+		httpUtils.ErrorResponse(w, http.StatusUnauthorized, middleware.ErrMissingOperator)
+		return
+	}
+
+	playerID := mux.Vars(r)["player_id"]
+	if playerID == "" {
+		httpUtils.ErrorResponse(w, http.StatusBadRequest, service.ErrInvalidRequest)
+		return
+	}
+
+	query := r.URL.Query()
+	format := query.Get("format")
+	if format == "" {
+		format = playerstatement.FormatCSV
+	}
+	response := &statementResponse{
+		w:        w,
+		fileName: fmt.Sprintf("statement-%s-%s-%s.%s", playerID, query.Get("from"), query.Get("to"), format),
+	}
+	writer, err := playerstatement.NewWriter(format, response)
+	if err != nil {
+		httpUtils.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+	response.contentType = writer.ContentType()
+
+	err = h.statementService.Write(r.Context(), &service.PlayerStatementRequest{
+		OperatorID: operatorID,
+		PlayerID:   playerID,
+		From:       query.Get("from"),
+		To:         query.Get("to"),
+	}, writer)
+	if err == nil {
+		return
+	}
+	// Once the statement started the status is sent, the client sees a cut off download
+	if response.started {
+		zap.L().Info("Player statement aborted",
+			zap.String("player_id", playerID),
+			zap.Error(err))
+		return
+	}
+	writePlayerStatementError(w, r, err)
+}
+
+// statementResponse sends the status and the download headers with the first
+// byte of the statement, so errors before it are still answered with JSON
+type statementResponse struct {
+	w           http.ResponseWriter
+	contentType string
+	fileName    string
+	started     bool
+}
+
+func (s *statementResponse) Write(b []byte) (int, error) {
+	if !s.started {
+		s.started = true
+		s.w.Header().Set("Content-Type", s.contentType)
+		s.w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": s.fileName}))
+		s.w.WriteHeader(http.StatusOK)
+	}
+	return s.w.Write(b)
+}
+
+func writePlayerStatementError(w http.ResponseWriter, r *http.Request, err error) {
+	switch err {
+	case service.ErrInvalidStatementPeriod, service.ErrStatementPeriodReversed:
+		httpUtils.ErrorResponse(w, http.StatusBadRequest, err)
+	case service.ErrPlayerNotFound:
+		httpUtils.ErrorResponse(w, http.StatusNotFound, err)
+	case service.ErrStatementArchived:
+		httpUtils.ErrorResponse(w, http.StatusGone, err)
+	default:
+		if contextErrorResponse(w, r) {
+			return
+		}
+		zap.L().Error("Error while handling player statement request", zap.Error(err))
+		httpUtils.ErrorResponse(w, http.StatusInternalServerError, err)
+	}
+}
@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/middleware"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/service"
+	httpUtils "github.com/BarisKilicGsu/casino-wallet-service/internal/utils/http"
+	"github.com/BarisKilicGsu/casino-wallet-service/models"
+	"github.com/go-openapi/strfmt"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+type ReconciliationHandler struct {
+	reconciliationService service.IReconciliationService
+}
+
+func NewReconciliationHandler(reconciliationService service.IReconciliationService) *ReconciliationHandler {
+	return &ReconciliationHandler{
+		reconciliationService: reconciliationService,
+	}
+}
+
+func (h *ReconciliationHandler) Reconcile(w http.ResponseWriter, r *http.Request) {
+	operatorID, ok := middleware.OperatorIDFromContext(r.Context())
+	if !ok {
+		httpUtils.ErrorResponse(w, http.StatusUnauthorized, middleware.ErrMissingOperator)
+		return
+	}
+
+	run, err := h.reconciliationService.Reconcile(r.Context(), operatorID, entities.ReconciliationTriggerAPI)
+	if err != nil {
+		writeReconciliationError(w, err)
+		return
+	}
+
+	httpUtils.JSONResponse(w, http.StatusCreated, run.ToApiResponse())
+}
+
+func (h *ReconciliationHandler) GetRun(w http.ResponseWriter, r *http.Request) {
+	operatorID, ok := middleware.OperatorIDFromContext(r.Context())
+	if !ok {
+		httpUtils.ErrorResponse(w, http.StatusUnauthorized, middleware.ErrMissingOperator)
+		return
+	}
+
+	id, err := strconv.ParseUint(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		httpUtils.ErrorResponse(w, http.StatusBadRequest, service.ErrInvalidRequest)
+		return
+	}
+
+	run, err := h.reconciliationService.GetRun(r.Context(), operatorID, id)
+	if err != nil {
+		writeReconciliationError(w, err)
+		return
+	}
+
+	httpUtils.JSONResponse(w, http.StatusOK, run.ToApiResponse())
+}
+
+func (h *ReconciliationHandler) ListDiscrepancies(w http.ResponseWriter, r *http.Request) {
+	operatorID, ok := middleware.OperatorIDFromContext(r.Context())
+	if !ok {
+		httpUtils.ErrorResponse(w, http.StatusUnauthorized, middleware.ErrMissingOperator)
+		return
+	}
+
+	status := entities.DiscrepancyStatus(r.URL.Query().Get("status"))
+	discrepancies, err := h.reconciliationService.ListDiscrepancies(r.Context(), operatorID, status)
+	if err != nil {
+		writeReconciliationError(w, err)
+		return
+	}
+
+	apiResponses := make([]*models.ReconciliationDiscrepancyResponse, len(discrepancies))
+	for i, discrepancy := range discrepancies {
+		apiResponses[i] = discrepancy.ToApiResponse()
+	}
+
+	httpUtils.JSONResponse(w, http.StatusOK, models.AllReconciliationDiscrepanciesResponse{
+		Discrepancies: apiResponses,
+	})
+}
+
+func (h *ReconciliationHandler) ApproveCorrection(w http.ResponseWriter, r *http.Request) {
+	operatorID, ok := middleware.OperatorIDFromContext(r.Context())
+	if !ok {
+		httpUtils.ErrorResponse(w, http.StatusUnauthorized, middleware.ErrMissingOperator)
+		return
+	}
+	apiKey, ok := middleware.APIKeyFromContext(r.Context())
+	if !ok {
+		httpUtils.ErrorResponse(w, http.StatusUnauthorized, middleware.ErrMissingAPIKey)
+		return
+	}
+
+	id, err := strconv.ParseUint(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		httpUtils.ErrorResponse(w, http.StatusBadRequest, service.ErrInvalidRequest)
+		return
+	}
+
+	var request models.ApproveCorrectionRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		zap.L().Info("Failed to decode approve correction request",
+			zap.String("url path", r.URL.Path),
+			zap.Error(err))
+		httpUtils.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := request.Validate(strfmt.Default); err != nil {
+		httpUtils.ErrorResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// The approver is recorded by key, names alone are not unique
+	approvedBy := fmt.Sprintf("api_key:%d (%s)", apiKey.ID, apiKey.Name)
+	adjustment, err := h.reconciliationService.ApproveCorrection(r.Context(), operatorID, id, *request.Reason, approvedBy)
+	if err != nil {
+		writeReconciliationError(w, err)
+		return
+	}
+
+	httpUtils.JSONResponse(w, http.StatusCreated, adjustment.ToApiResponse())
+}
+
+func writeReconciliationError(w http.ResponseWriter, err error) {
+	switch err {
+	case service.ErrInvalidDiscrepancyStatus, service.ErrMissingReason:
+		httpUtils.ErrorResponse(w, http.StatusBadRequest, err)
+	case service.ErrReconciliationNotFound, service.ErrDiscrepancyNotFound:
+		httpUtils.ErrorResponse(w, http.StatusNotFound, err)
+	case service.ErrReconciliationInProgress, service.ErrDiscrepancyNotOpen, service.ErrDiscrepancyChanged, service.ErrDiscrepancyResolved:
+		httpUtils.ErrorResponse(w, http.StatusConflict, err)
+	default:
+		zap.L().Error("Error while handling reconciliation request", zap.Error(err))
+		httpUtils.ErrorResponse(w, http.StatusInternalServerError, err)
+	}
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/middleware"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/service"
+	httpUtils "github.com/BarisKilicGsu/casino-wallet-service/internal/utils/http"
+	"go.uber.org/zap"
+)
+
+type ReportHandler struct {
+	reportService service.IReportService
+}
+
+func NewReportHandler(reportService service.IReportService) *ReportHandler {
+	return &ReportHandler{
+		reportService: reportService,
+	}
+}
+
+func (h *ReportHandler) GetGGR(w http.ResponseWriter, r *http.Request) {
+	report, ok := h.getGGR(w, r)
+	if !ok {
+		return
+	}
+
+	httpUtils.JSONResponse(w, http.StatusOK, report.ToApiResponse())
+}
+
+// ExportGGR writes the GGR report as CSV with a column per grouped dimension
+func (h *ReportHandler) ExportGGR(w http.ResponseWriter, r *http.Request) {
+	report, ok := h.getGGR(w, r)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"ggr-%s-%s.csv\"",
+		report.Filter.From.Format(entities.ReportDateLayout), report.Filter.To.Format(entities.ReportDateLayout)))
+	w.WriteHeader(http.StatusOK)
+
+	header := make([]string, 0, len(report.Filter.GroupBy)+5)
+	for _, dimension := range report.Filter.GroupBy {
+		header = append(header, string(dimension))
+	}
+	header = append(header, "turnover", "wins", "ggr", "round_count", "unique_players")
+
+	writer := csv.NewWriter(w)
+	_ = writer.Write(header)
+	for _, row := range report.Rows {
+		record := make([]string, 0, len(header))
+		for _, dimension := range report.Filter.GroupBy {
+			record = append(record, ggrDimensionValue(row, dimension))
+		}
+		record = append(record,
+			strconv.FormatFloat(row.Turnover, 'f', 2, 64),
+			strconv.FormatFloat(row.Wins, 'f', 2, 64),
+			strconv.FormatFloat(row.GGR, 'f', 2, 64),
+			strconv.FormatInt(row.RoundCount, 10),
+			strconv.FormatInt(row.UniquePlayers, 10))
+		_ = writer.Write(record)
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		zap.L().Info("Failed to write GGR export", zap.Error(err))
+	}
+}
+
+func (h *ReportHandler) getGGR(w http.ResponseWriter, r *http.Request) (*entities.GGRReport, bool) {
+	operatorID, ok := middleware.OperatorIDFromContext(r.Context())
+	if !ok {
+		httpUtils.ErrorResponse(w, http.StatusUnauthorized, middleware.ErrMissingOperator)
+		return nil, false
+	}
+
+	query := r.URL.Query()
+	report, err := h.reportService.GetGGR(r.Context(), &service.GGRReportRequest{
+		OperatorID: operatorID,
+		From:       query.Get("from"),
+		To:         query.Get("to"),
+		GroupBy:    query.Get("group_by"),
+		ProviderID: query.Get("provider_id"),
+		GameCode:   query.Get("game_code"),
+		Currency:   query.Get("currency"),
+	})
+	if err != nil {
+		writeReportError(w, err)
+		return nil, false
+	}
+	return report, true
+}
+
+func ggrDimensionValue(row *entities.GGRReportRow, dimension entities.GGRDimension) string {
+	switch dimension {
+	case entities.GGRDimensionDay:
+		if row.Day == nil {
+			return ""
+		}
+		return row.Day.Format(entities.ReportDateLayout)
+	case entities.GGRDimensionProvider:
+		return row.ProviderID
+	case entities.GGRDimensionGame:
+		return row.GameCode
+	case entities.GGRDimensionCurrency:
+		return row.Currency
+	}
+	return ""
+}
+
+func writeReportError(w http.ResponseWriter, err error) {
+	switch err {
+	case service.ErrInvalidReportDate, service.ErrInvalidReportRange, service.ErrReportRangeTooLong, service.ErrInvalidGroupBy:
+		httpUtils.ErrorResponse(w, http.StatusBadRequest, err)
+	default:
+		zap.L().Error("Error while handling report request", zap.Error(err))
+		httpUtils.ErrorResponse(w, http.StatusInternalServerError, err)
+	}
+}
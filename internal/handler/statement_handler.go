@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/middleware"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/providerstatement"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/service"
+	httpUtils "github.com/BarisKilicGsu/casino-wallet-service/internal/utils/http"
+	"github.com/BarisKilicGsu/casino-wallet-service/models"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+var statementExportHeader = []string{"kind", "line", "transaction_id", "req_id", "round_id", "player_id", "type", "currency", "provider_amount", "our_amount"}
+
+type StatementHandler struct {
+	statementService service.IStatementService
+	maxBytes         int64
+}
+
+func NewStatementHandler(statementService service.IStatementService, maxBytes int64) *StatementHandler {
+	return &StatementHandler{
+		statementService: statementService,
+		maxBytes:         maxBytes,
+	}
+}
+
+// Import takes the statement file as the raw request body
+func (h *StatementHandler) Import(w http.ResponseWriter, r *http.Request) {
+	operatorID, ok := middleware.OperatorIDFromContext(r.Context())
+	if !ok {
+		httpUtils.ErrorResponse(w, http.StatusUnauthorized, middleware.ErrMissingOperator)
+		return
+	}
+	apiKey, ok := middleware.APIKeyFromContext(r.Context())
+	if !ok {
+		httpUtils.ErrorResponse(w, http.StatusUnauthorized, middleware.ErrMissingAPIKey)
+		return
+	}
+
+	query := r.URL.Query()
+	statementImport, err := h.statementService.Import(r.Context(), &service.StatementImportRequest{
+		OperatorID: operatorID,
+		ProviderID: query.Get("provider_id"),
+		Date:       query.Get("date"),
+		FileName:   query.Get("file_name"),
+		ImportedBy: fmt.Sprintf("api_key:%d (%s)", apiKey.ID, apiKey.Name),
+		Body:       http.MaxBytesReader(w, r.Body, h.maxBytes),
+	})
+	if err != nil {
+		writeStatementError(w, err)
+		return
+	}
+
+	httpUtils.JSONResponse(w, http.StatusCreated, statementImport.ToApiResponse(false))
+}
+
+func (h *StatementHandler) ListImports(w http.ResponseWriter, r *http.Request) {
+	operatorID, ok := middleware.OperatorIDFromContext(r.Context())
+	if !ok {
+		httpUtils.ErrorResponse(w, http.StatusUnauthorized, middleware.ErrMissingOperator)
+		return
+	}
+
+	imports, err := h.statementService.ListImports(r.Context(), operatorID, r.URL.Query().Get("provider_id"))
+	if err != nil {
+		writeStatementError(w, err)
+		return
+	}
+
+	apiResponses := make([]*models.StatementImportResponse, len(imports))
+	for i, statementImport := range imports {
+		apiResponses[i] = statementImport.ToApiResponse(false)
+	}
+
+	httpUtils.JSONResponse(w, http.StatusOK, models.AllStatementImportsResponse{
+		Imports: apiResponses,
+	})
+}
+
+func (h *StatementHandler) GetImport(w http.ResponseWriter, r *http.Request) {
+	statementImport, ok := h.getImport(w, r)
+	if !ok {
+		return
+	}
+
+	httpUtils.JSONResponse(w, http.StatusOK, statementImport.ToApiResponse(true))
+}
+
+// Export writes the mismatches of an import as CSV
+func (h *StatementHandler) Export(w http.ResponseWriter, r *http.Request) {
+	statementImport, ok := h.getImport(w, r)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"statement-import-%d.csv\"", statementImport.ID))
+	w.WriteHeader(http.StatusOK)
+
+	writer := csv.NewWriter(w)
+	_ = writer.Write(statementExportHeader)
+	for _, mismatch := range statementImport.Mismatches {
+		_ = writer.Write([]string{
+			string(mismatch.Kind),
+			formatOptionalInt(mismatch.Line),
+			formatOptionalID(mismatch.TransactionID),
+			mismatch.ReqID,
+			mismatch.RoundID,
+			mismatch.PlayerID,
+			string(mismatch.Type),
+			mismatch.Currency,
+			formatOptionalAmount(mismatch.ProviderAmount),
+			formatOptionalAmount(mismatch.OurAmount),
+		})
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		zap.L().Info("Failed to write statement export",
+			zap.Uint64("import_id", statementImport.ID),
+			zap.Error(err))
+	}
+}
+
+func (h *StatementHandler) getImport(w http.ResponseWriter, r *http.Request) (*entities.StatementImport, bool) {
+	operatorID, ok := middleware.OperatorIDFromContext(r.Context())
+	if !ok {
+		httpUtils.ErrorResponse(w, http.StatusUnauthorized, middleware.ErrMissingOperator)
+		return nil, false
+	}
+
+	id, err := strconv.ParseUint(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		httpUtils.ErrorResponse(w, http.StatusBadRequest, service.ErrInvalidRequest)
+		return nil, false
+	}
+
+	kind := entities.StatementMismatchKind(r.URL.Query().Get("kind"))
+	statementImport, err := h.statementService.GetImport(r.Context(), operatorID, id, kind)
+	if err != nil {
+		writeStatementError(w, err)
+		return nil, false
+	}
+	return statementImport, true
+}
+
+func formatOptionalInt(value int) string {
+	if value == 0 {
+		return ""
+	}
+	return strconv.Itoa(value)
+}
+
+func formatOptionalID(value *uint64) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.FormatUint(*value, 10)
+}
+
+func formatOptionalAmount(value *float64) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*value, 'f', 2, 64)
+}
+
+func writeStatementError(w http.ResponseWriter, err error) {
+	var maxBytesError *http.MaxBytesError
+	switch {
+	case errors.As(err, &maxBytesError):
+		httpUtils.ErrorResponse(w, http.StatusRequestEntityTooLarge, err)
+	case errors.Is(err, providerstatement.ErrInvalidStatement),
+		errors.Is(err, service.ErrInvalidStatementDate),
+		errors.Is(err, service.ErrInvalidMismatchKind):
+		httpUtils.ErrorResponse(w, http.StatusBadRequest, err)
+	case errors.Is(err, service.ErrStatementProviderNotFound),
+		errors.Is(err, service.ErrStatementImportNotFound):
+		httpUtils.ErrorResponse(w, http.StatusNotFound, err)
+	case errors.Is(err, service.ErrStatementDateArchived):
+		httpUtils.ErrorResponse(w, http.StatusGone, err)
+	default:
+		zap.L().Error("Error while handling statement request", zap.Error(err))
+		httpUtils.ErrorResponse(w, http.StatusInternalServerError, err)
+	}
+}
@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/middleware"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/service"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/stream"
+	httpUtils "github.com/BarisKilicGsu/casino-wallet-service/internal/utils/http"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+const (
+	streamEventBalance      = "balance"
+	streamEventRoundSettled = "round_settled"
+)
+
+type StreamHandler struct {
+	walletService service.IWalletService
+	hub           *stream.Hub
+	heartbeat     time.Duration
+}
+
+func NewStreamHandler(walletService service.IWalletService, hub *stream.Hub, heartbeat time.Duration) *StreamHandler {
+	return &StreamHandler{
+		walletService: walletService,
+		hub:           hub,
+		heartbeat:     heartbeat,
+	}
+}
+
+// StreamBalance sends the current balance of the player as Server-Sent Events
+// followed by every committed change. A comment line is sent as heartbeat so
+// proxies keep the connection open and clients notice dead connections.
+func (h *StreamHandler) StreamBalance(w http.ResponseWriter, r *http.Request) {
+	operatorID, ok := middleware.OperatorIDFromContext(r.Context())
+	if !ok {
+		httpUtils.ErrorResponse(w, http.StatusUnauthorized, middleware.ErrMissingOperator)
+		return
+	}
+
+	playerID := mux.Vars(r)["player_id"]
+	if playerID == "" {
+		httpUtils.ErrorResponse(w, http.StatusBadRequest, service.ErrInvalidRequest)
+		return
+	}
+
+	// Subscribe before reading the balance, so no change between the two is missed
+	subscription, err := h.hub.Subscribe(operatorID, playerID)
+	if err != nil {
+		httpUtils.ErrorResponse(w, http.StatusServiceUnavailable, err)
+		return
+	}
+	defer h.hub.Unsubscribe(subscription)
+
+	player, err := h.walletService.GetPlayerBalance(r.Context(), operatorID, playerID)
+	if err != nil {
+		zap.L().Error("Error while getting player balance for stream",
+			zap.String("player_id", playerID),
+			zap.Error(err))
+		if contextErrorResponse(w, r) {
+			return
+		}
+		httpUtils.ErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	controller := http.NewResponseController(w)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	// Stops nginx from buffering the stream
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	snapshot := entities.BalanceNotification{
+		OperatorID: player.OperatorID,
+		PlayerID:   player.ID,
+		WalletID:   player.WalletID,
+		Balance:    player.Balance,
+		Currency:   player.Currency,
+		UpdatedAt:  player.UpdatedAt,
+	}
+	if err := writeStreamEvent(w, streamEventBalance, snapshot); err != nil {
+		return
+	}
+	if err := controller.Flush(); err != nil {
+		zap.L().Error("Balance stream does not support flushing", zap.Error(err))
+		return
+	}
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		case notification, ok := <-subscription.C:
+			if !ok {
+				return
+			}
+			if err := writeStreamEvent(w, streamEventBalance, notification); err != nil {
+				return
+			}
+			if notification.TransactionType == entities.TransactionTypeResult {
+				if err := writeStreamEvent(w, streamEventRoundSettled, notification); err != nil {
+					return
+				}
+			}
+		}
+		if err := controller.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+func writeStreamEvent(w http.ResponseWriter, event string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, payload)
+	return err
+}
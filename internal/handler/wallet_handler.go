@@ -2,12 +2,17 @@ package handler
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
+	"strconv"
 
 	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/middleware"
 	"github.com/BarisKilicGsu/casino-wallet-service/internal/service"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/tracing"
 	httpUtils "github.com/BarisKilicGsu/casino-wallet-service/internal/utils/http"
 	"github.com/BarisKilicGsu/casino-wallet-service/models"
 	"github.com/go-openapi/strfmt"
@@ -26,8 +31,17 @@ func NewWalletHandler(walletService service.IWalletService) *WalletHandler {
 }
 
 func (h *WalletHandler) GetPlayerBalance(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.Tracer().Start(r.Context(), "WalletHandler.GetPlayerBalance")
+	defer span.End()
+
 	zap.L().Debug("Received get player balance request")
 
+	operatorID, ok := middleware.OperatorIDFromContext(r.Context())
+	if !ok {
+		httpUtils.ErrorResponse(w, http.StatusUnauthorized, middleware.ErrMissingOperator)
+		return
+	}
+
 	playerID := mux.Vars(r)["player_id"]
 	if playerID == "" {
 		zap.L().Warn("Missing player_id parameter in request")
@@ -35,11 +49,14 @@ func (h *WalletHandler) GetPlayerBalance(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	player, err := h.walletService.GetPlayerBalance(playerID)
+	player, err := h.walletService.GetPlayerBalance(ctx, operatorID, playerID)
 	if err != nil {
 		zap.L().Error("Error while getting player balance",
 			zap.String("player_id", playerID),
 			zap.Error(err))
+		if contextErrorResponse(w, r) {
+			return
+		}
 		httpUtils.ErrorResponse(w, http.StatusInternalServerError, err)
 		return
 	}
@@ -49,34 +66,81 @@ func (h *WalletHandler) GetPlayerBalance(w http.ResponseWriter, r *http.Request)
 		zap.String("player_id", playerID))
 }
 
-func (h *WalletHandler) GetAllPlayers(w http.ResponseWriter, r *http.Request) {
-	zap.L().Debug("Received get all players request")
+func (h *WalletHandler) ListPlayers(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.Tracer().Start(r.Context(), "WalletHandler.ListPlayers")
+	defer span.End()
 
-	players, err := h.walletService.GetAllPlayers()
-	if err != nil {
-		zap.L().Error("Error while getting all players", zap.Error(err))
-		httpUtils.ErrorResponse(w, http.StatusInternalServerError, err)
+	zap.L().Debug("Received list players request")
+
+	operatorID, ok := middleware.OperatorIDFromContext(r.Context())
+	if !ok {
+		httpUtils.ErrorResponse(w, http.StatusUnauthorized, middleware.ErrMissingOperator)
 		return
 	}
 
-	apiPlayers := make([]*models.PlayerResponse, len(players))
-	for i, player := range players {
-		apiPlayers[i] = player.ToApiResponse()
+	query := r.URL.Query()
+	includeTotal := false
+	if value := query.Get("include_total"); value != "" {
+		var err error
+		if includeTotal, err = strconv.ParseBool(value); err != nil {
+			httpUtils.ErrorResponse(w, http.StatusBadRequest, service.ErrInvalidRequest)
+			return
+		}
 	}
 
-	httpUtils.JSONResponse(w, http.StatusOK, models.AllPlayersResponse{
-		Players: apiPlayers,
+	page, err := h.walletService.ListPlayers(ctx, &service.ListPlayersRequest{
+		OperatorID:   operatorID,
+		Limit:        query.Get("limit"),
+		Cursor:       query.Get("cursor"),
+		Sort:         query.Get("sort"),
+		Currency:     query.Get("currency"),
+		Status:       query.Get("status"),
+		MinBalance:   query.Get("min_balance"),
+		MaxBalance:   query.Get("max_balance"),
+		CreatedFrom:  query.Get("created_from"),
+		CreatedTo:    query.Get("created_to"),
+		IncludeTotal: includeTotal,
 	})
-	zap.L().Info("Successfully returned all players",
-		zap.Int("player_count", len(players)))
+	if err != nil {
+		switch err {
+		case service.ErrInvalidPlayerLimit, service.ErrInvalidPlayerCursor, service.ErrInvalidPlayerSort,
+			service.ErrInvalidPlayerStatus, service.ErrInvalidPlayerFilter:
+			httpUtils.ErrorResponse(w, http.StatusBadRequest, err)
+			return
+		}
+		zap.L().Error("Error while listing players", zap.Error(err))
+		if contextErrorResponse(w, r) {
+			return
+		}
+		httpUtils.ErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	httpUtils.JSONResponse(w, http.StatusOK, page.ToApiResponse())
+	zap.L().Info("Successfully returned players",
+		zap.Int("player_count", len(page.Players)))
 }
 
 func (h *WalletHandler) ProcessEvent(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.Tracer().Start(r.Context(), "WalletHandler.ProcessEvent")
+	defer span.End()
+
 	zap.L().Debug("Received process event request")
 
+	operatorID, ok := middleware.OperatorIDFromContext(r.Context())
+	if !ok {
+		httpUtils.ErrorResponse(w, http.StatusUnauthorized, middleware.ErrMissingOperator)
+		return
+	}
+
 	var transactionRequest models.EventRequest
 
-	body, err := io.ReadAll(r.Body)
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, middleware.MaxEventBodyBytes))
+	var maxBytesError *http.MaxBytesError
+	if errors.As(err, &maxBytesError) {
+		httpUtils.ErrorResponse(w, http.StatusRequestEntityTooLarge, err)
+		return
+	}
 	if err != nil {
 		zap.L().Info("Failed to read request body",
 			zap.String("url path", r.URL.Path), zap.Error(err))
@@ -105,15 +169,20 @@ func (h *WalletHandler) ProcessEvent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Statement imports of the provider are matched against the transactions it sent
+	providerID, _ := middleware.ProviderIDFromContext(r.Context())
 	transaction := entities.Transaction{}
-	transaction.CreateFromEventRequest(transactionRequest)
+	transaction.CreateFromEventRequest(operatorID, providerID, transactionRequest)
 
-	err = h.walletService.ProcessTransaction(&transaction)
+	err = h.walletService.ProcessTransaction(ctx, &transaction)
 	if err != nil {
 		zap.L().Error("Error while processing transaction",
 			zap.String("req_id", transaction.ReqID),
 			zap.String("type", string(transaction.Type)),
 			zap.Error(err))
+		if contextErrorResponse(w, r) {
+			return
+		}
 		switch err {
 		case service.ErrInsufficientBalance:
 			httpUtils.ErrorResponse(w, http.StatusBadRequest, err)
@@ -129,6 +198,14 @@ func (h *WalletHandler) ProcessEvent(w http.ResponseWriter, r *http.Request) {
 			httpUtils.ErrorResponse(w, http.StatusBadRequest, err)
 		case service.ErrDuplicateRound:
 			httpUtils.ErrorResponse(w, http.StatusBadRequest, err)
+		case service.ErrCurrencyNotAllowed:
+			httpUtils.ErrorResponse(w, http.StatusBadRequest, err)
+		case service.ErrCurrencyMismatch:
+			httpUtils.ErrorResponse(w, http.StatusBadRequest, err)
+		case service.ErrBetLimitExceeded:
+			httpUtils.ErrorResponse(w, http.StatusBadRequest, err)
+		case service.ErrConcurrentUpdate:
+			httpUtils.ErrorResponse(w, http.StatusServiceUnavailable, err)
 		default:
 			httpUtils.ErrorResponse(w, http.StatusInternalServerError, err)
 		}
@@ -140,3 +217,18 @@ func (h *WalletHandler) ProcessEvent(w http.ResponseWriter, r *http.Request) {
 		zap.String("req_id", transaction.ReqID),
 		zap.String("type", string(transaction.Type)))
 }
+
+// contextErrorResponse answers a request whose context ended while it was being
+// processed and reports whether it did. A request past its deadline gets a 503,
+// nothing is written when the client has already gone away.
+func contextErrorResponse(w http.ResponseWriter, r *http.Request) bool {
+	switch r.Context().Err() {
+	case context.DeadlineExceeded:
+		httpUtils.ErrorResponse(w, http.StatusServiceUnavailable, middleware.ErrRequestTimeout)
+		return true
+	case context.Canceled:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,234 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/middleware"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/service"
+	httpUtils "github.com/BarisKilicGsu/casino-wallet-service/internal/utils/http"
+	"github.com/BarisKilicGsu/casino-wallet-service/models"
+	"github.com/go-openapi/strfmt"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+type WebhookHandler struct {
+	webhookService service.IWebhookService
+}
+
+func NewWebhookHandler(webhookService service.IWebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+	}
+}
+
+func (h *WebhookHandler) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	operatorID, ok := middleware.OperatorIDFromContext(r.Context())
+	if !ok {
+		httpUtils.ErrorResponse(w, http.StatusUnauthorized, middleware.ErrMissingOperator)
+		return
+	}
+
+	webhooks, err := h.webhookService.ListWebhooks(r.Context(), operatorID)
+	if err != nil {
+		zap.L().Error("Error while listing webhooks", zap.Error(err))
+		httpUtils.ErrorResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	apiResponses := make([]*models.WebhookResponse, len(webhooks))
+	for i, webhook := range webhooks {
+		apiResponses[i] = webhook.ToApiResponse()
+	}
+
+	httpUtils.JSONResponse(w, http.StatusOK, models.AllWebhooksResponse{
+		Webhooks: apiResponses,
+	})
+}
+
+func (h *WebhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	operatorID, ok := middleware.OperatorIDFromContext(r.Context())
+	if !ok {
+		httpUtils.ErrorResponse(w, http.StatusUnauthorized, middleware.ErrMissingOperator)
+		return
+	}
+
+	request, ok := decodeWebhookRequest(w, r)
+	if !ok {
+		return
+	}
+
+	webhook, secret, err := h.webhookService.CreateWebhook(r.Context(), &entities.Webhook{
+		OperatorID: operatorID,
+		URL:        *request.URL,
+		EventTypes: request.EventTypes,
+		Active:     request.Active == nil || *request.Active,
+	})
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+
+	httpUtils.JSONResponse(w, http.StatusCreated, models.CreatedWebhookResponse{
+		Secret:  secret,
+		Webhook: webhook.ToApiResponse(),
+	})
+}
+
+func (h *WebhookHandler) UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	operatorID, ok := middleware.OperatorIDFromContext(r.Context())
+	if !ok {
+		httpUtils.ErrorResponse(w, http.StatusUnauthorized, middleware.ErrMissingOperator)
+		return
+	}
+
+	id, err := strconv.ParseUint(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		httpUtils.ErrorResponse(w, http.StatusBadRequest, service.ErrInvalidRequest)
+		return
+	}
+
+	request, ok := decodeWebhookRequest(w, r)
+	if !ok {
+		return
+	}
+
+	webhook, err := h.webhookService.UpdateWebhook(r.Context(), &entities.Webhook{
+		ID:         id,
+		OperatorID: operatorID,
+		URL:        *request.URL,
+		EventTypes: request.EventTypes,
+		Active:     request.Active == nil || *request.Active,
+	})
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+
+	httpUtils.JSONResponse(w, http.StatusOK, webhook.ToApiResponse())
+}
+
+func (h *WebhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	operatorID, ok := middleware.OperatorIDFromContext(r.Context())
+	if !ok {
+		httpUtils.ErrorResponse(w, http.StatusUnauthorized, middleware.ErrMissingOperator)
+		return
+	}
+
+	id, err := strconv.ParseUint(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		httpUtils.ErrorResponse(w, http.StatusBadRequest, service.ErrInvalidRequest)
+		return
+	}
+
+	if err := h.webhookService.DeleteWebhook(r.Context(), operatorID, id); err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+
+	httpUtils.JSONResponseNoData(w, http.StatusOK)
+}
+
+func (h *WebhookHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	operatorID, ok := middleware.OperatorIDFromContext(r.Context())
+	if !ok {
+		httpUtils.ErrorResponse(w, http.StatusUnauthorized, middleware.ErrMissingOperator)
+		return
+	}
+
+	id, err := strconv.ParseUint(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		httpUtils.ErrorResponse(w, http.StatusBadRequest, service.ErrInvalidRequest)
+		return
+	}
+
+	deliveries, err := h.webhookService.ListDeliveries(r.Context(), operatorID, id)
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+
+	apiResponses := make([]*models.WebhookDeliveryResponse, len(deliveries))
+	for i, delivery := range deliveries {
+		apiResponses[i] = delivery.ToApiResponse(false)
+	}
+
+	httpUtils.JSONResponse(w, http.StatusOK, models.AllWebhookDeliveriesResponse{
+		Deliveries: apiResponses,
+	})
+}
+
+func (h *WebhookHandler) GetDelivery(w http.ResponseWriter, r *http.Request) {
+	operatorID, ok := middleware.OperatorIDFromContext(r.Context())
+	if !ok {
+		httpUtils.ErrorResponse(w, http.StatusUnauthorized, middleware.ErrMissingOperator)
+		return
+	}
+
+	id, err := strconv.ParseUint(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		httpUtils.ErrorResponse(w, http.StatusBadRequest, service.ErrInvalidRequest)
+		return
+	}
+
+	delivery, err := h.webhookService.GetDelivery(r.Context(), operatorID, id)
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+
+	httpUtils.JSONResponse(w, http.StatusOK, delivery.ToApiResponse(true))
+}
+
+func (h *WebhookHandler) Redeliver(w http.ResponseWriter, r *http.Request) {
+	operatorID, ok := middleware.OperatorIDFromContext(r.Context())
+	if !ok {
+		httpUtils.ErrorResponse(w, http.StatusUnauthorized, middleware.ErrMissingOperator)
+		return
+	}
+
+	id, err := strconv.ParseUint(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		httpUtils.ErrorResponse(w, http.StatusBadRequest, service.ErrInvalidRequest)
+		return
+	}
+
+	delivery, err := h.webhookService.Redeliver(r.Context(), operatorID, id)
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+
+	httpUtils.JSONResponse(w, http.StatusOK, delivery.ToApiResponse(true))
+}
+
+func decodeWebhookRequest(w http.ResponseWriter, r *http.Request) (*models.WebhookRequest, bool) {
+	var request models.WebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		zap.L().Info("Failed to decode webhook request",
+			zap.String("url path", r.URL.Path),
+			zap.Error(err))
+		httpUtils.ErrorResponse(w, http.StatusBadRequest, err)
+		return nil, false
+	}
+	if err := request.Validate(strfmt.Default); err != nil {
+		httpUtils.ErrorResponse(w, http.StatusBadRequest, err)
+		return nil, false
+	}
+	return &request, true
+}
+
+func writeWebhookError(w http.ResponseWriter, err error) {
+	switch err {
+	case service.ErrInvalidWebhookURL, service.ErrInvalidWebhookEventType:
+		httpUtils.ErrorResponse(w, http.StatusBadRequest, err)
+	case service.ErrWebhookNotFound, service.ErrWebhookDeliveryNotFound:
+		httpUtils.ErrorResponse(w, http.StatusNotFound, err)
+	default:
+		zap.L().Error("Error while handling webhook request", zap.Error(err))
+		httpUtils.ErrorResponse(w, http.StatusInternalServerError, err)
+	}
+}
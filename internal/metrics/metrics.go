@@ -0,0 +1,212 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "wallet"
+
+var (
+	RateLimitedRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rate_limited_requests_total",
+		Help:      "Requests rejected with 429, by the limit that rejected them.",
+	}, []string{"scope"})
+
+	RateLimiterTrackedKeys = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "rate_limiter_tracked_keys",
+		Help:      "Number of clients or players that currently have a token bucket.",
+	}, []string{"scope"})
+
+	InFlightWrites = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "inflight_write_transactions",
+		Help:      "Write requests currently holding a database transaction slot.",
+	})
+
+	InFlightWritesLimit = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "inflight_write_transactions_limit",
+		Help:      "Maximum number of concurrent write requests.",
+	})
+)
+
+var (
+	HTTPRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by route template, method and status code.",
+	}, []string{"route", "method", "status"})
+
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by route template, method and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method", "status"})
+)
+
+var (
+	TransactionsProcessed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "transactions_processed_total",
+		Help:      "Bet and result events processed by the wallet service, by outcome.",
+	}, []string{"type", "outcome"})
+
+	TransactionProcessingDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "transaction_processing_duration_seconds",
+		Help:      "Time spent processing a bet or result event, by outcome.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"type", "outcome"})
+)
+
+var (
+	DBTransactions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "db_transactions_total",
+		Help:      "Finished database transactions by outcome (commit, rollback, commit_error).",
+	}, []string{"outcome"})
+
+	DBTransactionDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "db_transaction_duration_seconds",
+		Help:      "Time between begin and commit or rollback of a database transaction.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"outcome"})
+
+	DBLockWait = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "db_lock_wait_seconds",
+		Help:      "Duration of SELECT ... FOR UPDATE queries, which is dominated by waiting for row locks.",
+		Buckets:   []float64{.0005, .001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+	}, []string{"query"})
+
+	DBTransactionRetries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "db_transaction_retries_total",
+		Help:      "Transactions retried after the database aborted them, by reason (serialization_failure, deadlock_detected, database_busy, version_conflict).",
+	}, []string{"reason"})
+
+	DBTransactionRetriesExhausted = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "db_transaction_retries_exhausted_total",
+		Help:      "Transactions that still failed with a retryable error after the last attempt, by reason.",
+	}, []string{"reason"})
+)
+
+var (
+	OutboxPublished = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "outbox_published_total",
+		Help:      "Outbox events acknowledged by the event publisher, by event type.",
+	}, []string{"type"})
+
+	OutboxPublishFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "outbox_publish_failures_total",
+		Help:      "Failed publish attempts of outbox events, by event type.",
+	}, []string{"type"})
+
+	OutboxDeadLettered = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "outbox_dead_lettered_total",
+		Help:      "Outbox events moved to the dead-letter table after the last attempt, by event type.",
+	}, []string{"type"})
+
+	OutboxPending = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "outbox_pending_events",
+		Help:      "Events in the outbox that are not published yet.",
+	})
+
+	OutboxPublishLag = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "outbox_publish_lag_seconds",
+		Help:      "Time between writing an outbox event and its successful publish.",
+		Buckets:   []float64{.01, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60, 300},
+	})
+)
+
+var (
+	WebhookDeliveries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "webhook_delivery_attempts_total",
+		Help:      "Webhook delivery attempts by outcome (delivered, retry, failed).",
+	}, []string{"outcome"})
+
+	WebhookDeliveryDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "webhook_delivery_duration_seconds",
+		Help:      "Duration of webhook HTTP requests.",
+		Buckets:   prometheus.DefBuckets,
+	})
+)
+
+var BalanceStreams = promauto.NewGauge(prometheus.GaugeOpts{
+	Namespace: namespace,
+	Name:      "balance_streams",
+	Help:      "Open balance streams on this replica.",
+})
+
+var (
+	ReconciliationRuns = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reconciliation_runs_total",
+		Help:      "Balance reconciliation runs by outcome (success, error).",
+	}, []string{"outcome"})
+
+	ReconciliationDiscrepancies = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "reconciliation_discrepancies",
+		Help:      "Wallets whose balance differed from their transactions in the last run over all operators.",
+	})
+)
+
+var GGRRollups = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "ggr_rollups_total",
+	Help:      "Daily GGR statistics rollups by outcome (success, skipped, error).",
+}, []string{"outcome"})
+
+var BalanceCheckpointRuns = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "balance_checkpoint_runs_total",
+	Help:      "Balance checkpoint runs by outcome (success, skipped, error).",
+}, []string{"outcome"})
+
+var (
+	TransactionPartitionRuns = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "transaction_partition_runs_total",
+		Help:      "Transaction partition maintenance runs by outcome (success, skipped, error).",
+	}, []string{"outcome"})
+
+	TransactionsArchived = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "transactions_archived_total",
+		Help:      "Transactions moved from the database into archive files.",
+	})
+)
+
+var BalanceCacheRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "balance_cache_requests_total",
+	Help:      "Balance cache lookups by result (hit, miss, bypass, error).",
+}, []string{"result"})
+
+var (
+	DBReplicaLag = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "db_replica_lag_seconds",
+		Help:      "Replication lag of each read replica at its last check.",
+	}, []string{"replica"})
+
+	DBReads = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "db_reads_total",
+		Help:      "Reads outside of transactions by the server they were sent to (primary, replica) when replicas are configured.",
+	}, []string{"target"})
+)
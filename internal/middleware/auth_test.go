@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/service"
+	"github.com/BarisKilicGsu/casino-wallet-service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAPIKeyAuthenticatorRequire(t *testing.T) {
+	supportKey := &entities.APIKey{ID: 1, OperatorID: "operator-a", Role: entities.APIKeyRoleSupport}
+	financeKey := &entities.APIKey{ID: 2, OperatorID: "operator-b", Role: entities.APIKeyRoleFinance}
+
+	tests := []struct {
+		name         string
+		headers      map[string]string
+		authenticate func(apiKeyService *mocks.IAPIKeyService)
+		expected     int
+		operatorID   string
+	}{
+		{
+			name:     "missing key",
+			expected: http.StatusUnauthorized,
+		},
+		{
+			name:     "basic scheme",
+			headers:  map[string]string{"Authorization": "Basic c3VwcG9ydA=="},
+			expected: http.StatusUnauthorized,
+		},
+		{
+			name:     "bare key in authorization",
+			headers:  map[string]string{"Authorization": "support-key"},
+			expected: http.StatusUnauthorized,
+		},
+		{
+			name:    "unknown key",
+			headers: map[string]string{HeaderAPIKey: "unknown-key"},
+			authenticate: func(apiKeyService *mocks.IAPIKeyService) {
+				apiKeyService.On("Authenticate", mock.Anything, "unknown-key").Return(nil, service.ErrAPIKeyNotFound)
+			},
+			expected: http.StatusUnauthorized,
+		},
+		{
+			name:    "revoked key",
+			headers: map[string]string{HeaderAPIKey: "revoked-key"},
+			authenticate: func(apiKeyService *mocks.IAPIKeyService) {
+				apiKeyService.On("Authenticate", mock.Anything, "revoked-key").Return(nil, service.ErrAPIKeyRevoked)
+			},
+			expected: http.StatusUnauthorized,
+		},
+		{
+			name:    "lookup failure",
+			headers: map[string]string{HeaderAPIKey: "support-key"},
+			authenticate: func(apiKeyService *mocks.IAPIKeyService) {
+				apiKeyService.On("Authenticate", mock.Anything, "support-key").Return(nil, errors.New("connection refused"))
+			},
+			expected: http.StatusInternalServerError,
+		},
+		{
+			name:    "role not allowed",
+			headers: map[string]string{HeaderAPIKey: "finance-key"},
+			authenticate: func(apiKeyService *mocks.IAPIKeyService) {
+				apiKeyService.On("Authenticate", mock.Anything, "finance-key").Return(financeKey, nil)
+			},
+			expected: http.StatusForbidden,
+		},
+		{
+			name:    "api key header",
+			headers: map[string]string{HeaderAPIKey: "support-key"},
+			authenticate: func(apiKeyService *mocks.IAPIKeyService) {
+				apiKeyService.On("Authenticate", mock.Anything, "support-key").Return(supportKey, nil)
+			},
+			expected:   http.StatusOK,
+			operatorID: "operator-a",
+		},
+		{
+			name:    "bearer token",
+			headers: map[string]string{"Authorization": "bearer support-key"},
+			authenticate: func(apiKeyService *mocks.IAPIKeyService) {
+				apiKeyService.On("Authenticate", mock.Anything, "support-key").Return(supportKey, nil)
+			},
+			expected:   http.StatusOK,
+			operatorID: "operator-a",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiKeyService := mocks.NewIAPIKeyService(t)
+			if tt.authenticate != nil {
+				tt.authenticate(apiKeyService)
+			}
+
+			var seen *http.Request
+			handler := NewAPIKeyAuthenticator(apiKeyService).Require(entities.APIKeyRoleSupport, entities.APIKeyRoleAdmin)(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					seen = r
+					w.WriteHeader(http.StatusOK)
+				}))
+			r := httptest.NewRequest(http.MethodGet, "/players", nil)
+			for name, value := range tt.headers {
+				r.Header.Set(name, value)
+			}
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, r)
+
+			assert.Equal(t, tt.expected, recorder.Code)
+			if tt.expected != http.StatusOK {
+				assert.Nil(t, seen)
+				return
+			}
+			operatorID, ok := OperatorIDFromContext(seen.Context())
+			assert.True(t, ok)
+			assert.Equal(t, tt.operatorID, operatorID)
+			apiKey, ok := APIKeyFromContext(seen.Context())
+			assert.True(t, ok)
+			assert.Equal(t, supportKey, apiKey)
+		})
+	}
+}
@@ -0,0 +1,240 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/metrics"
+	httpUtils "github.com/BarisKilicGsu/casino-wallet-service/internal/utils/http"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+)
+
+const (
+	rateLimitScopeClient   = "client"
+	rateLimitScopePlayer   = "player"
+	rateLimitScopeInFlight = "inflight"
+
+	// Buckets that were not used for this long are dropped, a fresh bucket starts full anyway
+	limiterIdleTimeout = 10 * time.Minute
+
+	// MaxEventBodyBytes caps the JSON body of an event, every reader of the body applies it
+	MaxEventBodyBytes = 1 << 20
+)
+
+var (
+	ErrRateLimited     = errors.New("rate limit exceeded")
+	ErrTooManyInFlight = errors.New("too many concurrent write requests")
+)
+
+type RateLimitConfig struct {
+	ClientRate  float64
+	ClientBurst int
+	PlayerRate  float64
+	PlayerBurst int
+	// MaxInFlight of zero or less leaves concurrent writes uncapped
+	MaxInFlight  int
+	InFlightWait time.Duration
+}
+
+// RateLimiter keeps a token bucket per API client and per player and caps the
+// number of write requests that hold a database transaction at the same time.
+// State is kept in process memory, so limits apply per replica.
+type RateLimiter struct {
+	clients *limiterSet
+	players *limiterSet
+	// inFlight is nil when concurrent writes are not capped
+	inFlight     chan struct{}
+	inFlightWait time.Duration
+}
+
+func NewRateLimiter(config RateLimitConfig) *RateLimiter {
+	limiter := &RateLimiter{
+		clients:      newLimiterSet(rateLimitScopeClient, rate.Limit(config.ClientRate), config.ClientBurst),
+		players:      newLimiterSet(rateLimitScopePlayer, rate.Limit(config.PlayerRate), config.PlayerBurst),
+		inFlightWait: config.InFlightWait,
+	}
+	if config.MaxInFlight > 0 {
+		limiter.inFlight = make(chan struct{}, config.MaxInFlight)
+		metrics.InFlightWritesLimit.Set(float64(config.MaxInFlight))
+	}
+	return limiter
+}
+
+// LimitClient limits requests per authenticated caller. It must run after the
+// authentication middleware so the caller is known.
+func (l *RateLimiter) LimitClient(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !l.clients.allow(w, clientKey(r)) {
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// LimitPlayer limits requests per player. The player is taken from the
+// {player_id} route variable or from the player_id field of a JSON body.
+func (l *RateLimiter) LimitPlayer(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		playerID := mux.Vars(r)["player_id"]
+		if playerID == "" {
+			playerID = peekPlayerID(w, r)
+		}
+		if playerID != "" {
+			operatorID, _ := OperatorIDFromContext(r.Context())
+			if !l.players.allow(w, operatorID+"/"+playerID) {
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// LimitInFlight caps concurrent write requests. Requests wait shortly for a
+// free slot before they are rejected, so short bursts are smoothed out.
+func (l *RateLimiter) LimitInFlight(next http.Handler) http.Handler {
+	if l.inFlight == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		timer := time.NewTimer(l.inFlightWait)
+		defer timer.Stop()
+
+		select {
+		case l.inFlight <- struct{}{}:
+		case <-timer.C:
+			metrics.RateLimitedRequests.WithLabelValues(rateLimitScopeInFlight).Inc()
+			zap.L().Warn("Rejected request, too many in-flight writes",
+				zap.String("url path", r.URL.Path))
+			w.Header().Set("Retry-After", "1")
+			httpUtils.ErrorResponse(w, http.StatusTooManyRequests, ErrTooManyInFlight)
+			return
+		case <-r.Context().Done():
+			return
+		}
+
+		metrics.InFlightWrites.Inc()
+		defer func() {
+			<-l.inFlight
+			metrics.InFlightWrites.Dec()
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
+
+func clientKey(r *http.Request) string {
+	if providerID, ok := ProviderIDFromContext(r.Context()); ok {
+		return "provider/" + providerID
+	}
+	if apiKey, ok := APIKeyFromContext(r.Context()); ok {
+		return "api_key/" + strconv.FormatUint(apiKey.ID, 10)
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip/" + host
+}
+
+// peekPlayerID reads the player_id of a JSON body and leaves the body for the
+// next handler. A body over MaxEventBodyBytes has no player, the next reader
+// gets the same error after the bytes read here.
+func peekPlayerID(w http.ResponseWriter, r *http.Request) string {
+	if r.Body == nil {
+		return ""
+	}
+	limited := http.MaxBytesReader(w, r.Body, MaxEventBodyBytes)
+	body, err := io.ReadAll(limited)
+	r.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), limited))
+	if err != nil {
+		return ""
+	}
+
+	var payload struct {
+		PlayerID string `json:"player_id"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return ""
+	}
+	return payload.PlayerID
+}
+
+type limiterEntry struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+type limiterSet struct {
+	scope     string
+	limit     rate.Limit
+	burst     int
+	mu        sync.Mutex
+	entries   map[string]*limiterEntry
+	lastPrune time.Time
+}
+
+func newLimiterSet(scope string, limit rate.Limit, burst int) *limiterSet {
+	return &limiterSet{
+		scope:   scope,
+		limit:   limit,
+		burst:   burst,
+		entries: make(map[string]*limiterEntry),
+	}
+}
+
+// allow takes a token for the key and writes a 429 response when none is left
+func (s *limiterSet) allow(w http.ResponseWriter, key string) bool {
+	now := time.Now()
+	reservation := s.get(key, now).ReserveN(now, 1)
+	if !reservation.OK() {
+		s.reject(w, key, time.Second)
+		return false
+	}
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		s.reject(w, key, delay)
+		return false
+	}
+	return true
+}
+
+func (s *limiterSet) reject(w http.ResponseWriter, key string, retryAfter time.Duration) {
+	metrics.RateLimitedRequests.WithLabelValues(s.scope).Inc()
+	zap.L().Warn("Rejected request, rate limit exceeded",
+		zap.String("scope", s.scope),
+		zap.String("key", key))
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	httpUtils.ErrorResponse(w, http.StatusTooManyRequests, ErrRateLimited)
+}
+
+func (s *limiterSet) get(key string, now time.Time) *rate.Limiter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.lastPrune) > time.Minute {
+		for k, entry := range s.entries {
+			if now.Sub(entry.lastSeen) > limiterIdleTimeout {
+				delete(s.entries, k)
+			}
+		}
+		s.lastPrune = now
+	}
+
+	entry, ok := s.entries[key]
+	if !ok {
+		entry = &limiterEntry{limiter: rate.NewLimiter(s.limit, s.burst)}
+		s.entries[key] = entry
+	}
+	entry.lastSeen = now
+	metrics.RateLimiterTrackedKeys.WithLabelValues(s.scope).Set(float64(len(s.entries)))
+	return entry.limiter
+}
@@ -0,0 +1,140 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func okHandler(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+func TestLimitClient(t *testing.T) {
+	limiter := NewRateLimiter(RateLimitConfig{ClientRate: 0.001, ClientBurst: 2, PlayerRate: 1, PlayerBurst: 1})
+	handler := limiter.LimitClient(http.HandlerFunc(okHandler))
+
+	request := func(remoteAddr string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "/players", nil)
+		r.RemoteAddr = remoteAddr
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, r)
+		return recorder
+	}
+
+	assert.Equal(t, http.StatusOK, request("10.0.0.1:1000").Code)
+	assert.Equal(t, http.StatusOK, request("10.0.0.1:1001").Code)
+	recorder := request("10.0.0.1:1002")
+	assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	assert.NotEmpty(t, recorder.Header().Get("Retry-After"))
+
+	// Every client has its own bucket
+	assert.Equal(t, http.StatusOK, request("10.0.0.2:1000").Code)
+}
+
+func TestLimitPlayer(t *testing.T) {
+	limiter := NewRateLimiter(RateLimitConfig{ClientRate: 1, ClientBurst: 1, PlayerRate: 0.001, PlayerBurst: 1})
+	router := mux.NewRouter()
+	router.Handle("/players/{player_id}", limiter.LimitPlayer(http.HandlerFunc(okHandler)))
+	router.Handle("/event", limiter.LimitPlayer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The body is still there for the handler
+		body, err := io.ReadAll(r.Body)
+		if err != nil || !strings.Contains(string(body), "player_id") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})))
+
+	serve := func(r *http.Request) int {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, r)
+		return recorder.Code
+	}
+	event := func(playerID string) *http.Request {
+		return httptest.NewRequest(http.MethodPost, "/event", strings.NewReader(`{"player_id":"`+playerID+`","amount":1}`))
+	}
+
+	assert.Equal(t, http.StatusOK, serve(httptest.NewRequest(http.MethodGet, "/players/player-1", nil)))
+	assert.Equal(t, http.StatusTooManyRequests, serve(httptest.NewRequest(http.MethodGet, "/players/player-1", nil)))
+
+	// The player of a JSON body shares the bucket of the route variable
+	assert.Equal(t, http.StatusTooManyRequests, serve(event("player-1")))
+	assert.Equal(t, http.StatusOK, serve(event("player-2")))
+	assert.Equal(t, http.StatusTooManyRequests, serve(event("player-2")))
+}
+
+func TestLimitPlayerOversizedBody(t *testing.T) {
+	limiter := NewRateLimiter(RateLimitConfig{ClientRate: 1, ClientBurst: 1, PlayerRate: 0.001, PlayerBurst: 1})
+	var readErr error
+	handler := limiter.LimitPlayer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, readErr = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	body := `{"player_id":"player-1","padding":"` + strings.Repeat("x", MaxEventBodyBytes) + `"}`
+	for range 2 {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/event", strings.NewReader(body)))
+		// A body over the limit has no player, so no player bucket is used up
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		var maxBytesError *http.MaxBytesError
+		assert.ErrorAs(t, readErr, &maxBytesError, "the handler sees the limit as well")
+	}
+}
+
+func TestLimitInFlight(t *testing.T) {
+	limiter := NewRateLimiter(RateLimitConfig{MaxInFlight: 1, InFlightWait: 10 * time.Millisecond})
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	handler := limiter.LimitInFlight(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			close(entered)
+			<-release
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	slow := httptest.NewRecorder()
+	go func() {
+		defer wg.Done()
+		handler.ServeHTTP(slow, httptest.NewRequest(http.MethodPost, "/slow", nil))
+	}()
+	<-entered
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/event", nil))
+	assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	assert.Equal(t, "1", recorder.Header().Get("Retry-After"))
+
+	close(release)
+	wg.Wait()
+	require.Equal(t, http.StatusOK, slow.Code)
+
+	// The slot is free again
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/event", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestLimitInFlightDisabled(t *testing.T) {
+	for _, maxInFlight := range []int{0, -1} {
+		limiter := NewRateLimiter(RateLimitConfig{MaxInFlight: maxInFlight})
+		next := http.HandlerFunc(okHandler)
+		handler := limiter.LimitInFlight(next)
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/event", nil))
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Nil(t, limiter.inFlight, "MaxInFlight %d leaves writes uncapped", maxInFlight)
+	}
+}
@@ -0,0 +1,200 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/repository"
+	httpUtils "github.com/BarisKilicGsu/casino-wallet-service/internal/utils/http"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/utils/signature"
+	"go.uber.org/zap"
+)
+
+const (
+	HeaderProviderID = "X-Provider-Id"
+	HeaderTimestamp  = "X-Timestamp"
+	HeaderNonce      = "X-Nonce"
+	HeaderSignature  = "X-Signature"
+)
+
+var (
+	ErrMissingSignature = errors.New("missing request signature")
+	ErrUnknownProvider  = errors.New("unknown provider")
+	ErrInvalidSignature = errors.New("invalid request signature")
+	ErrStaleRequest     = errors.New("request timestamp outside of allowed window")
+	ErrReplayedRequest  = errors.New("request nonce already used")
+	ErrUnmappedProvider = errors.New("provider is not mapped to an operator")
+)
+
+// noncePruneInterval is the minimum time between removals of expired nonces
+const noncePruneInterval = time.Minute
+
+type providerIDKey struct{}
+
+// ProviderIDFromContext returns the provider that signed the request, if any
+func ProviderIDFromContext(ctx context.Context) (string, bool) {
+	providerID, ok := ctx.Value(providerIDKey{}).(string)
+	return providerID, ok
+}
+
+// SignatureVerifier authenticates provider callbacks. Every request must carry
+// the provider id, a unix timestamp, a unique nonce and a hex encoded
+// HMAC-SHA256 of "<timestamp>\n<nonce>\n<raw body>" computed with the
+// provider's shared secret.
+//
+// Each provider belongs to one operator. Providers without an explicit
+// mapping are rejected, unless a default operator is given for them.
+type SignatureVerifier struct {
+	secrets           map[string][]byte
+	providerOperators map[string]string
+	defaultOperatorID string
+	window            time.Duration
+	nonces            repository.INonceRepository
+	// lastPrune is the unix nano time expired nonces were last removed
+	lastPrune atomic.Int64
+	now       func() time.Time
+}
+
+// NewSignatureVerifier returns a verifier for the providers with a secret. An
+// empty defaultOperatorID rejects the providers without an operator mapping.
+func NewSignatureVerifier(secrets map[string]string, providerOperators map[string]string, defaultOperatorID string, window time.Duration, nonces repository.INonceRepository) *SignatureVerifier {
+	keys := make(map[string][]byte, len(secrets))
+	for providerID, secret := range secrets {
+		keys[providerID] = []byte(secret)
+	}
+	return &SignatureVerifier{
+		secrets:           keys,
+		providerOperators: providerOperators,
+		defaultOperatorID: defaultOperatorID,
+		window:            window,
+		nonces:            nonces,
+		now:               time.Now,
+	}
+}
+
+func (v *SignatureVerifier) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		providerID := r.Header.Get(HeaderProviderID)
+		timestamp := r.Header.Get(HeaderTimestamp)
+		nonce := r.Header.Get(HeaderNonce)
+		providedSignature := strings.TrimPrefix(r.Header.Get(HeaderSignature), "sha256=")
+
+		if providerID == "" || timestamp == "" || nonce == "" || providedSignature == "" {
+			zap.L().Warn("Rejected unsigned request", zap.String("url path", r.URL.Path))
+			httpUtils.ErrorResponse(w, http.StatusUnauthorized, ErrMissingSignature)
+			return
+		}
+
+		secret, ok := v.secrets[providerID]
+		if !ok {
+			zap.L().Warn("Rejected request from unknown provider",
+				zap.String("provider_id", providerID),
+				zap.String("url path", r.URL.Path))
+			httpUtils.ErrorResponse(w, http.StatusUnauthorized, ErrUnknownProvider)
+			return
+		}
+
+		unixSeconds, err := strconv.ParseInt(timestamp, 10, 64)
+		if err != nil {
+			httpUtils.ErrorResponse(w, http.StatusUnauthorized, ErrStaleRequest)
+			return
+		}
+		now := v.now()
+		requestTime := time.Unix(unixSeconds, 0)
+		if requestTime.Before(now.Add(-v.window)) || requestTime.After(now.Add(v.window)) {
+			zap.L().Warn("Rejected stale request",
+				zap.String("provider_id", providerID),
+				zap.Time("request_time", requestTime))
+			httpUtils.ErrorResponse(w, http.StatusUnauthorized, ErrStaleRequest)
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxEventBodyBytes))
+		var maxBytesError *http.MaxBytesError
+		if errors.As(err, &maxBytesError) {
+			httpUtils.ErrorResponse(w, http.StatusRequestEntityTooLarge, err)
+			return
+		}
+		if err != nil {
+			zap.L().Info("Failed to read request body",
+				zap.String("url path", r.URL.Path), zap.Error(err))
+			httpUtils.ErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+		// Handlers read the body again, so hand them a fresh reader
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		expected, err := hex.DecodeString(signature.Sign(string(secret), timestamp, nonce, body))
+		if err != nil {
+			httpUtils.ErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+		provided, err := hex.DecodeString(providedSignature)
+		if err != nil || !hmac.Equal(expected, provided) {
+			zap.L().Warn("Rejected request with invalid signature",
+				zap.String("provider_id", providerID),
+				zap.String("url path", r.URL.Path))
+			httpUtils.ErrorResponse(w, http.StatusUnauthorized, ErrInvalidSignature)
+			return
+		}
+
+		operatorID, ok := v.providerOperators[providerID]
+		if !ok {
+			if v.defaultOperatorID == "" {
+				zap.L().Warn("Rejected request from unmapped provider",
+					zap.String("provider_id", providerID),
+					zap.String("url path", r.URL.Path))
+				httpUtils.ErrorResponse(w, http.StatusForbidden, ErrUnmappedProvider)
+				return
+			}
+			operatorID = v.defaultOperatorID
+		}
+
+		// The nonce is only remembered once the signature is known to be valid,
+		// otherwise anyone could burn nonces of a legitimate provider
+		added, err := v.nonces.Add(r.Context(), providerID, nonce, now, now.Add(2*v.window), nil)
+		if err != nil {
+			zap.L().Error("Failed to store request nonce",
+				zap.String("provider_id", providerID),
+				zap.Error(err))
+			httpUtils.ErrorResponse(w, http.StatusInternalServerError, err)
+			return
+		}
+		if !added {
+			zap.L().Warn("Rejected replayed request",
+				zap.String("provider_id", providerID),
+				zap.String("nonce", nonce))
+			httpUtils.ErrorResponse(w, http.StatusUnauthorized, ErrReplayedRequest)
+			return
+		}
+		v.pruneNonces(r.Context(), now)
+
+		ctx := context.WithValue(r.Context(), providerIDKey{}, providerID)
+		ctx = withOperatorID(ctx, operatorID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// pruneNonces removes expired nonces in the background, at most once per
+// noncePruneInterval on this replica
+func (v *SignatureVerifier) pruneNonces(ctx context.Context, now time.Time) {
+	last := v.lastPrune.Load()
+	if now.Sub(time.Unix(0, last)) < noncePruneInterval || !v.lastPrune.CompareAndSwap(last, now.UnixNano()) {
+		return
+	}
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		if _, err := v.nonces.DeleteExpired(ctx, now, nil); err != nil {
+			zap.L().Warn("Failed to remove expired request nonces", zap.Error(err))
+		}
+	}()
+}
@@ -0,0 +1,212 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/repository"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/testutil"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/utils/signature"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "provider-secret"
+
+var testNow = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestVerifier(nonces repository.INonceRepository) *SignatureVerifier {
+	return newFallbackTestVerifier(nonces, "")
+}
+
+// newFallbackTestVerifier returns a verifier that also knows the unmapped provider-c
+func newFallbackTestVerifier(nonces repository.INonceRepository, defaultOperatorID string) *SignatureVerifier {
+	verifier := NewSignatureVerifier(
+		map[string]string{"provider-a": testSecret, "provider-b": testSecret, "provider-c": testSecret},
+		map[string]string{"provider-a": "operator-a", "provider-b": "operator-b"},
+		defaultOperatorID,
+		5*time.Minute,
+		nonces,
+	)
+	verifier.now = func() time.Time { return testNow }
+	return verifier
+}
+
+// signedRequest returns an /event request signed by providerID at requestTime
+func signedRequest(providerID, nonce string, requestTime time.Time, body string) *http.Request {
+	timestamp := strconv.FormatInt(requestTime.Unix(), 10)
+	r := httptest.NewRequest(http.MethodPost, "/event", strings.NewReader(body))
+	r.Header.Set(HeaderProviderID, providerID)
+	r.Header.Set(HeaderTimestamp, timestamp)
+	r.Header.Set(HeaderNonce, nonce)
+	r.Header.Set(HeaderSignature, "sha256="+signature.Sign(testSecret, timestamp, nonce, []byte(body)))
+	return r
+}
+
+// serve runs r through the verifier and returns the response and the context the handler saw
+func serve(verifier *SignatureVerifier, r *http.Request) (*httptest.ResponseRecorder, *http.Request) {
+	var seen *http.Request
+	recorder := httptest.NewRecorder()
+	verifier.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = r
+		w.WriteHeader(http.StatusOK)
+	})).ServeHTTP(recorder, r)
+	return recorder, seen
+}
+
+func TestSignatureVerifier(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  func() *http.Request
+		expected int
+	}{
+		{
+			name:     "valid",
+			request:  func() *http.Request { return signedRequest("provider-a", "nonce-1", testNow, `{"amount":1}`) },
+			expected: http.StatusOK,
+		},
+		{
+			name: "missing signature",
+			request: func() *http.Request {
+				r := signedRequest("provider-a", "nonce-1", testNow, `{}`)
+				r.Header.Del(HeaderSignature)
+				return r
+			},
+			expected: http.StatusUnauthorized,
+		},
+		{
+			name:     "unknown provider",
+			request:  func() *http.Request { return signedRequest("provider-x", "nonce-1", testNow, `{}`) },
+			expected: http.StatusUnauthorized,
+		},
+		{
+			name: "tampered body",
+			request: func() *http.Request {
+				r := signedRequest("provider-a", "nonce-1", testNow, `{"amount":1}`)
+				r.Body = httptest.NewRequest(http.MethodPost, "/event", strings.NewReader(`{"amount":1000}`)).Body
+				return r
+			},
+			expected: http.StatusUnauthorized,
+		},
+		{
+			name:     "timestamp before the window",
+			request:  func() *http.Request { return signedRequest("provider-a", "nonce-1", testNow.Add(-6*time.Minute), `{}`) },
+			expected: http.StatusUnauthorized,
+		},
+		{
+			name:     "timestamp after the window",
+			request:  func() *http.Request { return signedRequest("provider-a", "nonce-1", testNow.Add(6*time.Minute), `{}`) },
+			expected: http.StatusUnauthorized,
+		},
+		{
+			name:     "skew within the window",
+			request:  func() *http.Request { return signedRequest("provider-a", "nonce-1", testNow.Add(-4*time.Minute), `{}`) },
+			expected: http.StatusOK,
+		},
+		{
+			name: "body over the limit",
+			request: func() *http.Request {
+				return signedRequest("provider-a", "nonce-1", testNow, strings.Repeat("x", MaxEventBodyBytes+1))
+			},
+			expected: http.StatusRequestEntityTooLarge,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder, _ := serve(newTestVerifier(repository.NewMemoryNonceRepository()), tt.request())
+			assert.Equal(t, tt.expected, recorder.Code)
+		})
+	}
+}
+
+func TestSignatureVerifierSetsProviderAndOperator(t *testing.T) {
+	tests := []struct {
+		name              string
+		providerID        string
+		defaultOperatorID string
+		expected          int
+		operatorID        string
+	}{
+		{name: "mapped provider", providerID: "provider-b", expected: http.StatusOK, operatorID: "operator-b"},
+		{name: "unmapped provider", providerID: "provider-c", expected: http.StatusForbidden},
+		{name: "unmapped provider with fallback", providerID: "provider-c", defaultOperatorID: "default", expected: http.StatusOK, operatorID: "default"},
+		{name: "mapped provider with fallback", providerID: "provider-a", defaultOperatorID: "default", expected: http.StatusOK, operatorID: "operator-a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nonces := repository.NewMemoryNonceRepository()
+			verifier := newFallbackTestVerifier(nonces, tt.defaultOperatorID)
+			recorder, seen := serve(verifier, signedRequest(tt.providerID, "nonce-1", testNow, `{}`))
+			require.Equal(t, tt.expected, recorder.Code)
+			if tt.expected != http.StatusOK {
+				assert.Nil(t, seen)
+				// The rejected request did not use up its nonce
+				added, err := nonces.Add(context.Background(), tt.providerID, "nonce-1", testNow, testNow.Add(time.Minute), nil)
+				require.NoError(t, err)
+				assert.True(t, added)
+				return
+			}
+			providerID, _ := ProviderIDFromContext(seen.Context())
+			operatorID, _ := OperatorIDFromContext(seen.Context())
+			assert.Equal(t, tt.providerID, providerID)
+			assert.Equal(t, tt.operatorID, operatorID)
+		})
+	}
+}
+
+func TestSignatureVerifierRejectsReplays(t *testing.T) {
+	db := testutil.OpenSQLite(t)
+	stores := map[string]func() repository.INonceRepository{
+		"memory": func() repository.INonceRepository { return repository.NewMemoryNonceRepository() },
+		"sqlite": func() repository.INonceRepository {
+			return repository.NewNonceRepository(repository.NewGormRepository(db))
+		},
+	}
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			nonces := newStore()
+			verifier := newTestVerifier(nonces)
+
+			recorder, _ := serve(verifier, signedRequest("provider-a", name+"-nonce", testNow, `{}`))
+			require.Equal(t, http.StatusOK, recorder.Code)
+			recorder, _ = serve(verifier, signedRequest("provider-a", name+"-nonce", testNow, `{}`))
+			assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+
+			// Another provider may use the same nonce
+			recorder, _ = serve(verifier, signedRequest("provider-b", name+"-nonce", testNow, `{}`))
+			assert.Equal(t, http.StatusOK, recorder.Code)
+
+			// A request with an invalid signature does not use up the nonce
+			forged := signedRequest("provider-a", name+"-forged", testNow, `{}`)
+			forged.Header.Set(HeaderSignature, strings.Repeat("0", 64))
+			recorder, _ = serve(verifier, forged)
+			require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			recorder, _ = serve(verifier, signedRequest("provider-a", name+"-forged", testNow, `{}`))
+			assert.Equal(t, http.StatusOK, recorder.Code)
+
+			// Once expired the nonce can no longer be replayed within the window
+			later := newTestVerifier(nonces)
+			later.now = func() time.Time { return testNow.Add(11 * time.Minute) }
+			recorder, _ = serve(later, signedRequest("provider-a", name+"-nonce", testNow.Add(11*time.Minute), `{}`))
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		})
+	}
+}
+
+// Replicas share the nonces through the database, a request replayed to
+// another replica is rejected as well
+func TestSignatureVerifierRejectsReplaysOnOtherReplicas(t *testing.T) {
+	db := testutil.OpenSQLite(t)
+	first := newTestVerifier(repository.NewNonceRepository(repository.NewGormRepository(db)))
+	second := newTestVerifier(repository.NewNonceRepository(repository.NewGormRepository(db)))
+
+	recorder, _ := serve(first, signedRequest("provider-a", "nonce-1", testNow, `{}`))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	recorder, _ = serve(second, signedRequest("provider-a", "nonce-1", testNow, `{}`))
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+}
@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrMissingOperator = errors.New("request is not bound to an operator")
+
+type operatorIDKey struct{}
+
+// OperatorIDFromContext returns the operator of the authenticated caller. It is
+// set by the authentication middlewares and is the only source handlers use to
+// scope data, so clients can never pick a tenant themselves.
+func OperatorIDFromContext(ctx context.Context) (string, bool) {
+	operatorID, ok := ctx.Value(operatorIDKey{}).(string)
+	return operatorID, ok && operatorID != ""
+}
+
+func withOperatorID(ctx context.Context, operatorID string) context.Context {
+	return context.WithValue(ctx, operatorIDKey{}, operatorID)
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+var ErrRequestTimeout = errors.New("request timed out")
+
+// RequestTimeout bounds the time a request may spend in the service. The
+// deadline is put on the request context, which is passed down to the
+// database, so a slow query is cancelled and its transaction rolled back
+// instead of running on after the client has given up.
+type RequestTimeout struct {
+	defaultTimeout time.Duration
+	// routeTimeouts is keyed by route template, e.g. /wallet/{player_id}
+	routeTimeouts map[string]time.Duration
+}
+
+func NewRequestTimeout(defaultTimeout time.Duration, routeTimeouts map[string]time.Duration) *RequestTimeout {
+	return &RequestTimeout{
+		defaultTimeout: defaultTimeout,
+		routeTimeouts:  routeTimeouts,
+	}
+}
+
+func (t *RequestTimeout) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		timeout := t.timeoutFor(r)
+		if timeout <= 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func (t *RequestTimeout) timeoutFor(r *http.Request) time.Duration {
+	if current := mux.CurrentRoute(r); current != nil {
+		if template, err := current.GetPathTemplate(); err == nil {
+			if timeout, ok := t.routeTimeouts[template]; ok {
+				return timeout
+			}
+		}
+	}
+	return t.defaultTimeout
+}
@@ -0,0 +1,312 @@
+// Package migration applies numbered schema migrations. The applied version is
+// kept in schema_migrations the way golang-migrate keeps it, so both tools can
+// work on the same database.
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+type Dialect string
+
+const (
+	// Postgres migrations run under an advisory lock, so processes starting at
+	// the same time take turns
+	Postgres Dialect = "postgres"
+	// SQLite migrations need no lock, a transaction holds the write lock of the
+	// database from its start
+	SQLite Dialect = "sqlite"
+)
+
+// lockKey is the advisory lock held while migrating, next to the lock keys of the repositories
+const lockKey = 7_201_000_000
+
+var (
+	ErrDirty = errors.New("database is dirty, a migration failed halfway and has to be fixed by hand")
+	// ErrUnknownVersion is returned when the database is at a version none of the migrations has
+	ErrUnknownVersion = errors.New("database is at a version unknown to this build")
+	// ErrNoDown is returned when a migration to be reverted has no down file
+	ErrNoDown = errors.New("migration has no down file")
+	// fileNamePattern matches 000001_init_schema.up.sql
+	fileNamePattern = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+)
+
+// Migration is one numbered step of the schema
+type Migration struct {
+	Version uint64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status tells whether a migration is applied to the database
+type Status struct {
+	Migration
+	Applied bool
+}
+
+// Load reads the migrations in dir of fsys in version order. Files that are not
+// named like 000001_name.up.sql or 000001_name.down.sql are skipped.
+func Load(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	byVersion := make(map[uint64]*Migration)
+	for _, entry := range entries {
+		match := fileNamePattern.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version in %s: %w", entry.Name(), err)
+		}
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has files with different names", version)
+		}
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("migration %d has no up file", migration.Version)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// Migrator moves the schema of a database between the versions of its migrations.
+// Every migration runs in a transaction of its own together with the version
+// update, a failing one leaves the database at the version before it.
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+	dialect    Dialect
+}
+
+// New returns a migrator of db, migrations have to be in version order as Load returns them
+func New(db *sql.DB, migrations []Migration, dialect Dialect) *Migrator {
+	return &Migrator{
+		db:         db,
+		migrations: migrations,
+		dialect:    dialect,
+	}
+}
+
+// Up applies the migrations newer than the recorded version and returns how many it applied
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	applied := 0
+	err := m.locked(ctx, func(conn *sql.Conn) error {
+		for _, migration := range m.migrations {
+			ok, err := m.apply(ctx, conn, migration)
+			if err != nil {
+				return fmt.Errorf("migration %d_%s failed: %w", migration.Version, migration.Name, err)
+			}
+			if ok {
+				applied++
+			}
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down reverts the newest steps applied migrations, all of them when steps is
+// zero or less, and returns how many it reverted
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	reverted := 0
+	err := m.locked(ctx, func(conn *sql.Conn) error {
+		for steps <= 0 || reverted < steps {
+			ok, err := m.revert(ctx, conn)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return nil
+			}
+			reverted++
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Version returns the recorded version, 0 when no migration was applied yet
+func (m *Migrator) Version(ctx context.Context) (uint64, bool, error) {
+	if err := ensureTable(ctx, m.db); err != nil {
+		return 0, false, err
+	}
+	return readVersion(ctx, m.db)
+}
+
+// Status lists the migrations in version order with whether they are applied
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	version, _, err := m.Version(ctx)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]Status, len(m.migrations))
+	for i, migration := range m.migrations {
+		statuses[i] = Status{Migration: migration, Applied: migration.Version <= version}
+	}
+	return statuses, nil
+}
+
+// locked runs fn on a connection of its own, holding the advisory lock on Postgres
+func (m *Migrator) locked(ctx context.Context, fn func(conn *sql.Conn) error) (err error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if m.dialect == Postgres {
+		// The lock belongs to the session, it is released below or when the connection closes
+		if _, err := conn.ExecContext(ctx, fmt.Sprintf("SELECT pg_advisory_lock(%d)", lockKey)); err != nil {
+			return fmt.Errorf("failed to take migration lock: %w", err)
+		}
+		defer func() {
+			if _, unlockErr := conn.ExecContext(context.Background(), fmt.Sprintf("SELECT pg_advisory_unlock(%d)", lockKey)); unlockErr != nil && err == nil {
+				err = fmt.Errorf("failed to release migration lock: %w", unlockErr)
+			}
+		}()
+	}
+	if err := ensureTable(ctx, conn); err != nil {
+		return err
+	}
+	return fn(conn)
+}
+
+// apply runs the migration unless the database is already at its version or
+// later. The version is read in the transaction, so a process that waited for
+// another one does not apply the migration again.
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, migration Migration) (bool, error) {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+	current, dirty, err := readVersion(ctx, tx)
+	if err != nil {
+		return false, err
+	}
+	if dirty {
+		return false, ErrDirty
+	}
+	if migration.Version <= current {
+		return false, nil
+	}
+
+	if _, err := tx.ExecContext(ctx, migration.Up); err != nil {
+		return false, err
+	}
+	if err := setVersion(ctx, tx, migration.Version); err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
+
+// revert runs the down file of the migration the database is at and records
+// the version before it. It reports false when no migration is applied.
+func (m *Migrator) revert(ctx context.Context, conn *sql.Conn) (bool, error) {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+	current, dirty, err := readVersion(ctx, tx)
+	if err != nil {
+		return false, err
+	}
+	if dirty {
+		return false, ErrDirty
+	}
+	if current == 0 {
+		return false, nil
+	}
+	index := sort.Search(len(m.migrations), func(i int) bool {
+		return m.migrations[i].Version >= current
+	})
+	if index == len(m.migrations) || m.migrations[index].Version != current {
+		return false, fmt.Errorf("%w: %d", ErrUnknownVersion, current)
+	}
+	migration := m.migrations[index]
+	if migration.Down == "" {
+		return false, fmt.Errorf("%w: %d_%s", ErrNoDown, migration.Version, migration.Name)
+	}
+
+	if _, err := tx.ExecContext(ctx, migration.Down); err != nil {
+		return false, fmt.Errorf("migration %d_%s failed: %w", migration.Version, migration.Name, err)
+	}
+	var previous uint64
+	if index > 0 {
+		previous = m.migrations[index-1].Version
+	}
+	if err := setVersion(ctx, tx, previous); err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
+
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+type queryer interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+func ensureTable(ctx context.Context, db execer) error {
+	_, err := db.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS schema_migrations (version BIGINT NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)")
+	return err
+}
+
+// setVersion records version as the only row, like golang-migrate no row means no migration.
+// The version is a number, formatting it keeps the statement free of dialect specific placeholders.
+func setVersion(ctx context.Context, tx *sql.Tx, version uint64) error {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM schema_migrations"); err != nil {
+		return err
+	}
+	if version == 0 {
+		return nil
+	}
+	_, err := tx.ExecContext(ctx, fmt.Sprintf("INSERT INTO schema_migrations (version, dirty) VALUES (%d, FALSE)", version))
+	return err
+}
+
+// readVersion returns the recorded version, 0 when no migration was applied yet
+func readVersion(ctx context.Context, db queryer) (uint64, bool, error) {
+	var version uint64
+	var dirty bool
+	err := db.QueryRowContext(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	return version, dirty, err
+}
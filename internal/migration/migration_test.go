@@ -0,0 +1,210 @@
+package migration_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"testing/fstest"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/migration"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testMigrations() fstest.MapFS {
+	return fstest.MapFS{
+		"sql/000001_accounts.up.sql":   {Data: []byte("CREATE TABLE accounts (id INTEGER PRIMARY KEY);")},
+		"sql/000001_accounts.down.sql": {Data: []byte("DROP TABLE accounts;")},
+		"sql/000002_entries.up.sql":    {Data: []byte("CREATE TABLE entries (id INTEGER PRIMARY KEY); CREATE INDEX idx_entries ON entries (id);")},
+		"sql/000002_entries.down.sql":  {Data: []byte("DROP TABLE entries;")},
+		"sql/000010_audit.up.sql":      {Data: []byte("CREATE TABLE audit (id INTEGER PRIMARY KEY);")},
+		"sql/000010_audit.down.sql":    {Data: []byte("DROP TABLE audit;")},
+		"sql/README.md":                {Data: []byte("not a migration")},
+	}
+}
+
+func load(t *testing.T, fsys fstest.MapFS) []migration.Migration {
+	t.Helper()
+	migrations, err := migration.Load(fsys, "sql")
+	require.NoError(t, err)
+	return migrations
+}
+
+func tableExists(t *testing.T, db *sql.DB, table string) bool {
+	t.Helper()
+	var count int
+	require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?", table).Scan(&count))
+	return count == 1
+}
+
+func assertVersion(t *testing.T, migrator *migration.Migrator, expected uint64) {
+	t.Helper()
+	version, dirty, err := migrator.Version(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, expected, version)
+	assert.False(t, dirty)
+}
+
+func TestLoad(t *testing.T) {
+	migrations := load(t, testMigrations())
+	require.Len(t, migrations, 3)
+	assert.Equal(t, []uint64{1, 2, 10}, []uint64{migrations[0].Version, migrations[1].Version, migrations[2].Version})
+	assert.Equal(t, "entries", migrations[1].Name)
+	assert.Contains(t, migrations[1].Up, "CREATE TABLE entries")
+	assert.Equal(t, "DROP TABLE entries;", migrations[1].Down)
+
+	tests := []struct {
+		name  string
+		files fstest.MapFS
+	}{
+		{
+			name:  "no up file",
+			files: fstest.MapFS{"sql/000001_accounts.down.sql": {Data: []byte("DROP TABLE accounts;")}},
+		},
+		{
+			name: "different names",
+			files: fstest.MapFS{
+				"sql/000001_accounts.up.sql":   {Data: []byte("CREATE TABLE accounts (id INTEGER);")},
+				"sql/000001_balances.up.sql":   {Data: []byte("CREATE TABLE balances (id INTEGER);")},
+				"sql/000001_accounts.down.sql": {Data: []byte("DROP TABLE accounts;")},
+			},
+		},
+		{
+			name:  "version out of range",
+			files: fstest.MapFS{"sql/99999999999999999999_accounts.up.sql": {Data: []byte("CREATE TABLE accounts (id INTEGER);")}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := migration.Load(tt.files, "sql")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestMigratorUpAndDown(t *testing.T) {
+	ctx := context.Background()
+	db := testutil.OpenSQLiteDB(t)
+	migrator := migration.New(db, load(t, testMigrations()), migration.SQLite)
+
+	assertVersion(t, migrator, 0)
+	statuses, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	require.Len(t, statuses, 3)
+	for _, status := range statuses {
+		assert.False(t, status.Applied)
+	}
+
+	applied, err := migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, applied)
+	assertVersion(t, migrator, 10)
+	assert.True(t, tableExists(t, db, "audit"))
+
+	// Up is a no-op once the database is at the newest version
+	applied, err = migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, applied)
+
+	reverted, err := migrator.Down(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, reverted)
+	assertVersion(t, migrator, 2)
+	assert.False(t, tableExists(t, db, "audit"))
+	assert.True(t, tableExists(t, db, "entries"))
+	statuses, err = migrator.Status(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []bool{true, true, false}, []bool{statuses[0].Applied, statuses[1].Applied, statuses[2].Applied})
+
+	// Zero steps reverts everything
+	reverted, err = migrator.Down(ctx, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 2, reverted)
+	assertVersion(t, migrator, 0)
+	assert.False(t, tableExists(t, db, "accounts"))
+
+	reverted, err = migrator.Down(ctx, 0)
+	require.NoError(t, err)
+	assert.Zero(t, reverted)
+}
+
+func TestMigratorFailedMigration(t *testing.T) {
+	ctx := context.Background()
+	db := testutil.OpenSQLiteDB(t)
+	files := testMigrations()
+	files["sql/000002_entries.up.sql"] = &fstest.MapFile{Data: []byte("CREATE TABLE entries (id INTEGER PRIMARY KEY); CREATE TABLE broken (;")}
+	migrator := migration.New(db, load(t, files), migration.SQLite)
+
+	applied, err := migrator.Up(ctx)
+	assert.ErrorContains(t, err, "migration 2_entries failed")
+	assert.Equal(t, 1, applied)
+	// The failed migration was rolled back together with its version
+	assertVersion(t, migrator, 1)
+	assert.False(t, tableExists(t, db, "entries"))
+}
+
+func TestMigratorErrors(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("dirty", func(t *testing.T) {
+		db := testutil.OpenSQLiteDB(t)
+		migrator := migration.New(db, load(t, testMigrations()), migration.SQLite)
+		assertVersion(t, migrator, 0)
+		_, err := db.Exec("INSERT INTO schema_migrations (version, dirty) VALUES (1, TRUE)")
+		require.NoError(t, err)
+
+		_, err = migrator.Up(ctx)
+		assert.ErrorIs(t, err, migration.ErrDirty)
+		_, err = migrator.Down(ctx, 1)
+		assert.ErrorIs(t, err, migration.ErrDirty)
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		db := testutil.OpenSQLiteDB(t)
+		migrator := migration.New(db, load(t, testMigrations()), migration.SQLite)
+		assertVersion(t, migrator, 0)
+		_, err := db.Exec("INSERT INTO schema_migrations (version, dirty) VALUES (5, FALSE)")
+		require.NoError(t, err)
+
+		_, err = migrator.Down(ctx, 1)
+		assert.ErrorIs(t, err, migration.ErrUnknownVersion)
+	})
+
+	t.Run("no down file", func(t *testing.T) {
+		db := testutil.OpenSQLiteDB(t)
+		files := testMigrations()
+		delete(files, "sql/000010_audit.down.sql")
+		migrator := migration.New(db, load(t, files), migration.SQLite)
+		_, err := migrator.Up(ctx)
+		require.NoError(t, err)
+
+		_, err = migrator.Down(ctx, 1)
+		assert.ErrorIs(t, err, migration.ErrNoDown)
+		assertVersion(t, migrator, 10)
+	})
+}
+
+// The embedded SQLite migrations can be applied, reverted and applied again
+func TestSQLiteMigrations(t *testing.T) {
+	ctx := context.Background()
+	db := testutil.OpenSQLiteDB(t)
+	migrator := testutil.SQLiteMigrator(t, db)
+
+	applied, err := migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Positive(t, applied)
+	assert.True(t, tableExists(t, db, "transactions"))
+
+	reverted, err := migrator.Down(ctx, 0)
+	require.NoError(t, err)
+	assert.Equal(t, applied, reverted)
+	var tables int
+	// sqlite_sequence belongs to SQLite and stays once a table used AUTOINCREMENT
+	require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name <> 'schema_migrations' AND name NOT LIKE 'sqlite_%'").Scan(&tables))
+	assert.Zero(t, tables)
+
+	reapplied, err := migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, applied, reapplied)
+}
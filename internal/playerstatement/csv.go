@@ -0,0 +1,66 @@
+package playerstatement
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+)
+
+// The opening and closing balances are rows of their own, so the file keeps a
+// single set of columns
+const (
+	csvOpeningBalance = "opening_balance"
+	csvClosingBalance = "closing_balance"
+)
+
+var csvHeader = []string{"created_at", "type", "req_id", "round_id", "game_code", "currency", "amount", "balance"}
+
+type csvWriter struct {
+	writer   *csv.Writer
+	currency string
+	to       time.Time
+}
+
+func NewCSVWriter(w io.Writer) Writer {
+	return &csvWriter{writer: csv.NewWriter(w)}
+}
+
+func (c *csvWriter) ContentType() string {
+	return "text/csv"
+}
+
+func (c *csvWriter) WriteHeader(header Header) error {
+	c.currency = header.Currency
+	c.to = header.To
+	if err := c.writer.Write(csvHeader); err != nil {
+		return err
+	}
+	return c.writer.Write([]string{header.From.Format(time.RFC3339), csvOpeningBalance, "", "", "", c.currency, "", formatAmount(header.OpeningBalance)})
+}
+
+func (c *csvWriter) WriteLine(line Line) error {
+	transaction := line.Transaction
+	return c.writer.Write([]string{
+		transaction.CreatedAt.UTC().Format(time.RFC3339),
+		string(transaction.Type),
+		transaction.ReqID,
+		transaction.RoundID,
+		transaction.GameCode,
+		transaction.Currency,
+		formatAmount(line.Amount),
+		formatAmount(line.Balance),
+	})
+}
+
+func (c *csvWriter) WriteFooter(footer Footer) error {
+	if err := c.writer.Write([]string{c.to.Format(time.RFC3339), csvClosingBalance, "", "", "", c.currency, "", formatAmount(footer.ClosingBalance)}); err != nil {
+		return err
+	}
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
@@ -0,0 +1,240 @@
+package playerstatement
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	// A4 in points
+	pdfPageWidth  = 595.0
+	pdfPageHeight = 842.0
+	pdfMargin     = 40.0
+
+	pdfTitleSize  = 12.0
+	pdfFontSize   = 7.5
+	pdfLineHeight = 10.0
+
+	pdfTimeLayout = "2006-01-02 15:04:05"
+)
+
+// Objects written before the pages have fixed numbers. The catalog and the
+// page tree are written last, once every page is known.
+const (
+	pdfCatalogObject = iota + 1
+	pdfPagesObject
+	pdfFontObject
+	pdfBoldFontObject
+	pdfFirstPageObject
+)
+
+// Courier glyphs all have the same width, so columns line up by padding
+// without font metrics
+var (
+	pdfColumnWidths = []int{19, 6, 24, 16, 12, 14, 14}
+	pdfColumnTitles = []string{"Date (UTC)", "Type", "Req ID", "Round ID", "Game", "Amount", "Balance"}
+	// pdfRightAligned marks the amount columns
+	pdfRightAligned = []bool{false, false, false, false, false, true, true}
+)
+
+// pdfWriter writes a PDF page by page. Only the current page is kept in
+// memory, the rest of the document is the offset of every object for the
+// cross-reference table.
+type pdfWriter struct {
+	w   *countingWriter
+	err error
+
+	// offsets holds the file offset of every object by object number
+	offsets    []int64
+	nextObject int
+	pageIDs    []int
+
+	page       bytes.Buffer
+	pageNumber int
+	y          float64
+	header     Header
+}
+
+func NewPDFWriter(w io.Writer) Writer {
+	return &pdfWriter{
+		w:          &countingWriter{w: w},
+		nextObject: pdfFirstPageObject,
+	}
+}
+
+func (p *pdfWriter) ContentType() string {
+	return "application/pdf"
+}
+
+func (p *pdfWriter) WriteHeader(header Header) error {
+	p.header = header
+	// The binary comment tells transfer tools the file is not text
+	p.write("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	p.writeObject(pdfFontObject, "<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>")
+	p.writeObject(pdfBoldFontObject, "<< /Type /Font /Subtype /Type1 /BaseFont /Courier-Bold /Encoding /WinAnsiEncoding >>")
+
+	p.startPage()
+	p.text("F2", pdfTitleSize, "Account statement")
+	p.y -= pdfLineHeight
+	p.text("F1", pdfFontSize, fmt.Sprintf("Operator: %s   Player: %s   Wallet: %s", header.OperatorID, header.PlayerID, header.WalletID))
+	p.text("F1", pdfFontSize, fmt.Sprintf("Period: %s UTC to %s UTC", header.From.UTC().Format(pdfTimeLayout), header.To.UTC().Format(pdfTimeLayout)))
+	p.text("F1", pdfFontSize, fmt.Sprintf("Generated: %s UTC", header.GeneratedAt.UTC().Format(pdfTimeLayout)))
+	p.text("F2", pdfFontSize, fmt.Sprintf("Opening balance: %s %s", formatAmount(header.OpeningBalance), header.Currency))
+	p.y -= pdfLineHeight
+	p.tableHeader()
+	return p.err
+}
+
+func (p *pdfWriter) WriteLine(line Line) error {
+	if p.y < pdfMargin+pdfLineHeight {
+		p.finishPage()
+		p.startPage()
+		p.tableHeader()
+	}
+	transaction := line.Transaction
+	p.text("F1", pdfFontSize, pdfRow([]string{
+		transaction.CreatedAt.UTC().Format(pdfTimeLayout),
+		string(transaction.Type),
+		transaction.ReqID,
+		transaction.RoundID,
+		transaction.GameCode,
+		formatAmount(line.Amount),
+		formatAmount(line.Balance),
+	}))
+	return p.err
+}
+
+func (p *pdfWriter) WriteFooter(footer Footer) error {
+	// The summary is not split over two pages
+	if p.y < pdfMargin+6*pdfLineHeight {
+		p.finishPage()
+		p.startPage()
+	}
+	p.y -= pdfLineHeight
+	p.text("F1", pdfFontSize, fmt.Sprintf("Transactions: %d", footer.TransactionCount))
+	p.text("F1", pdfFontSize, fmt.Sprintf("Total bets: %s %s", formatAmount(footer.TotalBets), p.header.Currency))
+	p.text("F1", pdfFontSize, fmt.Sprintf("Total wins: %s %s", formatAmount(footer.TotalWins), p.header.Currency))
+	p.text("F2", pdfFontSize, fmt.Sprintf("Closing balance: %s %s", formatAmount(footer.ClosingBalance), p.header.Currency))
+	p.finishPage()
+
+	kids := make([]string, len(p.pageIDs))
+	for i, id := range p.pageIDs {
+		kids[i] = fmt.Sprintf("%d 0 R", id)
+	}
+	p.writeObject(pdfPagesObject, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(p.pageIDs)))
+	p.writeObject(pdfCatalogObject, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pdfPagesObject))
+
+	// Every cross-reference entry is exactly 20 bytes
+	xrefOffset := p.w.count
+	p.write(fmt.Sprintf("xref\n0 %d\n0000000000 65535 f \n", len(p.offsets)))
+	for _, offset := range p.offsets[1:] {
+		p.write(fmt.Sprintf("%010d 00000 n \n", offset))
+	}
+	p.write(fmt.Sprintf("trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(p.offsets), pdfCatalogObject, xrefOffset))
+	return p.err
+}
+
+func (p *pdfWriter) startPage() {
+	p.page.Reset()
+	p.pageNumber++
+	p.y = pdfPageHeight - pdfMargin
+}
+
+func (p *pdfWriter) tableHeader() {
+	p.text("F2", pdfFontSize, pdfRow(pdfColumnTitles))
+	// A rule under the titles
+	fmt.Fprintf(&p.page, "0.5 w %.2f %.2f m %.2f %.2f l S\n", pdfMargin, p.y+pdfLineHeight/2, pdfPageWidth-pdfMargin, p.y+pdfLineHeight/2)
+}
+
+// finishPage writes the current page with its content stream
+func (p *pdfWriter) finishPage() {
+	fmt.Fprintf(&p.page, "BT /F1 %.1f Tf %.2f %.2f Td (%s) Tj ET\n", pdfFontSize, pdfPageWidth/2-20, pdfMargin/2, pdfEscape("Page "+strconv.Itoa(p.pageNumber)))
+
+	contentID := p.allocate()
+	pageID := p.allocate()
+	p.writeObject(contentID, fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", p.page.Len(), p.page.String()))
+	p.writeObject(pageID, fmt.Sprintf("<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << /F1 %d 0 R /F2 %d 0 R >> >> /Contents %d 0 R >>",
+		pdfPagesObject, pdfPageWidth, pdfPageHeight, pdfFontObject, pdfBoldFontObject, contentID))
+	p.pageIDs = append(p.pageIDs, pageID)
+}
+
+// text writes a line at the left margin and moves down
+func (p *pdfWriter) text(font string, size float64, value string) {
+	fmt.Fprintf(&p.page, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, pdfMargin, p.y, pdfEscape(value))
+	p.y -= pdfLineHeight
+}
+
+func (p *pdfWriter) allocate() int {
+	id := p.nextObject
+	p.nextObject++
+	return id
+}
+
+func (p *pdfWriter) writeObject(id int, body string) {
+	for len(p.offsets) <= id {
+		p.offsets = append(p.offsets, 0)
+	}
+	p.offsets[id] = p.w.count
+	p.write(fmt.Sprintf("%d 0 obj\n%s\nendobj\n", id, body))
+}
+
+// write keeps the first error, later writes are skipped
+func (p *pdfWriter) write(value string) {
+	if p.err != nil {
+		return
+	}
+	_, p.err = io.WriteString(p.w, value)
+}
+
+// pdfRow pads and cuts the values to the column widths
+func pdfRow(values []string) string {
+	var row strings.Builder
+	for i, value := range values {
+		width := pdfColumnWidths[i]
+		if runes := []rune(value); len(runes) > width {
+			value = string(runes[:width-2]) + ".."
+		}
+		if i > 0 {
+			row.WriteByte(' ')
+		}
+		if pdfRightAligned[i] {
+			fmt.Fprintf(&row, "%*s", width, value)
+		} else {
+			fmt.Fprintf(&row, "%-*s", width, value)
+		}
+	}
+	return strings.TrimRight(row.String(), " ")
+}
+
+// pdfEscape encodes value for a literal string in WinAnsiEncoding. Latin-1
+// characters keep their code, characters the standard fonts cannot show are
+// replaced with a question mark.
+func pdfEscape(value string) string {
+	var escaped strings.Builder
+	for _, r := range value {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			escaped.WriteByte('\\')
+			escaped.WriteByte(byte(r))
+		case r >= 0x20 && r < 0x7f, r >= 0xa0 && r <= 0xff:
+			escaped.WriteByte(byte(r))
+		default:
+			escaped.WriteByte('?')
+		}
+	}
+	return escaped.String()
+}
+
+type countingWriter struct {
+	w     io.Writer
+	count int64
+}
+
+func (c *countingWriter) Write(b []byte) (int, error) {
+	n, err := c.w.Write(b)
+	c.count += int64(n)
+	return n, err
+}
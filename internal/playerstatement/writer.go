@@ -0,0 +1,64 @@
+// Package playerstatement writes account statements of a player. Writers
+// stream, they never hold more than a single page of the statement.
+package playerstatement
+
+import (
+	"errors"
+	"io"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+)
+
+const (
+	FormatCSV = "csv"
+	FormatPDF = "pdf"
+)
+
+var ErrInvalidFormat = errors.New("statement format must be csv or pdf")
+
+// Header opens a statement. To is exclusive.
+type Header struct {
+	OperatorID     string
+	PlayerID       string
+	WalletID       string
+	Currency       string
+	From           time.Time
+	To             time.Time
+	OpeningBalance float64
+	GeneratedAt    time.Time
+}
+
+// Line is a transaction with the balance after it. Amount is negative for bets.
+type Line struct {
+	Transaction *entities.Transaction
+	Amount      float64
+	Balance     float64
+}
+
+type Footer struct {
+	ClosingBalance   float64
+	TransactionCount int
+	TotalBets        float64
+	TotalWins        float64
+}
+
+// Writer is called with the header once, every line in order, then the footer
+type Writer interface {
+	WriteHeader(header Header) error
+	WriteLine(line Line) error
+	WriteFooter(footer Footer) error
+	ContentType() string
+}
+
+// NewWriter returns the writer of format writing to w
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return NewCSVWriter(w), nil
+	case FormatPDF:
+		return NewPDFWriter(w), nil
+	default:
+		return nil, ErrInvalidFormat
+	}
+}
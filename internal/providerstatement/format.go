@@ -0,0 +1,131 @@
+// Package providerstatement parses the transaction statements providers send
+// for the import that matches them against our transactions.
+package providerstatement
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+)
+
+var ErrInvalidFormat = errors.New("invalid statement format")
+
+// Columns names the CSV header or JSON key each field is read from. Either
+// ReqID or RoundID has to be set, rows are matched by them.
+type Columns struct {
+	ReqID    string `json:"req_id"`
+	RoundID  string `json:"round_id"`
+	PlayerID string `json:"player_id"`
+	Type     string `json:"type"`
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// Format describes the statement file of one provider
+type Format struct {
+	// Type is csv or json
+	Type string `json:"type"`
+	// Delimiter separates CSV columns, defaults to a comma
+	Delimiter string `json:"delimiter"`
+	// RecordsPath is the dot separated path of the record array in a JSON
+	// statement, empty when the document itself is the array
+	RecordsPath string  `json:"records_path"`
+	Columns     Columns `json:"columns"`
+	// TypeValues maps the provider's type values to bet and result, bet and
+	// result themselves are always understood
+	TypeValues map[string]entities.TransactionType `json:"type_values"`
+	// AmountInMinorUnits is set when amounts are sent in cents
+	AmountInMinorUnits bool `json:"amount_in_minor_units"`
+	// Timezone is the IANA zone the statement day is counted in, defaults to UTC
+	Timezone string `json:"timezone"`
+
+	location *time.Location
+}
+
+// DefaultFormat is used for providers without a configured format
+func DefaultFormat() Format {
+	return Format{
+		Type:      FormatCSV,
+		Delimiter: ",",
+		Columns: Columns{
+			ReqID:    "req_id",
+			RoundID:  "round_id",
+			PlayerID: "player_id",
+			Type:     "type",
+			Amount:   "amount",
+			Currency: "currency",
+		},
+		location: time.UTC,
+	}
+}
+
+// Location is the time zone the statement day is counted in
+func (f Format) Location() *time.Location {
+	if f.location == nil {
+		return time.UTC
+	}
+	return f.location
+}
+
+func (f *Format) validate() error {
+	if f.Type != FormatCSV && f.Type != FormatJSON {
+		return fmt.Errorf("%w: type must be csv or json", ErrInvalidFormat)
+	}
+	if f.Delimiter == "" {
+		f.Delimiter = ","
+	}
+	if len([]rune(f.Delimiter)) != 1 {
+		return fmt.Errorf("%w: delimiter must be a single character", ErrInvalidFormat)
+	}
+	if f.Columns.ReqID == "" && f.Columns.RoundID == "" {
+		return fmt.Errorf("%w: req_id or round_id column is required", ErrInvalidFormat)
+	}
+	if f.Columns.Type == "" || f.Columns.Amount == "" {
+		return fmt.Errorf("%w: type and amount columns are required", ErrInvalidFormat)
+	}
+	for value, transactionType := range f.TypeValues {
+		if transactionType != entities.TransactionTypeBet && transactionType != entities.TransactionTypeResult {
+			return fmt.Errorf("%w: type value %q must map to bet or result", ErrInvalidFormat, value)
+		}
+	}
+
+	location, err := time.LoadLocation(f.Timezone)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidFormat, err)
+	}
+	f.location = location
+	return nil
+}
+
+// LoadFormats reads the provider formats from a JSON file keyed by provider id.
+// An empty path configures no formats, every provider then uses DefaultFormat.
+func LoadFormats(path string) (map[string]Format, error) {
+	formats := make(map[string]Format)
+	if path == "" {
+		return formats, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(content, &formats); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFormat, err)
+	}
+	for providerID, format := range formats {
+		if err := format.validate(); err != nil {
+			return nil, fmt.Errorf("provider %s: %w", providerID, err)
+		}
+		formats[providerID] = format
+	}
+	return formats, nil
+}
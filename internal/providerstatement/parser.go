@@ -0,0 +1,194 @@
+package providerstatement
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+)
+
+var ErrInvalidStatement = errors.New("invalid statement")
+
+// Row is one bet or win of a provider statement. Line is the CSV line or the
+// position of the JSON record, counted from 1.
+type Row struct {
+	Line     int
+	ReqID    string
+	RoundID  string
+	PlayerID string
+	Type     entities.TransactionType
+	Amount   float64
+	Currency string
+}
+
+// Parse reads every row of the statement. The whole statement is rejected
+// when a single row cannot be read, a partial import would report its
+// missing rows as mismatches.
+func Parse(reader io.Reader, format Format) ([]Row, error) {
+	switch format.Type {
+	case FormatCSV:
+		return parseCSV(reader, format)
+	case FormatJSON:
+		return parseJSON(reader, format)
+	default:
+		return nil, fmt.Errorf("%w: unsupported format %q", ErrInvalidFormat, format.Type)
+	}
+}
+
+func parseCSV(reader io.Reader, format Format) ([]Row, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.Comma = []rune(format.Delimiter)[0]
+	csvReader.TrimLeadingSpace = true
+	csvReader.ReuseRecord = true
+
+	header, err := csvReader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading header: %v", ErrInvalidStatement, err)
+	}
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		index[strings.TrimSpace(name)] = i
+	}
+	for _, column := range format.columnNames() {
+		if _, ok := index[column]; !ok {
+			return nil, fmt.Errorf("%w: column %q is missing", ErrInvalidStatement, column)
+		}
+	}
+
+	var rows []Row
+	for {
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidStatement, err)
+		}
+		line, _ := csvReader.FieldPos(0)
+		row, err := format.newRow(line, func(column string) string {
+			return strings.TrimSpace(record[index[column]])
+		})
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+}
+
+func parseJSON(reader io.Reader, format Format) ([]Row, error) {
+	decoder := json.NewDecoder(reader)
+	// Numbers are kept as written, float64 would round long ids
+	decoder.UseNumber()
+	var document interface{}
+	if err := decoder.Decode(&document); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidStatement, err)
+	}
+
+	if format.RecordsPath != "" {
+		for _, key := range strings.Split(format.RecordsPath, ".") {
+			object, ok := document.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%w: %q is not an object", ErrInvalidStatement, key)
+			}
+			document = object[key]
+		}
+	}
+	records, ok := document.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: records are not an array", ErrInvalidStatement)
+	}
+
+	rows := make([]Row, 0, len(records))
+	for i, item := range records {
+		record, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%w: record %d is not an object", ErrInvalidStatement, i+1)
+		}
+		row, err := format.newRow(i+1, func(key string) string {
+			return strings.TrimSpace(jsonString(record[key]))
+		})
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func jsonString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// columnNames are the configured columns that have to be present
+func (f Format) columnNames() []string {
+	var names []string
+	for _, name := range []string{f.Columns.ReqID, f.Columns.RoundID, f.Columns.PlayerID, f.Columns.Type, f.Columns.Amount, f.Columns.Currency} {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func (f Format) newRow(line int, value func(column string) string) (Row, error) {
+	row := Row{Line: line}
+	if f.Columns.ReqID != "" {
+		row.ReqID = value(f.Columns.ReqID)
+	}
+	if f.Columns.RoundID != "" {
+		row.RoundID = value(f.Columns.RoundID)
+	}
+	if f.Columns.PlayerID != "" {
+		row.PlayerID = value(f.Columns.PlayerID)
+	}
+	if f.Columns.Currency != "" {
+		row.Currency = value(f.Columns.Currency)
+	}
+	if row.ReqID == "" && row.RoundID == "" {
+		return Row{}, fmt.Errorf("%w: line %d has neither req_id nor round_id", ErrInvalidStatement, line)
+	}
+
+	transactionType, ok := f.transactionType(value(f.Columns.Type))
+	if !ok {
+		return Row{}, fmt.Errorf("%w: line %d has unknown type %q", ErrInvalidStatement, line, value(f.Columns.Type))
+	}
+	row.Type = transactionType
+
+	amount, err := strconv.ParseFloat(value(f.Columns.Amount), 64)
+	if err != nil || math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return Row{}, fmt.Errorf("%w: line %d has invalid amount %q", ErrInvalidStatement, line, value(f.Columns.Amount))
+	}
+	if f.AmountInMinorUnits {
+		amount = amount / 100
+	}
+	row.Amount = math.Round(amount*100) / 100
+	return row, nil
+}
+
+func (f Format) transactionType(value string) (entities.TransactionType, bool) {
+	if transactionType, ok := f.TypeValues[value]; ok {
+		return transactionType, true
+	}
+	switch entities.TransactionType(strings.ToLower(value)) {
+	case entities.TransactionTypeBet:
+		return entities.TransactionTypeBet, true
+	case entities.TransactionTypeResult:
+		return entities.TransactionTypeResult, true
+	}
+	return "", false
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"gorm.io/gorm"
+)
+
+type IAPIKeyRepository interface {
+	Create(ctx context.Context, apiKey *entities.APIKey, outTx *gorm.DB) error
+	GetByID(ctx context.Context, operatorID string, id uint64, outTx *gorm.DB) (*entities.APIKey, error)
+	GetByHash(ctx context.Context, keyHash string, outTx *gorm.DB) (*entities.APIKey, error)
+	GetAll(ctx context.Context, operatorID string, outTx *gorm.DB) ([]*entities.APIKey, error)
+	Revoke(ctx context.Context, operatorID string, id uint64, revokedAt time.Time, outTx *gorm.DB) error
+}
+
+type apiKeyRepository struct {
+	IGormRepository
+}
+
+func NewAPIKeyRepository(repository IGormRepository) IAPIKeyRepository {
+	return &apiKeyRepository{
+		IGormRepository: repository,
+	}
+}
+
+func (r *apiKeyRepository) Create(ctx context.Context, apiKey *entities.APIKey, outTx *gorm.DB) error {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "APIKeyRepository.Create")
+	defer span.End()
+	apiKey.CreatedAt = time.Now()
+	apiKey.UpdatedAt = time.Now()
+	return outTx.Create(apiKey).Error
+}
+
+func (r *apiKeyRepository) GetByID(ctx context.Context, operatorID string, id uint64, outTx *gorm.DB) (*entities.APIKey, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "APIKeyRepository.GetByID")
+	defer span.End()
+	var apiKey entities.APIKey
+	if err := outTx.First(&apiKey, "operator_id = ? AND id = ?", operatorID, id).Error; err != nil {
+		return nil, err
+	}
+	return &apiKey, nil
+}
+
+func (r *apiKeyRepository) GetByHash(ctx context.Context, keyHash string, outTx *gorm.DB) (*entities.APIKey, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "APIKeyRepository.GetByHash")
+	defer span.End()
+	var apiKey entities.APIKey
+	if err := outTx.Where("key_hash = ?", keyHash).First(&apiKey).Error; err != nil {
+		return nil, err
+	}
+	return &apiKey, nil
+}
+
+func (r *apiKeyRepository) GetAll(ctx context.Context, operatorID string, outTx *gorm.DB) ([]*entities.APIKey, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "APIKeyRepository.GetAll")
+	defer span.End()
+	var apiKeys []*entities.APIKey
+	if err := outTx.Where("operator_id = ?", operatorID).Order("id").Find(&apiKeys).Error; err != nil {
+		return nil, err
+	}
+	return apiKeys, nil
+}
+
+func (r *apiKeyRepository) Revoke(ctx context.Context, operatorID string, id uint64, revokedAt time.Time, outTx *gorm.DB) error {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "APIKeyRepository.Revoke")
+	defer span.End()
+	return outTx.Model(&entities.APIKey{}).
+		Where("operator_id = ? AND id = ? AND revoked_at IS NULL", operatorID, id).
+		Updates(map[string]interface{}{
+			"revoked_at": revokedAt,
+			"updated_at": revokedAt,
+		}).
+		Error
+}
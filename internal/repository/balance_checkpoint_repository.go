@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"gorm.io/gorm"
+)
+
+// balanceCheckpointLockKey is the advisory lock that keeps checkpoint runs of all replicas from overlapping
+const balanceCheckpointLockKey = 7_201_000_003
+
+type IBalanceCheckpointRepository interface {
+	// TryLock takes the transaction scoped checkpoint lock and reports false
+	// when another run holds it. It has to run in a transaction.
+	TryLock(ctx context.Context, outTx *gorm.DB) (bool, error)
+	// CreateCheckpoints stores a checkpoint at cutoff for every wallet that has
+	// transactions between its latest checkpoint before cutoff and cutoff, and
+	// returns their number
+	CreateCheckpoints(ctx context.Context, cutoff time.Time, outTx *gorm.DB) (int64, error)
+	// GetBalanceAt replays the transactions stored at or before at on top of
+	// the latest checkpoint before it, or the opening balance when there is none
+	GetBalanceAt(ctx context.Context, operatorID, playerID string, at time.Time, outTx *gorm.DB) (*entities.HistoricalBalance, error)
+}
+
+type balanceCheckpointRepository struct {
+	IGormRepository
+}
+
+func NewBalanceCheckpointRepository(repository IGormRepository) IBalanceCheckpointRepository {
+	return &balanceCheckpointRepository{
+		IGormRepository: repository,
+	}
+}
+
+func (r *balanceCheckpointRepository) TryLock(ctx context.Context, outTx *gorm.DB) (bool, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "BalanceCheckpointRepository.TryLock")
+	defer span.End()
+	var locked bool
+	if err := outTx.Raw("SELECT pg_try_advisory_xact_lock(?)", balanceCheckpointLockKey).Scan(&locked).Error; err != nil {
+		return false, err
+	}
+	return locked, nil
+}
+
+func (r *balanceCheckpointRepository) CreateCheckpoints(ctx context.Context, cutoff time.Time, outTx *gorm.DB) (int64, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "BalanceCheckpointRepository.CreateCheckpoints")
+	defer span.End()
+	result := outTx.Exec(`WITH latest AS (
+			SELECT DISTINCT ON (operator_id, player_id) operator_id, player_id, checkpoint_at, balance
+			FROM balance_checkpoints
+			WHERE checkpoint_at <= @cutoff
+			ORDER BY operator_id, player_id, checkpoint_at DESC
+		)
+		INSERT INTO balance_checkpoints (operator_id, player_id, checkpoint_at, balance, last_transaction_id, created_at)
+		SELECT p.operator_id, p.id, @cutoff,
+			COALESCE(l.balance, p.initial_balance) + SUM(`+signedAmountSQL+`),
+			(ARRAY_AGG(t.id ORDER BY t.created_at DESC, t.id DESC))[1],
+			NOW()
+		FROM players p
+		LEFT JOIN latest l ON l.operator_id = p.operator_id AND l.player_id = p.id
+		JOIN transactions t ON t.operator_id = p.operator_id AND t.player_id = p.id AND t.deleted_at IS NULL
+			AND t.created_at <= @cutoff AND (l.checkpoint_at IS NULL OR t.created_at > l.checkpoint_at)
+		WHERE p.deleted_at IS NULL
+		GROUP BY p.operator_id, p.id, p.initial_balance, l.balance
+		ON CONFLICT DO NOTHING`, map[string]interface{}{"cutoff": cutoff})
+	return result.RowsAffected, result.Error
+}
+
+func (r *balanceCheckpointRepository) GetBalanceAt(ctx context.Context, operatorID, playerID string, at time.Time, outTx *gorm.DB) (*entities.HistoricalBalance, error) {
+	if outTx == nil {
+		outTx = r.GetPlayerReadDB(ctx, operatorID, playerID)
+	}
+	outTx, span := startSpan(ctx, outTx, "BalanceCheckpointRepository.GetBalanceAt")
+	defer span.End()
+
+	// One statement, so the balance and the last transaction come from the same snapshot
+	var balances []*entities.HistoricalBalance
+	if err := outTx.Raw(`WITH checkpoint AS (
+			SELECT checkpoint_at, balance
+			FROM balance_checkpoints
+			WHERE operator_id = @operator_id AND player_id = @player_id AND checkpoint_at <= @at
+			ORDER BY checkpoint_at DESC
+			LIMIT 1
+		)
+		SELECT p.id AS player_id, p.wallet_id, p.currency, c.checkpoint_at,
+			COALESCE(c.balance, p.initial_balance) + COALESCE((
+				SELECT SUM(`+signedAmountSQL+`)
+				FROM transactions t
+				WHERE t.operator_id = p.operator_id AND t.player_id = p.id AND t.deleted_at IS NULL
+					AND t.created_at <= @at AND (c.checkpoint_at IS NULL OR t.created_at > c.checkpoint_at)
+			), 0) AS balance,
+			(
+				SELECT t.id
+				FROM transactions t
+				WHERE t.operator_id = p.operator_id AND t.player_id = p.id AND t.deleted_at IS NULL AND t.created_at <= @at
+				ORDER BY t.created_at DESC, t.id DESC
+				LIMIT 1
+			) AS last_transaction_id
+		FROM players p
+		LEFT JOIN checkpoint c ON TRUE
+		WHERE p.operator_id = @operator_id AND p.id = @player_id AND p.deleted_at IS NULL`,
+		map[string]interface{}{"operator_id": operatorID, "player_id": playerID, "at": at}).
+		Scan(&balances).Error; err != nil {
+		return nil, err
+	}
+	if len(balances) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	balance := balances[0]
+	balance.At = at
+
+	if balance.LastTransactionID != nil {
+		var transaction entities.Transaction
+		if err := outTx.Where("operator_id = ? AND id = ?", operatorID, *balance.LastTransactionID).First(&transaction).Error; err != nil {
+			return nil, err
+		}
+		balance.LastTransaction = &transaction
+	}
+	return balance, nil
+}
@@ -1,64 +1,172 @@
 package repository
 
-import "gorm.io/gorm"
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/metrics"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/tracing"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const (
+	txOutcomeCommit      = "commit"
+	txOutcomeCommitError = "commit_error"
+	txOutcomeRollback    = "rollback"
+)
 
 type IGormRepository interface {
-	StartTransaction() (*gorm.DB, error)
-	StartTransactionWithIsolation(isolationLevel string) (*gorm.DB, error)
+	StartTransaction(ctx context.Context) (*gorm.DB, error)
+	StartTransactionWithIsolation(ctx context.Context, isolationLevel string) (*gorm.DB, error)
 	FinishTransaction(tx *gorm.DB, err error) error
 	RollbackTransaction(tx *gorm.DB)
-	GetDB() *gorm.DB
+	GetDB(ctx context.Context) *gorm.DB
+	// GetReadDB returns a session for reads outside of a transaction, on a
+	// replica within the lag limit when there is one
+	GetReadDB(ctx context.Context) *gorm.DB
+	// GetPlayerReadDB is GetReadDB for reads of one player, it stays on the
+	// primary while the player was written recently
+	GetPlayerReadDB(ctx context.Context, operatorID, playerID string) *gorm.DB
+	// RecordPlayerWrite starts the read-your-writes window of the player
+	RecordPlayerWrite(operatorID, playerID string)
 	CommitTransaction(tx *gorm.DB) error
 }
 
 type gormRepository struct {
 	db *gorm.DB
+	// replicas is nil when no replicas are configured
+	replicas *ReplicaSet
+	// writes collects the players written in a unit of work, they are recorded once it finished
+	writes *[]playerWrite
+	// startedAt holds the begin time of open transactions for the duration metrics.
+	// It is keyed by the underlying *sql.Tx so sessions derived from the
+	// transaction with WithContext still resolve to the same entry.
+	startedAt sync.Map
 }
 
 func NewGormRepository(db *gorm.DB) IGormRepository {
 	return &gormRepository{db: db}
 }
 
-func (r *gormRepository) StartTransaction() (*gorm.DB, error) {
-	tx := r.db.Begin()
+// NewGormRepositoryWithReplicas sends the reads outside of transactions to the replicas
+func NewGormRepositoryWithReplicas(db *gorm.DB, replicas *ReplicaSet) IGormRepository {
+	return &gormRepository{db: db, replicas: replicas}
+}
+
+// StartTransaction begins a transaction bound to ctx. When ctx is cancelled
+// database/sql rolls the transaction back on its own, so an abandoned request
+// never leaves locks behind.
+func (r *gormRepository) StartTransaction(ctx context.Context) (*gorm.DB, error) {
+	tx := r.db.WithContext(ctx).Begin()
 	if tx.Error != nil {
 		return nil, tx.Error
 	}
+	r.startedAt.Store(tx.Statement.ConnPool, time.Now())
 	return tx, nil
 }
 
-func (r *gormRepository) StartTransactionWithIsolation(isolationLevel string) (*gorm.DB, error) {
-	tx := r.db.Begin()
+func (r *gormRepository) StartTransactionWithIsolation(ctx context.Context, isolationLevel string) (*gorm.DB, error) {
+	tx := r.db.WithContext(ctx).Begin()
 	if tx.Error != nil {
 		return nil, tx.Error
 	}
+	r.startedAt.Store(tx.Statement.ConnPool, time.Now())
+
+	// SQLite has no isolation levels to choose from, its transactions are serializable
+	if isSQLite(tx) {
+		return tx, nil
+	}
 
 	// Set isolation level
 	if err := tx.Exec("SET TRANSACTION ISOLATION LEVEL " + isolationLevel).Error; err != nil {
-		tx.Rollback()
+		r.RollbackTransaction(tx)
 		return nil, err
 	}
 
 	return tx, nil
 }
 
+// FinishTransaction rolls tx back when err is set and returns err, otherwise it
+// commits tx and returns the commit error. A failed commit means nothing was
+// written, so callers must not report the work as done.
 func (r *gormRepository) FinishTransaction(tx *gorm.DB, err error) error {
 	if err != nil {
-		tx.Rollback()
-	} else {
-		tx.Commit()
+		r.RollbackTransaction(tx)
+		return err
 	}
-	return nil
+	return r.CommitTransaction(tx)
 }
 
 func (r *gormRepository) RollbackTransaction(tx *gorm.DB) {
 	tx.Rollback()
+	r.observe(tx, txOutcomeRollback)
 }
 
 func (r *gormRepository) CommitTransaction(tx *gorm.DB) error {
-	return tx.Commit().Error
+	err := tx.Commit().Error
+	r.observe(tx, commitOutcome(err))
+	return err
+}
+
+// GetDB returns a session bound to ctx for queries outside of a transaction
+func (r *gormRepository) GetDB(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx)
+}
+
+func (r *gormRepository) GetReadDB(ctx context.Context) *gorm.DB {
+	if r.replicas == nil {
+		return r.GetDB(ctx)
+	}
+	if db := r.replicas.pick(); db != nil {
+		metrics.DBReads.WithLabelValues("replica").Inc()
+		return db.WithContext(ctx)
+	}
+	metrics.DBReads.WithLabelValues("primary").Inc()
+	return r.GetDB(ctx)
+}
+
+func (r *gormRepository) GetPlayerReadDB(ctx context.Context, operatorID, playerID string) *gorm.DB {
+	if r.replicas != nil && r.replicas.recentlyWritten(operatorID, playerID) {
+		metrics.DBReads.WithLabelValues("primary").Inc()
+		return r.GetDB(ctx)
+	}
+	return r.GetReadDB(ctx)
+}
+
+func (r *gormRepository) RecordPlayerWrite(operatorID, playerID string) {
+	if r.writes != nil {
+		*r.writes = append(*r.writes, playerWrite{operatorID: operatorID, playerID: playerID})
+		return
+	}
+	if r.replicas != nil {
+		r.replicas.RecordWrite(operatorID, playerID)
+	}
+}
+
+func (r *gormRepository) observe(tx *gorm.DB, outcome string) {
+	metrics.DBTransactions.WithLabelValues(outcome).Inc()
+	if startedAt, ok := r.startedAt.LoadAndDelete(tx.Statement.ConnPool); ok {
+		metrics.DBTransactionDuration.WithLabelValues(outcome).Observe(time.Since(startedAt.(time.Time)).Seconds())
+	}
+}
+
+func commitOutcome(err error) string {
+	if err != nil {
+		return txOutcomeCommitError
+	}
+	return txOutcomeCommit
+}
+
+// observeLockWait records how long a locking read took, call it deferred with the start time
+func observeLockWait(query string, start time.Time) {
+	metrics.DBLockWait.WithLabelValues(query).Observe(time.Since(start).Seconds())
 }
 
-func (r *gormRepository) GetDB() *gorm.DB {
-	return r.db
+// startSpan starts a repository span as a child of ctx and returns a session
+// bound to the span context, so queries are both traced and cancelled with it
+func startSpan(ctx context.Context, outTx *gorm.DB, name string) (*gorm.DB, trace.Span) {
+	ctx, span := tracing.Tracer().Start(ctx, name)
+	return outTx.WithContext(ctx), span
 }
@@ -0,0 +1,56 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/repository"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// insertOrphanPlayer writes a player of a missing operator with the foreign
+// key check deferred to the commit, which then fails
+func insertOrphanPlayer(tx *gorm.DB) error {
+	if err := tx.Exec("PRAGMA defer_foreign_keys = ON").Error; err != nil {
+		return err
+	}
+	return tx.Exec(`INSERT INTO players (operator_id, id, wallet_id, balance, currency) VALUES (?, ?, ?, ?, ?)`,
+		"missing-operator", "player-1", "wallet-1", 10, "EUR").Error
+}
+
+func countPlayers(t *testing.T, db *gorm.DB) int64 {
+	t.Helper()
+	var count int64
+	require.NoError(t, db.Raw("SELECT COUNT(*) FROM players WHERE operator_id = ?", "missing-operator").Scan(&count).Error)
+	return count
+}
+
+func TestFinishTransactionReturnsCommitError(t *testing.T) {
+	db := testutil.OpenSQLite(t)
+	gormRepository := repository.NewGormRepository(db)
+
+	tx, err := gormRepository.StartTransaction(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, insertOrphanPlayer(tx))
+
+	err = gormRepository.FinishTransaction(tx, nil)
+	assert.ErrorContains(t, err, "FOREIGN KEY constraint failed")
+	assert.Zero(t, countPlayers(t, db))
+}
+
+func TestTransactionRunnerReturnsCommitError(t *testing.T) {
+	db := testutil.OpenSQLite(t)
+
+	attempts := 0
+	err := newTestRunner(db, 3).Run(context.Background(), func(tx *gorm.DB) error {
+		attempts++
+		return insertOrphanPlayer(tx)
+	})
+	assert.ErrorContains(t, err, "FOREIGN KEY constraint failed")
+	// A constraint violation is no reason to run the transaction again
+	assert.Equal(t, 1, attempts)
+	assert.Zero(t, countPlayers(t, db))
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"gorm.io/gorm"
+)
+
+type memoryAPIKeyRepository struct {
+	*memoryGormRepository
+}
+
+func NewMemoryAPIKeyRepository(store *MemoryStore) IAPIKeyRepository {
+	return &memoryAPIKeyRepository{&memoryGormRepository{store: store}}
+}
+
+func (r *memoryAPIKeyRepository) Create(ctx context.Context, apiKey *entities.APIKey, outTx *gorm.DB) error {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	ctx, span := startMemorySpan(ctx, "APIKeyRepository.Create")
+	defer span.End()
+	apiKey.CreatedAt = time.Now()
+	apiKey.UpdatedAt = time.Now()
+	return r.store.run(ctx, outTx, func(tx *memoryTx) error {
+		if err := r.store.reserveUnique(ctx, tx, "api_keys_hash:"+apiKey.KeyHash); err != nil {
+			return err
+		}
+		if apiKey.ID == 0 {
+			apiKey.ID = r.store.nextID("api_keys")
+		}
+		row := *apiKey
+		tx.apiKeys[row.ID] = &row
+		return nil
+	})
+}
+
+func (r *memoryAPIKeyRepository) GetByID(ctx context.Context, operatorID string, id uint64, outTx *gorm.DB) (*entities.APIKey, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	ctx, span := startMemorySpan(ctx, "APIKeyRepository.GetByID")
+	defer span.End()
+	var apiKey *entities.APIKey
+	err := r.store.run(ctx, outTx, func(tx *memoryTx) error {
+		row, ok := getMemoryRow(r.store.apiKeys, tx.apiKeys, id)
+		if !ok || row.OperatorID != operatorID {
+			return gorm.ErrRecordNotFound
+		}
+		apiKey = &row
+		return nil
+	})
+	return apiKey, err
+}
+
+func (r *memoryAPIKeyRepository) GetByHash(ctx context.Context, keyHash string, outTx *gorm.DB) (*entities.APIKey, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	ctx, span := startMemorySpan(ctx, "APIKeyRepository.GetByHash")
+	defer span.End()
+	var apiKey *entities.APIKey
+	err := r.store.run(ctx, outTx, func(tx *memoryTx) error {
+		rows := scanMemoryRows(r.store.apiKeys, tx.apiKeys, func(row entities.APIKey) bool {
+			return row.KeyHash == keyHash
+		})
+		if len(rows) == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		apiKey = &rows[0]
+		return nil
+	})
+	return apiKey, err
+}
+
+func (r *memoryAPIKeyRepository) GetAll(ctx context.Context, operatorID string, outTx *gorm.DB) ([]*entities.APIKey, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	ctx, span := startMemorySpan(ctx, "APIKeyRepository.GetAll")
+	defer span.End()
+	var apiKeys []*entities.APIKey
+	err := r.store.run(ctx, outTx, func(tx *memoryTx) error {
+		rows := scanMemoryRows(r.store.apiKeys, tx.apiKeys, func(row entities.APIKey) bool {
+			return row.OperatorID == operatorID
+		})
+		slices.SortFunc(rows, func(a, b entities.APIKey) int {
+			return cmp.Compare(a.ID, b.ID)
+		})
+		for i := range rows {
+			apiKeys = append(apiKeys, &rows[i])
+		}
+		return nil
+	})
+	return apiKeys, err
+}
+
+func (r *memoryAPIKeyRepository) Revoke(ctx context.Context, operatorID string, id uint64, revokedAt time.Time, outTx *gorm.DB) error {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	ctx, span := startMemorySpan(ctx, "APIKeyRepository.Revoke")
+	defer span.End()
+	return r.store.run(ctx, outTx, func(tx *memoryTx) error {
+		row, err := lockMemoryRow(ctx, r.store, tx, apiKeyRowKey(id), r.store.apiKeys, tx.apiKeys, id)
+		if errors.Is(err, gorm.ErrRecordNotFound) || row.OperatorID != operatorID || row.RevokedAt != nil {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		row.RevokedAt = &revokedAt
+		row.UpdatedAt = revokedAt
+		tx.apiKeys[id] = &row
+		return nil
+	})
+}
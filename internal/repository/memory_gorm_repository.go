@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/metrics"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/tracing"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// memoryGormRepository hands out transactions of a MemoryStore. Its sessions
+// are opaque handles, a nil session runs every statement in a transaction of
+// its own like autocommit does.
+type memoryGormRepository struct {
+	store *MemoryStore
+	// tx is the handle the repositories of a unit of work are bound to
+	tx *gorm.DB
+}
+
+func NewMemoryGormRepository(store *MemoryStore) IGormRepository {
+	return &memoryGormRepository{store: store}
+}
+
+func (r *memoryGormRepository) StartTransaction(ctx context.Context) (*gorm.DB, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return r.store.begin(ctx), nil
+}
+
+// StartTransactionWithIsolation ignores the level, transactions of the store
+// read committed rows and lock what they read for update, which is the
+// behaviour the services rely on
+func (r *memoryGormRepository) StartTransactionWithIsolation(ctx context.Context, isolationLevel string) (*gorm.DB, error) {
+	return r.StartTransaction(ctx)
+}
+
+func (r *memoryGormRepository) FinishTransaction(tx *gorm.DB, err error) error {
+	if err != nil {
+		r.RollbackTransaction(tx)
+		return err
+	}
+	return r.CommitTransaction(tx)
+}
+
+func (r *memoryGormRepository) RollbackTransaction(tx *gorm.DB) {
+	if startedAt, ok := r.store.rollback(tx); ok {
+		observeMemoryTransaction(txOutcomeRollback, startedAt)
+	}
+}
+
+func (r *memoryGormRepository) CommitTransaction(tx *gorm.DB) error {
+	startedAt, err := r.store.commit(tx)
+	if startedAt.IsZero() {
+		return err
+	}
+	observeMemoryTransaction(commitOutcome(err), startedAt)
+	return err
+}
+
+func (r *memoryGormRepository) GetDB(ctx context.Context) *gorm.DB {
+	return r.tx
+}
+
+func (r *memoryGormRepository) GetReadDB(ctx context.Context) *gorm.DB {
+	return r.GetDB(ctx)
+}
+
+func (r *memoryGormRepository) GetPlayerReadDB(ctx context.Context, operatorID, playerID string) *gorm.DB {
+	return r.GetDB(ctx)
+}
+
+// RecordPlayerWrite does nothing, the store has no replicas
+func (r *memoryGormRepository) RecordPlayerWrite(operatorID, playerID string) {}
+
+func observeMemoryTransaction(outcome string, startedAt time.Time) {
+	metrics.DBTransactions.WithLabelValues(outcome).Inc()
+	metrics.DBTransactionDuration.WithLabelValues(outcome).Observe(time.Since(startedAt).Seconds())
+}
+
+// startMemorySpan starts a span named like the one of the Postgres repository,
+// so traces read the same on both backends
+func startMemorySpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	return tracing.Tracer().Start(ctx, name)
+}
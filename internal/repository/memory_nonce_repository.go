@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// memoryNonceRepository keeps the nonces in the process, the in-memory backend
+// runs as a single replica
+type memoryNonceRepository struct {
+	mu      sync.Mutex
+	entries map[string]time.Time
+}
+
+func NewMemoryNonceRepository() INonceRepository {
+	return &memoryNonceRepository{
+		entries: make(map[string]time.Time),
+	}
+}
+
+func (r *memoryNonceRepository) Add(ctx context.Context, providerID, nonce string, now, expiresAt time.Time, outTx *gorm.DB) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := providerID + "\n" + nonce
+	if existing, ok := r.entries[key]; ok && now.Before(existing) {
+		return false, nil
+	}
+	r.entries[key] = expiresAt
+	return true, nil
+}
+
+func (r *memoryNonceRepository) DeleteExpired(ctx context.Context, now time.Time, outTx *gorm.DB) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var deleted int64
+	for key, expiresAt := range r.entries {
+		if !now.Before(expiresAt) {
+			delete(r.entries, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// memoryNotificationRepository drops notifications, nothing listens for them
+// without Postgres
+type memoryNotificationRepository struct{}
+
+func NewMemoryNotificationRepository() INotificationRepository {
+	return memoryNotificationRepository{}
+}
+
+func (memoryNotificationRepository) Notify(ctx context.Context, channel string, payload []byte, outTx *gorm.DB) error {
+	return nil
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"gorm.io/gorm"
+)
+
+type memoryOperatorRepository struct {
+	*memoryGormRepository
+}
+
+func NewMemoryOperatorRepository(store *MemoryStore) IOperatorRepository {
+	return &memoryOperatorRepository{&memoryGormRepository{store: store}}
+}
+
+func (r *memoryOperatorRepository) GetByID(ctx context.Context, id string, outTx *gorm.DB) (*entities.Operator, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	ctx, span := startMemorySpan(ctx, "OperatorRepository.GetByID")
+	defer span.End()
+	var operator *entities.Operator
+	err := r.store.run(ctx, outTx, func(tx *memoryTx) error {
+		row, ok := getMemoryRow(r.store.operators, tx.operators, id)
+		if !ok {
+			return gorm.ErrRecordNotFound
+		}
+		operator = cloneOperator(row)
+		return nil
+	})
+	return operator, err
+}
+
+func (r *memoryOperatorRepository) Create(ctx context.Context, operator *entities.Operator, outTx *gorm.DB) error {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	ctx, span := startMemorySpan(ctx, "OperatorRepository.Create")
+	defer span.End()
+	operator.CreatedAt = time.Now()
+	operator.UpdatedAt = time.Now()
+	return r.store.run(ctx, outTx, func(tx *memoryTx) error {
+		if err := r.store.reserveUnique(ctx, tx, operatorPrimaryKey(operator.ID)); err != nil {
+			return err
+		}
+		tx.operators[operator.ID] = cloneOperator(*operator)
+		return nil
+	})
+}
+
+func (r *memoryOperatorRepository) Update(ctx context.Context, operator *entities.Operator, outTx *gorm.DB) error {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	ctx, span := startMemorySpan(ctx, "OperatorRepository.Update")
+	defer span.End()
+	operator.UpdatedAt = time.Now()
+	return r.store.run(ctx, outTx, func(tx *memoryTx) error {
+		row, err := lockMemoryRow(ctx, r.store, tx, operatorPrimaryKey(operator.ID), r.store.operators, tx.operators, operator.ID)
+		if err != nil {
+			// Updating a missing row is not an error, it updates nothing
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+		updated := cloneOperator(*operator)
+		row.Name, row.Currencies, row.MinBet, row.MaxBet = updated.Name, updated.Currencies, updated.MinBet, updated.MaxBet
+		row.SpendOrder, row.UpdatedAt = updated.SpendOrder, updated.UpdatedAt
+		tx.operators[operator.ID] = &row
+		return nil
+	})
+}
@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"gorm.io/gorm"
+)
+
+type memoryOutboxRepository struct {
+	*memoryGormRepository
+}
+
+func NewMemoryOutboxRepository(store *MemoryStore) IOutboxRepository {
+	return &memoryOutboxRepository{&memoryGormRepository{store: store}}
+}
+
+func (r *memoryOutboxRepository) Create(ctx context.Context, event *entities.OutboxEvent, outTx *gorm.DB) error {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	ctx, span := startMemorySpan(ctx, "OutboxRepository.Create")
+	defer span.End()
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+	if event.NextAttemptAt.IsZero() {
+		event.NextAttemptAt = event.CreatedAt
+	}
+	return r.store.run(ctx, outTx, func(tx *memoryTx) error {
+		if event.ID == 0 {
+			event.ID = r.store.nextID("outbox_events")
+		}
+		row := *event
+		row.Payload = slices.Clone(event.Payload)
+		tx.outbox[row.ID] = &row
+		return nil
+	})
+}
+
+func (r *memoryOutboxRepository) GetPublishableWithLock(ctx context.Context, now time.Time, limit int, outTx *gorm.DB) ([]*entities.OutboxEvent, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	ctx, span := startMemorySpan(ctx, "OutboxRepository.GetPublishableWithLock")
+	defer span.End()
+	defer observeLockWait("outbox_publishable", time.Now())
+	var events []*entities.OutboxEvent
+	err := r.store.run(ctx, outTx, func(tx *memoryTx) error {
+		rows := scanMemoryRows(r.store.outbox, tx.outbox, func(entities.OutboxEvent) bool { return true })
+		slices.SortFunc(rows, func(a, b entities.OutboxEvent) int {
+			return cmp.Compare(a.ID, b.ID)
+		})
+		// Only the oldest event of a key is handed out, which keeps the per key
+		// order even when a publish fails and is retried later
+		oldest := make(map[[2]string]bool)
+		for i := range rows {
+			key := [2]string{rows[i].OperatorID, rows[i].AggregateKey}
+			if oldest[key] {
+				continue
+			}
+			oldest[key] = true
+			if len(events) == limit {
+				break
+			}
+			if rows[i].NextAttemptAt.After(now) || !r.store.tryLockRow(tx, outboxRowKey(rows[i].ID)) {
+				continue
+			}
+			events = append(events, &rows[i])
+		}
+		return nil
+	})
+	return events, err
+}
+
+func (r *memoryOutboxRepository) Delete(ctx context.Context, id uint64, outTx *gorm.DB) error {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	ctx, span := startMemorySpan(ctx, "OutboxRepository.Delete")
+	defer span.End()
+	return r.store.run(ctx, outTx, func(tx *memoryTx) error {
+		return r.delete(ctx, tx, id)
+	})
+}
+
+func (r *memoryOutboxRepository) MarkFailed(ctx context.Context, id uint64, attempts int, lastError string, nextAttemptAt time.Time, outTx *gorm.DB) error {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	ctx, span := startMemorySpan(ctx, "OutboxRepository.MarkFailed")
+	defer span.End()
+	return r.store.run(ctx, outTx, func(tx *memoryTx) error {
+		row, err := lockMemoryRow(ctx, r.store, tx, outboxRowKey(id), r.store.outbox, tx.outbox, id)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		row.Attempts = attempts
+		row.LastError = &lastError
+		row.NextAttemptAt = nextAttemptAt
+		tx.outbox[id] = &row
+		return nil
+	})
+}
+
+func (r *memoryOutboxRepository) MoveToDeadLetter(ctx context.Context, event *entities.OutboxEvent, lastError string, outTx *gorm.DB) error {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	ctx, span := startMemorySpan(ctx, "OutboxRepository.MoveToDeadLetter")
+	defer span.End()
+	return r.store.run(ctx, outTx, func(tx *memoryTx) error {
+		deadLetter := &entities.OutboxDeadLetter{
+			ID:             r.store.nextID("outbox_dead_letters"),
+			OutboxEventID:  event.ID,
+			OperatorID:     event.OperatorID,
+			AggregateKey:   event.AggregateKey,
+			EventType:      event.EventType,
+			Payload:        slices.Clone(event.Payload),
+			Attempts:       event.Attempts,
+			LastError:      &lastError,
+			EventCreatedAt: event.CreatedAt,
+			CreatedAt:      time.Now(),
+		}
+		tx.deadLetters[deadLetter.ID] = deadLetter
+		return r.delete(ctx, tx, event.ID)
+	})
+}
+
+func (r *memoryOutboxRepository) CountPending(ctx context.Context, outTx *gorm.DB) (int64, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	ctx, span := startMemorySpan(ctx, "OutboxRepository.CountPending")
+	defer span.End()
+	var count int64
+	err := r.store.run(ctx, outTx, func(tx *memoryTx) error {
+		count = int64(len(scanMemoryRows(r.store.outbox, tx.outbox, func(entities.OutboxEvent) bool { return true })))
+		return nil
+	})
+	return count, err
+}
+
+func (r *memoryOutboxRepository) delete(ctx context.Context, tx *memoryTx, id uint64) error {
+	_, err := lockMemoryRow(ctx, r.store, tx, outboxRowKey(id), r.store.outbox, tx.outbox, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	tx.outbox[id] = nil
+	return nil
+}
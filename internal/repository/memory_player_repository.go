@@ -0,0 +1,220 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"gorm.io/gorm"
+)
+
+type memoryPlayerRepository struct {
+	*memoryGormRepository
+}
+
+func NewMemoryPlayerRepository(store *MemoryStore) IPlayerRepository {
+	return &memoryPlayerRepository{&memoryGormRepository{store: store}}
+}
+
+func (r *memoryPlayerRepository) GetByID(ctx context.Context, operatorID, id string, outTx *gorm.DB) (*entities.Player, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	ctx, span := startMemorySpan(ctx, "PlayerRepository.GetByID")
+	defer span.End()
+	var player *entities.Player
+	err := r.store.run(ctx, outTx, func(tx *memoryTx) error {
+		row, ok := getMemoryRow(r.store.players, tx.players, playerKey{operatorID, id})
+		if !ok {
+			return gorm.ErrRecordNotFound
+		}
+		player = &row
+		return nil
+	})
+	return player, err
+}
+
+func (r *memoryPlayerRepository) GetByIDWithLock(ctx context.Context, operatorID, id string, outTx *gorm.DB) (*entities.Player, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	ctx, span := startMemorySpan(ctx, "PlayerRepository.GetByIDWithLock")
+	defer span.End()
+	defer observeLockWait("player_by_id", time.Now())
+	var player *entities.Player
+	err := r.store.run(ctx, outTx, func(tx *memoryTx) error {
+		row, err := lockMemoryRow(ctx, r.store, tx, playerRowKey(operatorID, id), r.store.players, tx.players, playerKey{operatorID, id})
+		if err != nil {
+			return err
+		}
+		player = &row
+		return nil
+	})
+	return player, err
+}
+
+func (r *memoryPlayerRepository) List(ctx context.Context, filter *entities.PlayerListFilter, order entities.PlayerOrder, after *entities.PlayerCursor, limit int, outTx *gorm.DB) ([]*entities.Player, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	ctx, span := startMemorySpan(ctx, "PlayerRepository.List")
+	defer span.End()
+	var players []*entities.Player
+	err := r.store.run(ctx, outTx, func(tx *memoryTx) error {
+		rows := scanMemoryRows(r.store.players, tx.players, func(player entities.Player) bool {
+			if !matchesPlayerFilter(&player, filter) {
+				return false
+			}
+			if after == nil {
+				return true
+			}
+			cmp := comparePlayers(&player, order.Field, after.CreatedAt, after.Balance, after.ID)
+			if order.Descending {
+				return cmp < 0
+			}
+			return cmp > 0
+		})
+		sort.Slice(rows, func(i, j int) bool {
+			cmp := comparePlayers(&rows[i], order.Field, rows[j].CreatedAt, rows[j].Balance, rows[j].ID)
+			if order.Descending {
+				return cmp > 0
+			}
+			return cmp < 0
+		})
+		for i := range rows[:min(limit, len(rows))] {
+			players = append(players, &rows[i])
+		}
+		return nil
+	})
+	return players, err
+}
+
+func (r *memoryPlayerRepository) Count(ctx context.Context, filter *entities.PlayerListFilter, outTx *gorm.DB) (int64, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	ctx, span := startMemorySpan(ctx, "PlayerRepository.Count")
+	defer span.End()
+	var count int64
+	err := r.store.run(ctx, outTx, func(tx *memoryTx) error {
+		count = int64(len(scanMemoryRows(r.store.players, tx.players, func(player entities.Player) bool {
+			return matchesPlayerFilter(&player, filter)
+		})))
+		return nil
+	})
+	return count, err
+}
+
+func matchesPlayerFilter(player *entities.Player, filter *entities.PlayerListFilter) bool {
+	switch {
+	case player.OperatorID != filter.OperatorID:
+		return false
+	case filter.Currency != "" && player.Currency != filter.Currency:
+		return false
+	case filter.Status != "" && player.Status != filter.Status:
+		return false
+	case filter.MinBalance != nil && player.Balance < *filter.MinBalance:
+		return false
+	case filter.MaxBalance != nil && player.Balance > *filter.MaxBalance:
+		return false
+	case filter.CreatedFrom != nil && player.CreatedAt.Before(*filter.CreatedFrom):
+		return false
+	case filter.CreatedTo != nil && !player.CreatedAt.Before(*filter.CreatedTo):
+		return false
+	}
+	return true
+}
+
+// comparePlayers orders the player against a position by the sort field and then by id
+func comparePlayers(player *entities.Player, field entities.PlayerSortField, createdAt time.Time, balance float64, id string) int {
+	switch field {
+	case entities.PlayerSortCreatedAt:
+		if cmp := player.CreatedAt.Compare(createdAt); cmp != 0 {
+			return cmp
+		}
+	case entities.PlayerSortBalance:
+		if player.Balance != balance {
+			if player.Balance < balance {
+				return -1
+			}
+			return 1
+		}
+	}
+	return strings.Compare(player.ID, id)
+}
+
+func (r *memoryPlayerRepository) UpdateBalance(ctx context.Context, operatorID, id string, amount float64, outTx *gorm.DB) error {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	ctx, span := startMemorySpan(ctx, "PlayerRepository.UpdateBalance")
+	defer span.End()
+	return r.store.run(ctx, outTx, func(tx *memoryTx) error {
+		player, err := lockMemoryRow(ctx, r.store, tx, playerRowKey(operatorID, id), r.store.players, tx.players, playerKey{operatorID, id})
+		if err != nil {
+			// Updating a missing row is not an error, it updates nothing
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+		player.Balance = roundDecimal(player.Balance + amount)
+		player.Version++
+		tx.players[playerKey{operatorID, id}] = &player
+		return nil
+	})
+}
+
+func (r *memoryPlayerRepository) UpdateBalanceIfVersion(ctx context.Context, operatorID, id string, version int64, amount float64, outTx *gorm.DB) error {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	ctx, span := startMemorySpan(ctx, "PlayerRepository.UpdateBalanceIfVersion")
+	defer span.End()
+	return r.store.run(ctx, outTx, func(tx *memoryTx) error {
+		player, err := lockMemoryRow(ctx, r.store, tx, playerRowKey(operatorID, id), r.store.players, tx.players, playerKey{operatorID, id})
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrVersionConflict
+		}
+		if err != nil {
+			return err
+		}
+		if player.Version != version || (amount < 0 && player.Balance+amount < 0) {
+			return ErrVersionConflict
+		}
+		player.Balance = roundDecimal(player.Balance + amount)
+		player.Version++
+		tx.players[playerKey{operatorID, id}] = &player
+		return nil
+	})
+}
+
+func (r *memoryPlayerRepository) Create(ctx context.Context, player *entities.Player, outTx *gorm.DB) error {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	ctx, span := startMemorySpan(ctx, "PlayerRepository.Create")
+	defer span.End()
+	player.CreatedAt = time.Now()
+	player.UpdatedAt = time.Now()
+	player.InitialBalance = player.Balance
+	if player.Status == "" {
+		player.Status = entities.PlayerStatusActive
+	}
+	return r.store.run(ctx, outTx, func(tx *memoryTx) error {
+		if err := r.store.reserveUnique(ctx, tx, playerRowKey(player.OperatorID, player.ID)); err != nil {
+			return err
+		}
+		if err := r.store.reserveUnique(ctx, tx, "players_wallet:"+player.OperatorID+":"+player.WalletID); err != nil {
+			return err
+		}
+		row := *player
+		row.Balance = roundDecimal(row.Balance)
+		row.InitialBalance = roundDecimal(row.InitialBalance)
+		tx.players[playerKey{player.OperatorID, player.ID}] = &row
+		return nil
+	})
+}
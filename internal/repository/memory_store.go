@@ -0,0 +1,380 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrMemoryUnsupported = errors.New("not supported by the in-memory storage backend")
+	ErrMemoryDeadlock    = errors.New("deadlock detected between in-memory transactions")
+	errMemoryTxDone      = errors.New("transaction has already been committed or rolled back")
+)
+
+type playerKey struct {
+	operatorID string
+	id         string
+}
+
+// MemoryStore holds the tables of the in-memory repositories. The writes of a
+// transaction are buffered and applied when it commits, so other transactions
+// never see uncommitted rows. Rows read for update and values of unique columns
+// stay with the transaction until it ends, a second transaction waits for them
+// the way it would wait on Postgres.
+type MemoryStore struct {
+	mu sync.Mutex
+	// released is closed and replaced whenever a transaction ends, waiters check their row again then
+	released chan struct{}
+	// txs maps the handles given out by StartTransaction to their transaction
+	txs map[*gorm.DB]*memoryTx
+
+	players      map[playerKey]entities.Player
+	transactions map[uint64]entities.Transaction
+	operators    map[string]entities.Operator
+	apiKeys      map[uint64]entities.APIKey
+	outbox       map[uint64]entities.OutboxEvent
+	deadLetters  map[uint64]entities.OutboxDeadLetter
+
+	// locks holds the rows locked for update by the transaction holding them
+	locks map[string]*memoryTx
+	// uniqueKeys holds the values of unique columns, a nil owner means committed
+	uniqueKeys map[string]*memoryTx
+	// sequences hand out IDs, like Postgres identities they are not rolled back
+	sequences map[string]uint64
+}
+
+// memoryTx buffers the writes of one transaction, a nil row marks a delete
+type memoryTx struct {
+	ctx       context.Context
+	startedAt time.Time
+
+	players      map[playerKey]*entities.Player
+	transactions map[uint64]*entities.Transaction
+	operators    map[string]*entities.Operator
+	apiKeys      map[uint64]*entities.APIKey
+	outbox       map[uint64]*entities.OutboxEvent
+	deadLetters  map[uint64]*entities.OutboxDeadLetter
+
+	locks      []string
+	uniqueKeys []string
+	// waitingFor is the transaction this one waits on, it is followed to detect deadlocks
+	waitingFor *memoryTx
+}
+
+// NewMemoryStore returns an empty store holding only the default operator, the
+// same row the migrations create
+func NewMemoryStore() *MemoryStore {
+	store := &MemoryStore{
+		released:     make(chan struct{}),
+		txs:          make(map[*gorm.DB]*memoryTx),
+		players:      make(map[playerKey]entities.Player),
+		transactions: make(map[uint64]entities.Transaction),
+		operators:    make(map[string]entities.Operator),
+		apiKeys:      make(map[uint64]entities.APIKey),
+		outbox:       make(map[uint64]entities.OutboxEvent),
+		deadLetters:  make(map[uint64]entities.OutboxDeadLetter),
+		locks:        make(map[string]*memoryTx),
+		uniqueKeys:   make(map[string]*memoryTx),
+		sequences:    make(map[string]uint64),
+	}
+	now := time.Now()
+	store.operators[entities.DefaultOperatorID] = entities.Operator{
+		ID:         entities.DefaultOperatorID,
+		Name:       "Default operator",
+		Currencies: []string{"INR"},
+		SpendOrder: []entities.BalanceBucket{entities.BalanceBucketCash},
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	store.uniqueKeys[operatorPrimaryKey(entities.DefaultOperatorID)] = nil
+	return store
+}
+
+// PingContext reports the store as reachable, it lets the health check treat it like a database
+func (s *MemoryStore) PingContext(ctx context.Context) error {
+	return ctx.Err()
+}
+
+func newMemoryTx(ctx context.Context) *memoryTx {
+	return &memoryTx{
+		ctx:          ctx,
+		startedAt:    time.Now(),
+		players:      make(map[playerKey]*entities.Player),
+		transactions: make(map[uint64]*entities.Transaction),
+		operators:    make(map[string]*entities.Operator),
+		apiKeys:      make(map[uint64]*entities.APIKey),
+		outbox:       make(map[uint64]*entities.OutboxEvent),
+		deadLetters:  make(map[uint64]*entities.OutboxDeadLetter),
+	}
+}
+
+// begin registers a new transaction and returns its handle. The handle is only
+// a key into the store, it must never be used as a gorm session.
+func (s *MemoryStore) begin(ctx context.Context) *gorm.DB {
+	handle := &gorm.DB{}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.txs[handle] = newMemoryTx(ctx)
+	return handle
+}
+
+// commit applies the buffered writes of the transaction. Like a database/sql
+// transaction it is rolled back instead when its context was cancelled.
+func (s *MemoryStore) commit(handle *gorm.DB) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tx, ok := s.txs[handle]
+	if !ok {
+		return time.Time{}, errMemoryTxDone
+	}
+	delete(s.txs, handle)
+	if err := tx.ctx.Err(); err != nil {
+		s.rollbackLocked(tx)
+		return tx.startedAt, err
+	}
+	s.commitLocked(tx)
+	return tx.startedAt, nil
+}
+
+func (s *MemoryStore) rollback(handle *gorm.DB) (time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tx, ok := s.txs[handle]
+	if !ok {
+		return time.Time{}, false
+	}
+	delete(s.txs, handle)
+	s.rollbackLocked(tx)
+	return tx.startedAt, true
+}
+
+// run calls fn with the store locked and the transaction of handle, a nil
+// handle runs fn in a transaction of its own that commits when fn succeeds
+func (s *MemoryStore) run(ctx context.Context, handle *gorm.DB, fn func(tx *memoryTx) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if handle != nil {
+		tx, ok := s.txs[handle]
+		if !ok {
+			return errMemoryTxDone
+		}
+		if err := tx.ctx.Err(); err != nil {
+			return err
+		}
+		return fn(tx)
+	}
+
+	tx := newMemoryTx(ctx)
+	if err := fn(tx); err != nil {
+		s.rollbackLocked(tx)
+		return err
+	}
+	s.commitLocked(tx)
+	return nil
+}
+
+func (s *MemoryStore) commitLocked(tx *memoryTx) {
+	applyMemoryWrites(s.players, tx.players)
+	applyMemoryWrites(s.transactions, tx.transactions)
+	applyMemoryWrites(s.operators, tx.operators)
+	applyMemoryWrites(s.apiKeys, tx.apiKeys)
+	applyMemoryWrites(s.outbox, tx.outbox)
+	applyMemoryWrites(s.deadLetters, tx.deadLetters)
+	for _, key := range tx.uniqueKeys {
+		s.uniqueKeys[key] = nil
+	}
+	s.releaseLocked(tx)
+}
+
+func (s *MemoryStore) rollbackLocked(tx *memoryTx) {
+	for _, key := range tx.uniqueKeys {
+		delete(s.uniqueKeys, key)
+	}
+	s.releaseLocked(tx)
+}
+
+func (s *MemoryStore) releaseLocked(tx *memoryTx) {
+	for _, key := range tx.locks {
+		delete(s.locks, key)
+	}
+	tx.locks = nil
+	tx.uniqueKeys = nil
+	close(s.released)
+	s.released = make(chan struct{})
+}
+
+// lockRow locks the row for tx, waiting while another transaction holds it
+func (s *MemoryStore) lockRow(ctx context.Context, tx *memoryTx, key string) error {
+	for {
+		owner, locked := s.locks[key]
+		if !locked {
+			s.locks[key] = tx
+			tx.locks = append(tx.locks, key)
+			return nil
+		}
+		if owner == tx {
+			return nil
+		}
+		if err := s.waitLocked(ctx, tx, owner); err != nil {
+			return err
+		}
+	}
+}
+
+// tryLockRow locks the row when no other transaction holds it, like SKIP LOCKED
+func (s *MemoryStore) tryLockRow(tx *memoryTx, key string) bool {
+	owner, locked := s.locks[key]
+	if !locked {
+		s.locks[key] = tx
+		tx.locks = append(tx.locks, key)
+		return true
+	}
+	return owner == tx
+}
+
+// reserveUnique claims a value of a unique column for tx. A value inserted by a
+// transaction that is still open is waited for, the insert fails with
+// gorm.ErrDuplicatedKey once that transaction commits.
+func (s *MemoryStore) reserveUnique(ctx context.Context, tx *memoryTx, key string) error {
+	for {
+		owner, taken := s.uniqueKeys[key]
+		if !taken {
+			s.uniqueKeys[key] = tx
+			tx.uniqueKeys = append(tx.uniqueKeys, key)
+			return nil
+		}
+		if owner == nil || owner == tx {
+			return gorm.ErrDuplicatedKey
+		}
+		if err := s.waitLocked(ctx, tx, owner); err != nil {
+			return err
+		}
+	}
+}
+
+// waitLocked releases the store until a transaction ended or ctx is done. It
+// fails at once when owner is waiting on tx, directly or through others.
+func (s *MemoryStore) waitLocked(ctx context.Context, tx, owner *memoryTx) error {
+	for waiter := owner; waiter != nil; waiter = waiter.waitingFor {
+		if waiter == tx {
+			return ErrMemoryDeadlock
+		}
+	}
+	released := s.released
+	tx.waitingFor = owner
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		tx.waitingFor = nil
+	}()
+	select {
+	case <-released:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *MemoryStore) nextID(sequence string) uint64 {
+	s.sequences[sequence]++
+	return s.sequences[sequence]
+}
+
+// getMemoryRow returns the row as seen by a transaction, its own writes go over the committed rows
+func getMemoryRow[K comparable, V any](committed map[K]V, pending map[K]*V, key K) (V, bool) {
+	if row, ok := pending[key]; ok {
+		if row == nil {
+			var zero V
+			return zero, false
+		}
+		return *row, true
+	}
+	row, ok := committed[key]
+	return row, ok
+}
+
+// scanMemoryRows returns the rows seen by a transaction matching keep, in no particular order
+func scanMemoryRows[K comparable, V any](committed map[K]V, pending map[K]*V, keep func(V) bool) []V {
+	var rows []V
+	for key, row := range committed {
+		if _, ok := pending[key]; !ok && keep(row) {
+			rows = append(rows, row)
+		}
+	}
+	for _, row := range pending {
+		if row != nil && keep(*row) {
+			rows = append(rows, *row)
+		}
+	}
+	return rows
+}
+
+// lockMemoryRow locks an existing row for tx and returns its latest version, like
+// a locking read of Postgres it sees the commit of the transaction it waited for
+func lockMemoryRow[K comparable, V any](ctx context.Context, s *MemoryStore, tx *memoryTx, lockKey string, committed map[K]V, pending map[K]*V, key K) (V, error) {
+	if _, ok := getMemoryRow(committed, pending, key); !ok {
+		var zero V
+		return zero, gorm.ErrRecordNotFound
+	}
+	if err := s.lockRow(ctx, tx, lockKey); err != nil {
+		var zero V
+		return zero, err
+	}
+	row, ok := getMemoryRow(committed, pending, key)
+	if !ok {
+		return row, gorm.ErrRecordNotFound
+	}
+	return row, nil
+}
+
+func applyMemoryWrites[K comparable, V any](committed map[K]V, pending map[K]*V) {
+	for key, row := range pending {
+		if row == nil {
+			delete(committed, key)
+			continue
+		}
+		committed[key] = *row
+	}
+}
+
+// roundDecimal rounds like a DECIMAL(20,2) column does on write
+func roundDecimal(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+func playerRowKey(operatorID, id string) string {
+	return fmt.Sprintf("players:%s:%s", operatorID, id)
+}
+
+func transactionRowKey(id uint64) string {
+	return fmt.Sprintf("transactions:%d", id)
+}
+
+func apiKeyRowKey(id uint64) string {
+	return fmt.Sprintf("api_keys:%d", id)
+}
+
+func outboxRowKey(id uint64) string {
+	return fmt.Sprintf("outbox_events:%d", id)
+}
+
+func operatorPrimaryKey(id string) string {
+	return "operators:" + id
+}
+
+func cloneOperator(operator entities.Operator) *entities.Operator {
+	operator.Currencies = slices.Clone(operator.Currencies)
+	operator.SpendOrder = slices.Clone(operator.SpendOrder)
+	return &operator
+}
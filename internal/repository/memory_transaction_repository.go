@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"gorm.io/gorm"
+)
+
+type memoryTransactionRepository struct {
+	*memoryGormRepository
+}
+
+func NewMemoryTransactionRepository(store *MemoryStore) ITransactionRepository {
+	return &memoryTransactionRepository{&memoryGormRepository{store: store}}
+}
+
+func (r *memoryTransactionRepository) Create(ctx context.Context, transaction *entities.Transaction, outTx *gorm.DB) error {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	ctx, span := startMemorySpan(ctx, "TransactionRepository.Create")
+	defer span.End()
+	transaction.CreatedAt = time.Now()
+	transaction.UpdatedAt = time.Now()
+	return r.store.run(ctx, outTx, func(tx *memoryTx) error {
+		if err := r.store.reserveUnique(ctx, tx, "transactions_req:"+transaction.OperatorID+":"+transaction.ReqID); err != nil {
+			return err
+		}
+		if transaction.ID == 0 {
+			transaction.ID = r.store.nextID("transactions")
+		}
+		row := *transaction
+		row.Amount = roundDecimal(row.Amount)
+		tx.transactions[row.ID] = &row
+		return nil
+	})
+}
+
+func (r *memoryTransactionRepository) GetByReqID(ctx context.Context, operatorID, reqID string, outTx *gorm.DB) (*entities.Transaction, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	ctx, span := startMemorySpan(ctx, "TransactionRepository.GetByReqID")
+	defer span.End()
+	return r.first(ctx, outTx, false, func(transaction *entities.Transaction) bool {
+		return transaction.OperatorID == operatorID && transaction.ReqID == reqID
+	})
+}
+
+func (r *memoryTransactionRepository) GetByRoundID(ctx context.Context, operatorID, roundID string, outTx *gorm.DB) (*entities.Transaction, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	ctx, span := startMemorySpan(ctx, "TransactionRepository.GetByRoundID")
+	defer span.End()
+	return r.first(ctx, outTx, false, func(transaction *entities.Transaction) bool {
+		return transaction.OperatorID == operatorID && transaction.RoundID == roundID
+	})
+}
+
+func (r *memoryTransactionRepository) GetByPlayerID(ctx context.Context, operatorID, playerID string, outTx *gorm.DB) ([]*entities.Transaction, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	ctx, span := startMemorySpan(ctx, "TransactionRepository.GetByPlayerID")
+	defer span.End()
+	return r.find(ctx, outTx, func(transaction *entities.Transaction) bool {
+		return transaction.OperatorID == operatorID && transaction.PlayerID == playerID
+	})
+}
+
+func (r *memoryTransactionRepository) GetByReqIDWithLock(ctx context.Context, operatorID, reqID string, outTx *gorm.DB) (*entities.Transaction, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	ctx, span := startMemorySpan(ctx, "TransactionRepository.GetByReqIDWithLock")
+	defer span.End()
+	defer observeLockWait("transaction_by_req_id", time.Now())
+	return r.first(ctx, outTx, true, func(transaction *entities.Transaction) bool {
+		return transaction.OperatorID == operatorID && transaction.ReqID == reqID
+	})
+}
+
+func (r *memoryTransactionRepository) GetByRoundIDAndPlayerIDAndWalletID(ctx context.Context, operatorID, roundID, walletID string, transactionType entities.TransactionType, outTx *gorm.DB) (*entities.Transaction, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	ctx, span := startMemorySpan(ctx, "TransactionRepository.GetByRoundIDAndPlayerIDAndWalletID")
+	defer span.End()
+	return r.first(ctx, outTx, false, func(transaction *entities.Transaction) bool {
+		return transaction.OperatorID == operatorID && transaction.RoundID == roundID &&
+			transaction.WalletID == walletID && transaction.Type == transactionType
+	})
+}
+
+func (r *memoryTransactionRepository) GetByRoundIDAndPlayerIDAndWalletIDWithLock(ctx context.Context, operatorID, roundID, walletID string, transactionType entities.TransactionType, outTx *gorm.DB) (*entities.Transaction, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	ctx, span := startMemorySpan(ctx, "TransactionRepository.GetByRoundIDAndPlayerIDAndWalletIDWithLock")
+	defer span.End()
+	defer observeLockWait("transaction_by_round", time.Now())
+	return r.first(ctx, outTx, true, func(transaction *entities.Transaction) bool {
+		return transaction.OperatorID == operatorID && transaction.RoundID == roundID &&
+			transaction.WalletID == walletID && transaction.Type == transactionType
+	})
+}
+
+func (r *memoryTransactionRepository) GetByProviderAndPeriod(ctx context.Context, operatorID, providerID string, from, to time.Time, outTx *gorm.DB) ([]*entities.Transaction, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	ctx, span := startMemorySpan(ctx, "TransactionRepository.GetByProviderAndPeriod")
+	defer span.End()
+	return r.find(ctx, outTx, func(transaction *entities.Transaction) bool {
+		return transaction.OperatorID == operatorID && transaction.ProviderID == providerID &&
+			!transaction.CreatedAt.Before(from) && transaction.CreatedAt.Before(to)
+	})
+}
+
+func (r *memoryTransactionRepository) GetByReqIDs(ctx context.Context, operatorID string, reqIDs []string, outTx *gorm.DB) ([]*entities.Transaction, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	ctx, span := startMemorySpan(ctx, "TransactionRepository.GetByReqIDs")
+	defer span.End()
+	return r.find(ctx, outTx, func(transaction *entities.Transaction) bool {
+		return transaction.OperatorID == operatorID && slices.Contains(reqIDs, transaction.ReqID)
+	})
+}
+
+func (r *memoryTransactionRepository) GetByRoundIDs(ctx context.Context, operatorID string, roundIDs []string, outTx *gorm.DB) ([]*entities.Transaction, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	ctx, span := startMemorySpan(ctx, "TransactionRepository.GetByRoundIDs")
+	defer span.End()
+	return r.find(ctx, outTx, func(transaction *entities.Transaction) bool {
+		return transaction.OperatorID == operatorID && slices.Contains(roundIDs, transaction.RoundID)
+	})
+}
+
+// first returns the matching transaction with the lowest id. With lock set the
+// row is locked and read again once the lock is held.
+func (r *memoryTransactionRepository) first(ctx context.Context, outTx *gorm.DB, lock bool, match func(*entities.Transaction) bool) (*entities.Transaction, error) {
+	var transaction *entities.Transaction
+	err := r.store.run(ctx, outTx, func(tx *memoryTx) error {
+		rows := r.scan(tx, match)
+		if len(rows) == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		row := rows[0]
+		if lock {
+			var err error
+			if row, err = lockMemoryRow(ctx, r.store, tx, transactionRowKey(row.ID), r.store.transactions, tx.transactions, row.ID); err != nil {
+				return err
+			}
+		}
+		transaction = &row
+		return nil
+	})
+	return transaction, err
+}
+
+func (r *memoryTransactionRepository) find(ctx context.Context, outTx *gorm.DB, match func(*entities.Transaction) bool) ([]*entities.Transaction, error) {
+	var transactions []*entities.Transaction
+	err := r.store.run(ctx, outTx, func(tx *memoryTx) error {
+		rows := r.scan(tx, match)
+		for i := range rows {
+			transactions = append(transactions, &rows[i])
+		}
+		return nil
+	})
+	return transactions, err
+}
+
+// scan returns the matching transactions in id order, the caller holds the store
+func (r *memoryTransactionRepository) scan(tx *memoryTx, match func(*entities.Transaction) bool) []entities.Transaction {
+	rows := scanMemoryRows(r.store.transactions, tx.transactions, func(transaction entities.Transaction) bool {
+		return match(&transaction)
+	})
+	slices.SortFunc(rows, func(a, b entities.Transaction) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return rows
+}
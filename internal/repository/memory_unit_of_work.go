@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/metrics"
+)
+
+type memoryUnitOfWork struct {
+	gormRepository *memoryGormRepository
+}
+
+// memoryMaxAttempts bounds the runs of a unit of work that keeps losing optimistic balance updates
+const memoryMaxAttempts = 10
+
+// NewMemoryUnitOfWork runs units of work against the store. Transactions of the
+// store never abort on their own, so fn only runs again after an optimistic
+// balance update lost to another transaction. Reconciliation, reports and
+// balance checkpoints are not available, their repositories are nil.
+func NewMemoryUnitOfWork(store *MemoryStore) IUnitOfWork {
+	return &memoryUnitOfWork{
+		gormRepository: &memoryGormRepository{store: store},
+	}
+}
+
+func (u *memoryUnitOfWork) Do(ctx context.Context, fn UnitOfWorkFunc) error {
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = u.doOnce(ctx, fn); !errors.Is(err, ErrVersionConflict) {
+			return err
+		}
+		if attempt >= memoryMaxAttempts {
+			metrics.DBTransactionRetriesExhausted.WithLabelValues("version_conflict").Inc()
+			return err
+		}
+		metrics.DBTransactionRetries.WithLabelValues("version_conflict").Inc()
+	}
+}
+
+func (u *memoryUnitOfWork) doOnce(ctx context.Context, fn UnitOfWorkFunc) error {
+	tx, err := u.gormRepository.StartTransaction(ctx)
+	if err != nil {
+		return err
+	}
+	// A panicking unit of work must not leave the transaction and its row locks open
+	defer func() {
+		if p := recover(); p != nil {
+			u.gormRepository.RollbackTransaction(tx)
+			panic(p)
+		}
+	}()
+	bound := &memoryGormRepository{store: u.gormRepository.store, tx: tx}
+	return u.gormRepository.FinishTransaction(tx, fn(Repositories{
+		Players:       &memoryPlayerRepository{bound},
+		Transactions:  &memoryTransactionRepository{bound},
+		Operators:     &memoryOperatorRepository{bound},
+		APIKeys:       &memoryAPIKeyRepository{bound},
+		Outbox:        &memoryOutboxRepository{bound},
+		Webhooks:      NewMemoryWebhookRepository(),
+		Notifications: NewMemoryNotificationRepository(),
+	}))
+}
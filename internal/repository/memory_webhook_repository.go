@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"gorm.io/gorm"
+)
+
+// memoryWebhookRepository stands for an empty webhooks table, the in-memory
+// backend does not store webhooks so no event is ever delivered
+type memoryWebhookRepository struct{}
+
+func NewMemoryWebhookRepository() IWebhookRepository {
+	return memoryWebhookRepository{}
+}
+
+func (memoryWebhookRepository) Create(ctx context.Context, webhook *entities.Webhook, outTx *gorm.DB) error {
+	return ErrMemoryUnsupported
+}
+
+func (memoryWebhookRepository) GetByID(ctx context.Context, operatorID string, id uint64, outTx *gorm.DB) (*entities.Webhook, error) {
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (memoryWebhookRepository) GetAll(ctx context.Context, operatorID string, outTx *gorm.DB) ([]*entities.Webhook, error) {
+	return nil, nil
+}
+
+func (memoryWebhookRepository) Update(ctx context.Context, webhook *entities.Webhook, outTx *gorm.DB) error {
+	return gorm.ErrRecordNotFound
+}
+
+func (memoryWebhookRepository) Delete(ctx context.Context, operatorID string, id uint64, outTx *gorm.DB) error {
+	return gorm.ErrRecordNotFound
+}
+
+func (memoryWebhookRepository) EnqueueDeliveries(ctx context.Context, operatorID, eventType string, payload []byte, outTx *gorm.DB) (int64, error) {
+	return 0, nil
+}
+
+func (memoryWebhookRepository) GetDeliveries(ctx context.Context, operatorID string, webhookID uint64, limit int, outTx *gorm.DB) ([]*entities.WebhookDelivery, error) {
+	return nil, nil
+}
+
+func (memoryWebhookRepository) GetDeliveryByID(ctx context.Context, operatorID string, id uint64, outTx *gorm.DB) (*entities.WebhookDelivery, error) {
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (memoryWebhookRepository) ClaimDueDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int, outTx *gorm.DB) ([]*entities.WebhookDelivery, error) {
+	return nil, nil
+}
+
+func (memoryWebhookRepository) RecordAttempt(ctx context.Context, delivery *entities.WebhookDelivery, attempt *entities.WebhookDeliveryAttempt, leaseUntil time.Time, outTx *gorm.DB) error {
+	return gorm.ErrRecordNotFound
+}
+
+func (memoryWebhookRepository) Redeliver(ctx context.Context, operatorID string, id uint64, at time.Time, outTx *gorm.DB) error {
+	return gorm.ErrRecordNotFound
+}
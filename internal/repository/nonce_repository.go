@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// INonceRepository remembers the nonces of signed provider requests. The rows
+// live in the database, so a request replayed to another replica is caught too.
+type INonceRepository interface {
+	// Add stores the nonce of the provider until expiresAt and reports false
+	// when it is already stored and has not expired at now
+	Add(ctx context.Context, providerID, nonce string, now, expiresAt time.Time, outTx *gorm.DB) (bool, error)
+	// DeleteExpired removes the nonces that expired before now
+	DeleteExpired(ctx context.Context, now time.Time, outTx *gorm.DB) (int64, error)
+}
+
+type nonceRepository struct {
+	IGormRepository
+}
+
+func NewNonceRepository(repository IGormRepository) INonceRepository {
+	return &nonceRepository{
+		IGormRepository: repository,
+	}
+}
+
+func (r *nonceRepository) Add(ctx context.Context, providerID, nonce string, now, expiresAt time.Time, outTx *gorm.DB) (bool, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "NonceRepository.Add")
+	defer span.End()
+	// The unique key decides between concurrent requests, an expired row that
+	// was not deleted yet is taken over
+	result := outTx.Exec(`
+		INSERT INTO request_nonces (provider_id, nonce, expires_at) VALUES (?, ?, ?)
+		ON CONFLICT (provider_id, nonce) DO UPDATE SET expires_at = excluded.expires_at
+		WHERE request_nonces.expires_at <= ?`, providerID, nonce, expiresAt, now)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *nonceRepository) DeleteExpired(ctx context.Context, now time.Time, outTx *gorm.DB) (int64, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "NonceRepository.DeleteExpired")
+	defer span.End()
+	result := outTx.Exec("DELETE FROM request_nonces WHERE expires_at <= ?", now)
+	return result.RowsAffected, result.Error
+}
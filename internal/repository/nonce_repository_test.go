@@ -0,0 +1,48 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/repository"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNonceRepository(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	stores := map[string]repository.INonceRepository{
+		"memory": repository.NewMemoryNonceRepository(),
+		"sqlite": repository.NewNonceRepository(repository.NewGormRepository(testutil.OpenSQLite(t))),
+	}
+	for name, nonces := range stores {
+		t.Run(name, func(t *testing.T) {
+			added, err := nonces.Add(ctx, "provider-a", "nonce-1", now, now.Add(time.Minute), nil)
+			require.NoError(t, err)
+			assert.True(t, added)
+
+			added, err = nonces.Add(ctx, "provider-a", "nonce-1", now.Add(30*time.Second), now.Add(90*time.Second), nil)
+			require.NoError(t, err)
+			assert.False(t, added, "a stored nonce is rejected until it expires")
+
+			added, err = nonces.Add(ctx, "provider-b", "nonce-1", now, now.Add(time.Minute), nil)
+			require.NoError(t, err)
+			assert.True(t, added, "nonces are kept per provider")
+
+			added, err = nonces.Add(ctx, "provider-a", "nonce-1", now.Add(time.Minute), now.Add(2*time.Minute), nil)
+			require.NoError(t, err)
+			assert.True(t, added, "an expired nonce is taken over")
+
+			deleted, err := nonces.DeleteExpired(ctx, now.Add(time.Minute), nil)
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), deleted)
+
+			added, err = nonces.Add(ctx, "provider-a", "nonce-1", now.Add(time.Minute), now.Add(2*time.Minute), nil)
+			require.NoError(t, err)
+			assert.False(t, added)
+		})
+	}
+}
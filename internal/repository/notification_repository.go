@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type INotificationRepository interface {
+	// Notify sends a Postgres notification. Inside a transaction it is only
+	// delivered when the transaction commits and dropped on rollback. SQLite
+	// has no notifications, nothing is sent there.
+	Notify(ctx context.Context, channel string, payload []byte, outTx *gorm.DB) error
+}
+
+type notificationRepository struct {
+	IGormRepository
+}
+
+func NewNotificationRepository(repository IGormRepository) INotificationRepository {
+	return &notificationRepository{
+		IGormRepository: repository,
+	}
+}
+
+func (r *notificationRepository) Notify(ctx context.Context, channel string, payload []byte, outTx *gorm.DB) error {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "NotificationRepository.Notify")
+	defer span.End()
+	if isSQLite(outTx) {
+		return nil
+	}
+	return outTx.Exec("SELECT pg_notify(?, ?)", channel, string(payload)).Error
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"gorm.io/gorm"
+)
+
+type IOperatorRepository interface {
+	GetByID(ctx context.Context, id string, outTx *gorm.DB) (*entities.Operator, error)
+	Create(ctx context.Context, operator *entities.Operator, outTx *gorm.DB) error
+	Update(ctx context.Context, operator *entities.Operator, outTx *gorm.DB) error
+}
+
+type operatorRepository struct {
+	IGormRepository
+}
+
+func NewOperatorRepository(repository IGormRepository) IOperatorRepository {
+	return &operatorRepository{
+		IGormRepository: repository,
+	}
+}
+
+func (r *operatorRepository) GetByID(ctx context.Context, id string, outTx *gorm.DB) (*entities.Operator, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "OperatorRepository.GetByID")
+	defer span.End()
+	var operator entities.Operator
+	if err := outTx.First(&operator, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &operator, nil
+}
+
+func (r *operatorRepository) Create(ctx context.Context, operator *entities.Operator, outTx *gorm.DB) error {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "OperatorRepository.Create")
+	defer span.End()
+	operator.CreatedAt = time.Now()
+	operator.UpdatedAt = time.Now()
+	return outTx.Create(operator).Error
+}
+
+func (r *operatorRepository) Update(ctx context.Context, operator *entities.Operator, outTx *gorm.DB) error {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "OperatorRepository.Update")
+	defer span.End()
+	operator.UpdatedAt = time.Now()
+	// Selecting the columns also writes zero limits, which disable a limit
+	return outTx.Model(operator).
+		Select("name", "currencies", "min_bet", "max_bet", "spend_order", "updated_at").
+		Updates(operator).
+		Error
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"gorm.io/gorm"
+)
+
+type IOutboxRepository interface {
+	Create(ctx context.Context, event *entities.OutboxEvent, outTx *gorm.DB) error
+	// GetPublishableWithLock returns the oldest due event of every aggregate key,
+	// skipping rows another relay already holds. It has to run in a transaction.
+	GetPublishableWithLock(ctx context.Context, now time.Time, limit int, outTx *gorm.DB) ([]*entities.OutboxEvent, error)
+	Delete(ctx context.Context, id uint64, outTx *gorm.DB) error
+	MarkFailed(ctx context.Context, id uint64, attempts int, lastError string, nextAttemptAt time.Time, outTx *gorm.DB) error
+	// MoveToDeadLetter stores the event in the dead-letter table and removes it from the outbox
+	MoveToDeadLetter(ctx context.Context, event *entities.OutboxEvent, lastError string, outTx *gorm.DB) error
+	CountPending(ctx context.Context, outTx *gorm.DB) (int64, error)
+}
+
+type outboxRepository struct {
+	IGormRepository
+}
+
+func NewOutboxRepository(repository IGormRepository) IOutboxRepository {
+	return &outboxRepository{
+		IGormRepository: repository,
+	}
+}
+
+func (r *outboxRepository) Create(ctx context.Context, event *entities.OutboxEvent, outTx *gorm.DB) error {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "OutboxRepository.Create")
+	defer span.End()
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+	if event.NextAttemptAt.IsZero() {
+		event.NextAttemptAt = event.CreatedAt
+	}
+	return outTx.Create(event).Error
+}
+
+func (r *outboxRepository) GetPublishableWithLock(ctx context.Context, now time.Time, limit int, outTx *gorm.DB) ([]*entities.OutboxEvent, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "OutboxRepository.GetPublishableWithLock")
+	defer span.End()
+	defer observeLockWait("outbox_publishable", time.Now())
+	var events []*entities.OutboxEvent
+	// An event is only handed out while no older event of its key is left, which
+	// keeps the per key order even when a publish fails and is retried later
+	err := outTx.Raw(`
+		SELECT * FROM outbox_events o
+		WHERE o.next_attempt_at <= ?
+		AND NOT EXISTS (
+			SELECT 1 FROM outbox_events p
+			WHERE p.operator_id = o.operator_id AND p.aggregate_key = o.aggregate_key AND p.id < o.id
+		)
+		ORDER BY o.id
+		LIMIT ?
+		`+skipLockedSQL(outTx), now, limit).Scan(&events).Error
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (r *outboxRepository) Delete(ctx context.Context, id uint64, outTx *gorm.DB) error {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "OutboxRepository.Delete")
+	defer span.End()
+	return outTx.Delete(&entities.OutboxEvent{}, "id = ?", id).Error
+}
+
+func (r *outboxRepository) MarkFailed(ctx context.Context, id uint64, attempts int, lastError string, nextAttemptAt time.Time, outTx *gorm.DB) error {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "OutboxRepository.MarkFailed")
+	defer span.End()
+	return outTx.Model(&entities.OutboxEvent{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"attempts":        attempts,
+			"last_error":      lastError,
+			"next_attempt_at": nextAttemptAt,
+		}).Error
+}
+
+func (r *outboxRepository) MoveToDeadLetter(ctx context.Context, event *entities.OutboxEvent, lastError string, outTx *gorm.DB) error {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "OutboxRepository.MoveToDeadLetter")
+	defer span.End()
+	deadLetter := &entities.OutboxDeadLetter{
+		OutboxEventID:  event.ID,
+		OperatorID:     event.OperatorID,
+		AggregateKey:   event.AggregateKey,
+		EventType:      event.EventType,
+		Payload:        event.Payload,
+		Attempts:       event.Attempts,
+		LastError:      &lastError,
+		EventCreatedAt: event.CreatedAt,
+		CreatedAt:      time.Now(),
+	}
+	if err := outTx.Create(deadLetter).Error; err != nil {
+		return err
+	}
+	return outTx.Delete(&entities.OutboxEvent{}, "id = ?", event.ID).Error
+}
+
+func (r *outboxRepository) CountPending(ctx context.Context, outTx *gorm.DB) (int64, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "OutboxRepository.CountPending")
+	defer span.End()
+	var count int64
+	err := outTx.Model(&entities.OutboxEvent{}).Count(&count).Error
+	return count, err
+}
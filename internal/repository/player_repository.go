@@ -1,6 +1,9 @@
 package repository
 
 import (
+	"context"
+	"errors"
+	"strconv"
 	"time"
 
 	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
@@ -8,12 +11,24 @@ import (
 	"gorm.io/gorm/clause"
 )
 
+// ErrVersionConflict is returned when a player changed after it was read, the
+// unit of work is run again with the new version
+var ErrVersionConflict = errors.New("player was updated by another transaction")
+
+// IPlayerRepository scopes every query to a single operator, so players of
+// different operators never see each other even when their IDs collide
 type IPlayerRepository interface {
-	GetByID(id string, outTx *gorm.DB) (*entities.Player, error)
-	GetByIDWithLock(id string, outTx *gorm.DB) (*entities.Player, error)
-	GetAll(outTx *gorm.DB) ([]*entities.Player, error)
-	UpdateBalance(id string, amount float64, outTx *gorm.DB) error
-	Create(player *entities.Player, outTx *gorm.DB) error
+	GetByID(ctx context.Context, operatorID, id string, outTx *gorm.DB) (*entities.Player, error)
+	GetByIDWithLock(ctx context.Context, operatorID, id string, outTx *gorm.DB) (*entities.Player, error)
+	// List returns up to limit players matching filter in the given order, starting after the cursor when it is set
+	List(ctx context.Context, filter *entities.PlayerListFilter, order entities.PlayerOrder, after *entities.PlayerCursor, limit int, outTx *gorm.DB) ([]*entities.Player, error)
+	Count(ctx context.Context, filter *entities.PlayerListFilter, outTx *gorm.DB) (int64, error)
+	UpdateBalance(ctx context.Context, operatorID, id string, amount float64, outTx *gorm.DB) error
+	// UpdateBalanceIfVersion adds amount to the balance when the player is still
+	// at version and a negative amount does not take the balance below zero, in
+	// a single statement. It returns ErrVersionConflict otherwise.
+	UpdateBalanceIfVersion(ctx context.Context, operatorID, id string, version int64, amount float64, outTx *gorm.DB) error
+	Create(ctx context.Context, player *entities.Player, outTx *gorm.DB) error
 }
 
 type playerRepository struct {
@@ -26,54 +41,168 @@ func NewPlayerRepository(repository IGormRepository) IPlayerRepository {
 	}
 }
 
-func (r *playerRepository) GetByID(id string, outTx *gorm.DB) (*entities.Player, error) {
+func (r *playerRepository) GetByID(ctx context.Context, operatorID, id string, outTx *gorm.DB) (*entities.Player, error) {
 	if outTx == nil {
-		outTx = r.GetDB()
+		outTx = r.GetPlayerReadDB(ctx, operatorID, id)
 	}
+	outTx, span := startSpan(ctx, outTx, "PlayerRepository.GetByID")
+	defer span.End()
 	var player entities.Player
-	if err := outTx.First(&player, "id = ?", id).Error; err != nil {
+	if err := outTx.First(&player, "operator_id = ? AND id = ?", operatorID, id).Error; err != nil {
 		return nil, err
 	}
 	return &player, nil
 }
 
-func (r *playerRepository) GetByIDWithLock(id string, outTx *gorm.DB) (*entities.Player, error) {
+func (r *playerRepository) GetByIDWithLock(ctx context.Context, operatorID, id string, outTx *gorm.DB) (*entities.Player, error) {
 	if outTx == nil {
-		outTx = r.GetDB()
+		outTx = r.GetDB(ctx)
 	}
+	outTx, span := startSpan(ctx, outTx, "PlayerRepository.GetByIDWithLock")
+	defer span.End()
+	defer observeLockWait("player_by_id", time.Now())
 	var player entities.Player
-	if err := outTx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&player, "id = ?", id).Error; err != nil {
+	if err := outTx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&player, "operator_id = ? AND id = ?", operatorID, id).Error; err != nil {
 		return nil, err
 	}
 	return &player, nil
 }
 
-func (r *playerRepository) GetAll(outTx *gorm.DB) ([]*entities.Player, error) {
+func (r *playerRepository) List(ctx context.Context, filter *entities.PlayerListFilter, order entities.PlayerOrder, after *entities.PlayerCursor, limit int, outTx *gorm.DB) ([]*entities.Player, error) {
 	if outTx == nil {
-		outTx = r.GetDB()
+		outTx = r.GetReadDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "PlayerRepository.List")
+	defer span.End()
+
+	direction, comparison := "ASC", ">"
+	if order.Descending {
+		direction, comparison = "DESC", "<"
+	}
+	query := filterPlayers(outTx.Model(&entities.Player{}), filter)
+	// Row comparisons follow the (operator_id, field, id) indexes, balances are
+	// compared as decimals so the index stays usable
+	switch order.Field {
+	case entities.PlayerSortCreatedAt:
+		if after != nil {
+			query = query.Where("(created_at, id) "+comparison+" (?, ?)", after.CreatedAt, after.ID)
+		}
+		query = query.Order("created_at " + direction)
+	case entities.PlayerSortBalance:
+		if after != nil {
+			query = query.Where("(balance, id) "+comparison+" (CAST(? AS DECIMAL), ?)", formatDecimal(after.Balance), after.ID)
+		}
+		query = query.Order("balance " + direction)
+	default:
+		if after != nil {
+			query = query.Where("id "+comparison+" ?", after.ID)
+		}
 	}
+
 	var players []*entities.Player
-	if err := outTx.Find(&players).Error; err != nil {
+	if err := query.Order("id " + direction).Limit(limit).Find(&players).Error; err != nil {
 		return nil, err
 	}
 	return players, nil
 }
 
-func (r *playerRepository) UpdateBalance(id string, amount float64, outTx *gorm.DB) error {
+func (r *playerRepository) Count(ctx context.Context, filter *entities.PlayerListFilter, outTx *gorm.DB) (int64, error) {
 	if outTx == nil {
-		outTx = r.GetDB()
+		outTx = r.GetReadDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "PlayerRepository.Count")
+	defer span.End()
+	var count int64
+	if err := filterPlayers(outTx.Model(&entities.Player{}), filter).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func filterPlayers(query *gorm.DB, filter *entities.PlayerListFilter) *gorm.DB {
+	query = query.Where("operator_id = ?", filter.OperatorID)
+	if filter.Currency != "" {
+		query = query.Where("currency = ?", filter.Currency)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.MinBalance != nil {
+		query = query.Where("balance >= CAST(? AS DECIMAL)", formatDecimal(*filter.MinBalance))
+	}
+	if filter.MaxBalance != nil {
+		query = query.Where("balance <= CAST(? AS DECIMAL)", formatDecimal(*filter.MaxBalance))
+	}
+	if filter.CreatedFrom != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		query = query.Where("created_at < ?", *filter.CreatedTo)
 	}
+	return query
+}
+
+func formatDecimal(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func (r *playerRepository) UpdateBalance(ctx context.Context, operatorID, id string, amount float64, outTx *gorm.DB) error {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "PlayerRepository.UpdateBalance")
+	defer span.End()
+	r.RecordPlayerWrite(operatorID, id)
 	return outTx.Model(&entities.Player{}).
-		Where("id = ?", id).
-		UpdateColumn("balance", gorm.Expr("balance + ?", amount)).
+		Where("operator_id = ? AND id = ?", operatorID, id).
+		// Rounding keeps SQLite, where decimals are floats, at cents like DECIMAL(20,2).
+		// The version goes up as well, so optimistic writers of the player notice the change.
+		UpdateColumns(map[string]interface{}{
+			"balance": gorm.Expr("ROUND(balance + ?, 2)", amount),
+			"version": gorm.Expr("version + 1"),
+		}).
 		Error
 }
 
-func (r *playerRepository) Create(player *entities.Player, outTx *gorm.DB) error {
+func (r *playerRepository) UpdateBalanceIfVersion(ctx context.Context, operatorID, id string, version int64, amount float64, outTx *gorm.DB) error {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "PlayerRepository.UpdateBalanceIfVersion")
+	defer span.End()
+	r.RecordPlayerWrite(operatorID, id)
+	query := outTx.Model(&entities.Player{}).
+		Where("operator_id = ? AND id = ? AND version = ?", operatorID, id, version)
+	// Only a debit can take the balance below zero
+	if amount < 0 {
+		query = query.Where("balance + ? >= 0", amount)
+	}
+	result := query.
+		UpdateColumns(map[string]interface{}{
+			"balance": gorm.Expr("ROUND(balance + ?, 2)", amount),
+			"version": gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	// The balance was checked against the version that was read, so a balance
+	// that would go negative also means another transaction got in between
+	if result.RowsAffected == 0 {
+		return ErrVersionConflict
+	}
+	return nil
+}
+
+func (r *playerRepository) Create(ctx context.Context, player *entities.Player, outTx *gorm.DB) error {
 	if outTx == nil {
-		outTx = r.GetDB()
+		outTx = r.GetDB(ctx)
 	}
+	outTx, span := startSpan(ctx, outTx, "PlayerRepository.Create")
+	defer span.End()
 	player.CreatedAt = time.Now()
 	player.UpdatedAt = time.Now()
+	// A new wallet has no transactions, so its opening balance is the current one
+	player.InitialBalance = player.Balance
 	return outTx.Create(player).Error
 }
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+)
+
+// statementIsolationLevel gives the opening balance and the transactions of a
+// statement the same snapshot
+const statementIsolationLevel = "REPEATABLE READ READ ONLY"
+
+// PlayerStatementOpenFunc receives the player, its balance at the start of the
+// period and the archived months the period reaches into. When the period
+// starts within the first of them, openingBalance still contains the
+// transactions of that month from the start of the period on.
+type PlayerStatementOpenFunc func(player *entities.Player, openingBalance float64, archives []*entities.TransactionArchive) error
+
+// PlayerStatementTransactionFunc receives the transactions of the period in order
+type PlayerStatementTransactionFunc func(transaction *entities.Transaction) error
+
+type IPlayerStatementRepository interface {
+	// Read reads the player, its balance at from, the archives and its
+	// transactions in [from, to) that are still in the database in one snapshot. Transactions are passed to each as they are
+	// read from a cursor, never loaded as a whole. It returns
+	// gorm.ErrRecordNotFound before calling open when the player does not exist.
+	Read(ctx context.Context, operatorID, playerID string, from, to time.Time, open PlayerStatementOpenFunc, each PlayerStatementTransactionFunc) error
+}
+
+type playerStatementRepository struct {
+	IGormRepository
+}
+
+func NewPlayerStatementRepository(repository IGormRepository) IPlayerStatementRepository {
+	return &playerStatementRepository{
+		IGormRepository: repository,
+	}
+}
+
+func (r *playerStatementRepository) Read(ctx context.Context, operatorID, playerID string, from, to time.Time, open PlayerStatementOpenFunc, each PlayerStatementTransactionFunc) (err error) {
+	tx, err := r.StartTransactionWithIsolation(ctx, statementIsolationLevel)
+	if err != nil {
+		return err
+	}
+	// Nothing is written, the commit only ends the snapshot
+	defer func() {
+		err = r.FinishTransaction(tx, err)
+	}()
+	tx, span := startSpan(ctx, tx, "PlayerStatementRepository.Read")
+	defer span.End()
+
+	var player entities.Player
+	if err := tx.Where("operator_id = ? AND id = ?", operatorID, playerID).First(&player).Error; err != nil {
+		return err
+	}
+	var openingBalance float64
+	if err := tx.Table("players p").
+		Select(expectedBalanceSQL).
+		Joins("LEFT JOIN transactions t ON t.operator_id = p.operator_id AND t.player_id = p.id AND t.deleted_at IS NULL AND t.created_at < ?", from).
+		Where("p.operator_id = ? AND p.id = ?", operatorID, playerID).
+		Group("p.operator_id, p.id").
+		Scan(&openingBalance).Error; err != nil {
+		return err
+	}
+	// The opening balance of the wallet includes the archived months, the ones
+	// starting at or after from are taken out again
+	var archivedAfter float64
+	if err := tx.Raw(`SELECT COALESCE(SUM(amount), 0) FROM transaction_archive_totals
+		WHERE operator_id = ? AND player_id = ? AND month >= ?`, operatorID, playerID, from).
+		Scan(&archivedAfter).Error; err != nil {
+		return err
+	}
+	var archives []*entities.TransactionArchive
+	if err := tx.Where("month < ?", to).Order("month").Find(&archives).Error; err != nil {
+		return err
+	}
+	for len(archives) > 0 && !archives[0].End().After(from) {
+		archives = archives[1:]
+	}
+	if err := open(&player, openingBalance-archivedAfter, archives); err != nil {
+		return err
+	}
+
+	rows, err := tx.Model(&entities.Transaction{}).
+		Where("operator_id = ? AND player_id = ? AND created_at >= ? AND created_at < ?", operatorID, playerID, from, to).
+		Order("created_at, id").
+		Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var transaction entities.Transaction
+		if err := tx.ScanRows(rows, &transaction); err != nil {
+			return err
+		}
+		if err := each(&transaction); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,303 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// reconciliationLockKey is the advisory lock that keeps reconciliation runs of
+// all replicas from overlapping
+const reconciliationLockKey = 7_201_000_001
+
+// signedAmountSQL is the effect of transaction t on the balance
+const signedAmountSQL = `CASE WHEN t.type = 'result' THEN t.amount WHEN t.type = 'bet' THEN -t.amount ELSE 0 END`
+
+// expectedBalanceSQL replays the transactions of a wallet on top of its opening
+// balance. Rounding changes nothing on Postgres decimals, on SQLite it removes
+// the float error of the sum so it compares equal to the stored balance.
+const expectedBalanceSQL = `ROUND(p.initial_balance + COALESCE(SUM(` + signedAmountSQL + `), 0), 2)`
+
+type IReconciliationRepository interface {
+	// TryLock takes the transaction scoped reconciliation lock and reports false
+	// when another run holds it. It has to run in a transaction.
+	TryLock(ctx context.Context, outTx *gorm.DB) (bool, error)
+	// CountWallets counts the wallets of the operator, or of every operator when operatorID is empty
+	CountWallets(ctx context.Context, operatorID string, outTx *gorm.DB) (int64, error)
+	// GetDriftingWallets returns the wallets whose stored balance differs from the
+	// balance their transactions add up to. Balances and transactions are read
+	// in one statement, so they always belong to the same snapshot.
+	GetDriftingWallets(ctx context.Context, operatorID string, outTx *gorm.DB) ([]*entities.WalletBalanceCheck, error)
+	// GetWalletBalanceCheck recomputes the balance of a single wallet
+	GetWalletBalanceCheck(ctx context.Context, operatorID, playerID string, outTx *gorm.DB) (*entities.WalletBalanceCheck, error)
+	// GetLastConsistentCheck returns the start of the latest run that covered the
+	// wallet without finding a discrepancy, nil when there was none
+	GetLastConsistentCheck(ctx context.Context, operatorID, playerID string, outTx *gorm.DB) (*time.Time, error)
+	// GetSuspectTransactionIDs returns the transactions of the wallet that are
+	// invalid on their own, and all that were stored after since when it is set
+	GetSuspectTransactionIDs(ctx context.Context, wallet *entities.WalletBalanceCheck, since *time.Time, limit int, outTx *gorm.DB) ([]uint64, error)
+	CreateRun(ctx context.Context, run *entities.ReconciliationRun, outTx *gorm.DB) error
+	// GetRunByID loads the run with the discrepancies of one operator
+	GetRunByID(ctx context.Context, operatorID string, id uint64, outTx *gorm.DB) (*entities.ReconciliationRun, error)
+	GetDiscrepancies(ctx context.Context, operatorID string, status entities.DiscrepancyStatus, limit int, outTx *gorm.DB) ([]*entities.ReconciliationDiscrepancy, error)
+	GetDiscrepancyByIDWithLock(ctx context.Context, operatorID string, id uint64, outTx *gorm.DB) (*entities.ReconciliationDiscrepancy, error)
+	ResolveDiscrepancy(ctx context.Context, discrepancy *entities.ReconciliationDiscrepancy, outTx *gorm.DB) error
+	// ApplyAdjustment sets the wallet balance to the new balance of the adjustment and stores it
+	ApplyAdjustment(ctx context.Context, adjustment *entities.BalanceAdjustment, outTx *gorm.DB) error
+}
+
+type reconciliationRepository struct {
+	IGormRepository
+}
+
+func NewReconciliationRepository(repository IGormRepository) IReconciliationRepository {
+	return &reconciliationRepository{
+		IGormRepository: repository,
+	}
+}
+
+func (r *reconciliationRepository) TryLock(ctx context.Context, outTx *gorm.DB) (bool, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "ReconciliationRepository.TryLock")
+	defer span.End()
+	// A SQLite transaction holds the write lock from its start, a second run waits for it instead
+	if isSQLite(outTx) {
+		return true, nil
+	}
+	var locked bool
+	if err := outTx.Raw("SELECT pg_try_advisory_xact_lock(?)", reconciliationLockKey).Scan(&locked).Error; err != nil {
+		return false, err
+	}
+	return locked, nil
+}
+
+func (r *reconciliationRepository) CountWallets(ctx context.Context, operatorID string, outTx *gorm.DB) (int64, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "ReconciliationRepository.CountWallets")
+	defer span.End()
+	query := outTx.Model(&entities.Player{})
+	if operatorID != "" {
+		query = query.Where("operator_id = ?", operatorID)
+	}
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *reconciliationRepository) walletBalanceQuery(outTx *gorm.DB) *gorm.DB {
+	return outTx.Table("players p").
+		Select(`p.operator_id, p.id AS player_id, p.wallet_id, p.currency, p.initial_balance,
+			p.balance AS actual_balance, ` + expectedBalanceSQL + ` AS expected_balance`).
+		Joins("LEFT JOIN transactions t ON t.operator_id = p.operator_id AND t.player_id = p.id AND t.deleted_at IS NULL").
+		Where("p.deleted_at IS NULL").
+		Group("p.operator_id, p.id")
+}
+
+func (r *reconciliationRepository) GetDriftingWallets(ctx context.Context, operatorID string, outTx *gorm.DB) ([]*entities.WalletBalanceCheck, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "ReconciliationRepository.GetDriftingWallets")
+	defer span.End()
+	query := r.walletBalanceQuery(outTx)
+	if operatorID != "" {
+		query = query.Where("p.operator_id = ?", operatorID)
+	}
+	// Balances are compared as numeric, or rounded to cents on SQLite, so there is no float rounding involved
+	var wallets []*entities.WalletBalanceCheck
+	if err := query.Having("p.balance <> " + expectedBalanceSQL).
+		Order("p.operator_id, p.id").
+		Scan(&wallets).Error; err != nil {
+		return nil, err
+	}
+	return wallets, nil
+}
+
+func (r *reconciliationRepository) GetWalletBalanceCheck(ctx context.Context, operatorID, playerID string, outTx *gorm.DB) (*entities.WalletBalanceCheck, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "ReconciliationRepository.GetWalletBalanceCheck")
+	defer span.End()
+	var wallets []*entities.WalletBalanceCheck
+	if err := r.walletBalanceQuery(outTx).
+		Where("p.operator_id = ? AND p.id = ?", operatorID, playerID).
+		Scan(&wallets).Error; err != nil {
+		return nil, err
+	}
+	if len(wallets) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return wallets[0], nil
+}
+
+func (r *reconciliationRepository) GetLastConsistentCheck(ctx context.Context, operatorID, playerID string, outTx *gorm.DB) (*time.Time, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "ReconciliationRepository.GetLastConsistentCheck")
+	defer span.End()
+	var startedAt []time.Time
+	if err := outTx.Raw(`
+		SELECT r.started_at FROM reconciliation_runs r
+		WHERE (r.operator_id IS NULL OR r.operator_id = ?)
+		AND NOT EXISTS (
+			SELECT 1 FROM reconciliation_discrepancies d
+			WHERE d.run_id = r.id AND d.operator_id = ? AND d.player_id = ?
+		)
+		ORDER BY r.started_at DESC
+		LIMIT 1`, operatorID, operatorID, playerID).Scan(&startedAt).Error; err != nil {
+		return nil, err
+	}
+	if len(startedAt) == 0 {
+		return nil, nil
+	}
+	return &startedAt[0], nil
+}
+
+func (r *reconciliationRepository) GetSuspectTransactionIDs(ctx context.Context, wallet *entities.WalletBalanceCheck, since *time.Time, limit int, outTx *gorm.DB) ([]uint64, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "ReconciliationRepository.GetSuspectTransactionIDs")
+	defer span.End()
+	// A transaction is suspect when it does not fit the wallet, has no usable
+	// amount, is a result without a bet or repeats the type of an earlier
+	// transaction of the same round
+	suspect := `t.type NOT IN ('bet', 'result')
+		OR t.currency <> @currency
+		OR t.wallet_id <> @wallet_id
+		OR t.amount IS NULL OR t.amount <= 0
+		OR (t.type = 'result' AND NOT EXISTS (
+			SELECT 1 FROM transactions b
+			WHERE b.operator_id = t.operator_id AND b.wallet_id = t.wallet_id AND b.round_id = t.round_id
+			AND b.type = 'bet' AND b.deleted_at IS NULL))
+		OR EXISTS (
+			SELECT 1 FROM transactions d
+			WHERE d.operator_id = t.operator_id AND d.wallet_id = t.wallet_id AND d.round_id = t.round_id
+			AND d.type = t.type AND d.id < t.id AND d.deleted_at IS NULL)`
+	args := map[string]interface{}{
+		"operator_id": wallet.OperatorID,
+		"player_id":   wallet.PlayerID,
+		"wallet_id":   wallet.WalletID,
+		"currency":    wallet.Currency,
+		"limit":       limit,
+	}
+	if since != nil {
+		suspect += ` OR t.created_at > @since`
+		args["since"] = *since
+	}
+	var ids []uint64
+	if err := outTx.Raw(`
+		SELECT t.id FROM transactions t
+		WHERE t.operator_id = @operator_id AND t.player_id = @player_id AND t.deleted_at IS NULL
+		AND (`+suspect+`)
+		ORDER BY t.id
+		LIMIT @limit`, args).Scan(&ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (r *reconciliationRepository) CreateRun(ctx context.Context, run *entities.ReconciliationRun, outTx *gorm.DB) error {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "ReconciliationRepository.CreateRun")
+	defer span.End()
+	now := time.Now()
+	for i := range run.Discrepancies {
+		run.Discrepancies[i].CreatedAt = now
+	}
+	return outTx.Create(run).Error
+}
+
+func (r *reconciliationRepository) GetRunByID(ctx context.Context, operatorID string, id uint64, outTx *gorm.DB) (*entities.ReconciliationRun, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "ReconciliationRepository.GetRunByID")
+	defer span.End()
+	var run entities.ReconciliationRun
+	if err := outTx.Preload("Discrepancies", func(db *gorm.DB) *gorm.DB {
+		return db.Where("operator_id = ?", operatorID).Order("id")
+	}).First(&run, "id = ? AND (operator_id IS NULL OR operator_id = ?)", id, operatorID).Error; err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+func (r *reconciliationRepository) GetDiscrepancies(ctx context.Context, operatorID string, status entities.DiscrepancyStatus, limit int, outTx *gorm.DB) ([]*entities.ReconciliationDiscrepancy, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "ReconciliationRepository.GetDiscrepancies")
+	defer span.End()
+	query := outTx.Where("operator_id = ?", operatorID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	var discrepancies []*entities.ReconciliationDiscrepancy
+	if err := query.Order("id DESC").Limit(limit).Find(&discrepancies).Error; err != nil {
+		return nil, err
+	}
+	return discrepancies, nil
+}
+
+func (r *reconciliationRepository) GetDiscrepancyByIDWithLock(ctx context.Context, operatorID string, id uint64, outTx *gorm.DB) (*entities.ReconciliationDiscrepancy, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "ReconciliationRepository.GetDiscrepancyByIDWithLock")
+	defer span.End()
+	defer observeLockWait("discrepancy_by_id", time.Now())
+	var discrepancy entities.ReconciliationDiscrepancy
+	if err := outTx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&discrepancy, "operator_id = ? AND id = ?", operatorID, id).Error; err != nil {
+		return nil, err
+	}
+	return &discrepancy, nil
+}
+
+func (r *reconciliationRepository) ResolveDiscrepancy(ctx context.Context, discrepancy *entities.ReconciliationDiscrepancy, outTx *gorm.DB) error {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "ReconciliationRepository.ResolveDiscrepancy")
+	defer span.End()
+	return outTx.Model(&entities.ReconciliationDiscrepancy{}).
+		Where("id = ?", discrepancy.ID).
+		Updates(map[string]interface{}{
+			"status":      discrepancy.Status,
+			"resolved_by": discrepancy.ResolvedBy,
+			"resolved_at": discrepancy.ResolvedAt,
+		}).Error
+}
+
+func (r *reconciliationRepository) ApplyAdjustment(ctx context.Context, adjustment *entities.BalanceAdjustment, outTx *gorm.DB) error {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "ReconciliationRepository.ApplyAdjustment")
+	defer span.End()
+	r.RecordPlayerWrite(adjustment.OperatorID, adjustment.PlayerID)
+	if err := outTx.Model(&entities.Player{}).
+		Where("operator_id = ? AND id = ?", adjustment.OperatorID, adjustment.PlayerID).
+		Updates(map[string]interface{}{
+			"balance":    adjustment.NewBalance,
+			"version":    gorm.Expr("version + 1"),
+			"updated_at": adjustment.CreatedAt,
+		}).Error; err != nil {
+		return err
+	}
+	return outTx.Create(adjustment).Error
+}
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/metrics"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// replicaLagSQL is zero when the replica replayed everything it received, so an
+// idle primary does not make its replicas look behind
+const replicaLagSQL = `SELECT CASE
+		WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+		ELSE COALESCE(EXTRACT(EPOCH FROM NOW() - pg_last_xact_replay_timestamp()), 0)
+	END`
+
+type ReplicaConfig struct {
+	// MaxLag is the replication lag above which a replica receives no reads
+	MaxLag time.Duration
+	// CheckInterval is the time between lag checks, a replica whose last
+	// successful check is older than three intervals receives no reads
+	CheckInterval time.Duration
+	// ReadYourWritesWindow sends the reads of a player to the primary for this
+	// long after a write of the player, zero turns it off. It has to be longer
+	// than MaxLag for the replica to have caught up when it ends.
+	ReadYourWritesWindow time.Duration
+}
+
+type playerWrite struct {
+	operatorID string
+	playerID   string
+}
+
+type replica struct {
+	name string
+	db   *gorm.DB
+	// usable is set by the lag checks
+	usable    atomic.Bool
+	checkedAt atomic.Int64
+}
+
+// ReplicaSet spreads reads outside of transactions over the replicas that are
+// within the lag limit and keeps the reads of recently written players on the primary
+type ReplicaSet struct {
+	replicas []*replica
+	config   ReplicaConfig
+	next     atomic.Uint64
+
+	writesMu sync.Mutex
+	writes   map[string]time.Time
+}
+
+// NewReplicaSet takes the replica connections by name, the names label the metrics
+func NewReplicaSet(replicas map[string]*gorm.DB, config ReplicaConfig) *ReplicaSet {
+	if config.CheckInterval <= 0 {
+		config.CheckInterval = time.Second
+	}
+	set := &ReplicaSet{
+		config: config,
+		writes: make(map[string]time.Time),
+	}
+	for name, db := range replicas {
+		set.replicas = append(set.replicas, &replica{name: name, db: db})
+	}
+	return set
+}
+
+// Run checks the lag of the replicas until ctx is cancelled, replicas receive
+// no reads before their first check
+func (s *ReplicaSet) Run(ctx context.Context) {
+	zap.L().Info("Replica lag checks started",
+		zap.Int("replicas", len(s.replicas)),
+		zap.Duration("max_lag", s.config.MaxLag))
+	ticker := time.NewTicker(s.config.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		s.check(ctx)
+		s.forgetWrites()
+		select {
+		case <-ctx.Done():
+			zap.L().Info("Replica lag checks stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *ReplicaSet) check(ctx context.Context) {
+	for _, r := range s.replicas {
+		checkCtx, cancel := context.WithTimeout(ctx, s.config.CheckInterval)
+		var lag float64
+		err := r.db.WithContext(checkCtx).Raw(replicaLagSQL).Scan(&lag).Error
+		cancel()
+		if err != nil {
+			if r.usable.Swap(false) {
+				zap.L().Warn("Replica lag check failed, reading from the primary", zap.String("replica", r.name), zap.Error(err))
+			}
+			continue
+		}
+		metrics.DBReplicaLag.WithLabelValues(r.name).Set(lag)
+		usable := time.Duration(lag*float64(time.Second)) <= s.config.MaxLag
+		if r.usable.Swap(usable) != usable {
+			zap.L().Info("Replica usability changed",
+				zap.String("replica", r.name),
+				zap.Bool("usable", usable),
+				zap.Float64("lag_seconds", lag))
+		}
+		r.checkedAt.Store(time.Now().UnixNano())
+	}
+}
+
+// pick returns a usable replica in turn, or nil when there is none
+func (s *ReplicaSet) pick() *gorm.DB {
+	staleBefore := time.Now().Add(-3 * s.config.CheckInterval).UnixNano()
+	start := s.next.Add(1)
+	for i := range s.replicas {
+		r := s.replicas[(start+uint64(i))%uint64(len(s.replicas))]
+		if r.usable.Load() && r.checkedAt.Load() > staleBefore {
+			return r.db
+		}
+	}
+	return nil
+}
+
+// RecordWrite keeps the reads of the player on the primary for the read-your-writes window
+func (s *ReplicaSet) RecordWrite(operatorID, playerID string) {
+	if s.config.ReadYourWritesWindow <= 0 {
+		return
+	}
+	s.writesMu.Lock()
+	s.writes[operatorID+"/"+playerID] = time.Now().Add(s.config.ReadYourWritesWindow)
+	s.writesMu.Unlock()
+}
+
+func (s *ReplicaSet) recentlyWritten(operatorID, playerID string) bool {
+	s.writesMu.Lock()
+	defer s.writesMu.Unlock()
+	until, ok := s.writes[operatorID+"/"+playerID]
+	return ok && time.Now().Before(until)
+}
+
+func (s *ReplicaSet) forgetWrites() {
+	now := time.Now()
+	s.writesMu.Lock()
+	defer s.writesMu.Unlock()
+	for key, until := range s.writes {
+		if !now.Before(until) {
+			delete(s.writes, key)
+		}
+	}
+}
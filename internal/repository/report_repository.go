@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"gorm.io/gorm"
+)
+
+// ggrRollupLockKey is the advisory lock that keeps GGR rollups of all replicas from overlapping
+const ggrRollupLockKey = 7_201_000_002
+
+// transactionDaySQL is the UTC day of a transaction
+const transactionDaySQL = `(created_at AT TIME ZONE 'UTC')::date`
+
+type IReportRepository interface {
+	// TryLockRollup takes the transaction scoped rollup lock and reports false
+	// when another rollup holds it. It has to run in a transaction.
+	TryLockRollup(ctx context.Context, outTx *gorm.DB) (bool, error)
+	// RollupGGR recomputes the daily statistics of the days from to to, both
+	// inclusive, from the transactions of every operator
+	RollupGGR(ctx context.Context, from, to time.Time, outTx *gorm.DB) error
+	// GetGGR sums the daily statistics by the dimensions of the filter
+	GetGGR(ctx context.Context, filter *entities.GGRReportFilter, outTx *gorm.DB) ([]*entities.GGRReportRow, error)
+}
+
+type reportRepository struct {
+	IGormRepository
+}
+
+func NewReportRepository(repository IGormRepository) IReportRepository {
+	return &reportRepository{
+		IGormRepository: repository,
+	}
+}
+
+func (r *reportRepository) TryLockRollup(ctx context.Context, outTx *gorm.DB) (bool, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "ReportRepository.TryLockRollup")
+	defer span.End()
+	var locked bool
+	if err := outTx.Raw("SELECT pg_try_advisory_xact_lock(?)", ggrRollupLockKey).Scan(&locked).Error; err != nil {
+		return false, err
+	}
+	return locked, nil
+}
+
+func (r *reportRepository) RollupGGR(ctx context.Context, from, to time.Time, outTx *gorm.DB) error {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "ReportRepository.RollupGGR")
+	defer span.End()
+	args := map[string]interface{}{
+		"from":  from.Format(entities.ReportDateLayout),
+		"to":    to.Format(entities.ReportDateLayout),
+		"start": from,
+		"end":   to.AddDate(0, 0, 1),
+	}
+	// The days are replaced as a whole, so groups whose transactions were deleted disappear as well
+	statements := []string{
+		`DELETE FROM ggr_daily_players WHERE day BETWEEN @from AND @to`,
+		`DELETE FROM ggr_daily_stats WHERE day BETWEEN @from AND @to`,
+		`INSERT INTO ggr_daily_players (day, operator_id, provider_id, game_code, currency, player_id)
+		SELECT DISTINCT ` + transactionDaySQL + `, operator_id, provider_id, game_code, currency, player_id
+		FROM transactions
+		WHERE deleted_at IS NULL AND created_at >= @start AND created_at < @end`,
+		`INSERT INTO ggr_daily_stats (day, operator_id, provider_id, game_code, currency, turnover, wins, round_count, unique_players, updated_at)
+		SELECT ` + transactionDaySQL + `, operator_id, provider_id, game_code, currency,
+			COALESCE(SUM(amount) FILTER (WHERE type = 'bet'), 0),
+			COALESCE(SUM(amount) FILTER (WHERE type = 'result'), 0),
+			COUNT(*) FILTER (WHERE type = 'bet'),
+			COUNT(DISTINCT player_id),
+			NOW()
+		FROM transactions
+		WHERE deleted_at IS NULL AND created_at >= @start AND created_at < @end
+		GROUP BY 1, 2, 3, 4, 5`,
+	}
+	for _, statement := range statements {
+		if err := outTx.Exec(statement, args).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *reportRepository) GetGGR(ctx context.Context, filter *entities.GGRReportFilter, outTx *gorm.DB) ([]*entities.GGRReportRow, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "ReportRepository.GetGGR")
+	defer span.End()
+
+	// Dimensions are validated by the service, they are safe to use as column names
+	columns := make([]string, len(filter.GroupBy))
+	for i, dimension := range filter.GroupBy {
+		columns[i] = string(dimension)
+	}
+	selectColumns, groupBy, join := "", "", "CROSS JOIN players p"
+	if len(columns) > 0 {
+		selectColumns = strings.Join(columns, ", ") + ", "
+		groupBy = "GROUP BY " + strings.Join(columns, ", ")
+		join = "JOIN players p USING (" + strings.Join(columns, ", ") + ")"
+	}
+
+	where := "operator_id = @operator_id AND day BETWEEN @from AND @to"
+	args := map[string]interface{}{
+		"operator_id": filter.OperatorID,
+		"from":        filter.From.Format(entities.ReportDateLayout),
+		"to":          filter.To.Format(entities.ReportDateLayout),
+	}
+	for _, condition := range []struct{ column, value string }{
+		{"provider_id", filter.ProviderID},
+		{"game_code", filter.GameCode},
+		{"currency", filter.Currency},
+	} {
+		if condition.value != "" {
+			where += " AND " + condition.column + " = @" + condition.column
+			args[condition.column] = condition.value
+		}
+	}
+
+	// Unique players are counted from the daily players, they do not add up over days or games
+	query := `WITH stats AS (
+			SELECT ` + selectColumns + `COALESCE(SUM(turnover), 0) AS turnover, COALESCE(SUM(wins), 0) AS wins, COALESCE(SUM(round_count), 0) AS round_count
+			FROM ggr_daily_stats WHERE ` + where + ` ` + groupBy + `
+		), players AS (
+			SELECT ` + selectColumns + `COUNT(DISTINCT player_id) AS unique_players
+			FROM ggr_daily_players WHERE ` + where + ` ` + groupBy + `
+		)
+		SELECT s.*, s.turnover - s.wins AS ggr, p.unique_players
+		FROM stats s ` + join
+	if len(columns) > 0 {
+		query += " ORDER BY " + strings.Join(columns, ", ")
+	}
+
+	var rows []*entities.GGRReportRow
+	if err := outTx.Raw(query, args).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
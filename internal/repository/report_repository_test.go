@@ -0,0 +1,95 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/repository"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportRepositoryGetGGR(t *testing.T) {
+	db := testutil.OpenSQLite(t)
+	require.NoError(t, db.Exec("INSERT INTO operators (id, name) VALUES (?, ?)", "other", "Other operator").Error)
+
+	stats := []struct {
+		day, operatorID, providerID, gameCode, currency string
+		turnover, wins                                  float64
+		rounds                                          int64
+		players                                         []string
+	}{
+		{"2025-01-01", "default", "provider-a", "slots", "EUR", 100, 40, 2, []string{"p1", "p2"}},
+		{"2025-01-01", "default", "provider-a", "slots", "TRY", 3000, 1000, 3, []string{"p3"}},
+		{"2025-01-01", "default", "provider-b", "poker", "EUR", 50, 70, 1, []string{"p1"}},
+		{"2025-01-02", "default", "provider-a", "slots", "EUR", 10, 0, 1, []string{"p1"}},
+		// Outside of the range or of another operator
+		{"2025-01-03", "default", "provider-a", "slots", "EUR", 999, 0, 9, []string{"p9"}},
+		{"2025-01-01", "other", "provider-a", "slots", "EUR", 999, 0, 9, []string{"p9"}},
+	}
+	for _, stat := range stats {
+		require.NoError(t, db.Exec(`INSERT INTO ggr_daily_stats (day, operator_id, provider_id, game_code, currency, turnover, wins, round_count, unique_players)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			stat.day, stat.operatorID, stat.providerID, stat.gameCode, stat.currency, stat.turnover, stat.wins, stat.rounds, len(stat.players)).Error)
+		for _, playerID := range stat.players {
+			require.NoError(t, db.Exec(`INSERT INTO ggr_daily_players (day, operator_id, provider_id, game_code, currency, player_id) VALUES (?, ?, ?, ?, ?, ?)`,
+				stat.day, stat.operatorID, stat.providerID, stat.gameCode, stat.currency, playerID).Error)
+		}
+	}
+
+	day1 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	day2 := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		filter   entities.GGRReportFilter
+		expected []*entities.GGRReportRow
+	}{
+		{
+			name:   "by day and currency",
+			filter: entities.GGRReportFilter{GroupBy: []entities.GGRDimension{entities.GGRDimensionDay, entities.GGRDimensionCurrency}},
+			expected: []*entities.GGRReportRow{
+				{Day: &day1, Currency: "EUR", Turnover: 150, Wins: 110, GGR: 40, RoundCount: 3, UniquePlayers: 2},
+				{Day: &day1, Currency: "TRY", Turnover: 3000, Wins: 1000, GGR: 2000, RoundCount: 3, UniquePlayers: 1},
+				{Day: &day2, Currency: "EUR", Turnover: 10, Wins: 0, GGR: 10, RoundCount: 1, UniquePlayers: 1},
+			},
+		},
+		{
+			name:   "by provider filtered to one currency",
+			filter: entities.GGRReportFilter{GroupBy: []entities.GGRDimension{entities.GGRDimensionProvider}, Currency: "EUR"},
+			expected: []*entities.GGRReportRow{
+				// p1 played on both days, it is counted once
+				{ProviderID: "provider-a", Turnover: 110, Wins: 40, GGR: 70, RoundCount: 3, UniquePlayers: 2},
+				{ProviderID: "provider-b", Turnover: 50, Wins: 70, GGR: -20, RoundCount: 1, UniquePlayers: 1},
+			},
+		},
+		{
+			name:   "by game of one provider",
+			filter: entities.GGRReportFilter{GroupBy: []entities.GGRDimension{entities.GGRDimensionGame, entities.GGRDimensionCurrency}, ProviderID: "provider-b"},
+			expected: []*entities.GGRReportRow{
+				{GameCode: "poker", Currency: "EUR", Turnover: 50, Wins: 70, GGR: -20, RoundCount: 1, UniquePlayers: 1},
+			},
+		},
+		{
+			name:   "no dimensions",
+			filter: entities.GGRReportFilter{Currency: "TRY"},
+			expected: []*entities.GGRReportRow{
+				{Turnover: 3000, Wins: 1000, GGR: 2000, RoundCount: 3, UniquePlayers: 1},
+			},
+		},
+	}
+	reportRepo := repository.NewReportRepository(repository.NewGormRepository(db))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := tt.filter
+			filter.OperatorID = entities.DefaultOperatorID
+			filter.From = day1
+			filter.To = day2
+			rows, err := reportRepo.GetGGR(context.Background(), &filter, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, rows)
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net/url"
+	"time"
+
+	gosqlite "github.com/glebarez/go-sqlite"
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+// sqliteBusy is the primary result code SQLite fails with when the write lock
+// could not be taken within the busy timeout
+const sqliteBusy = 5
+
+// sqliteConstraintUnique is the extended result code of an insert or update
+// that violates a unique constraint
+const sqliteConstraintUnique = 2067
+
+// SQLiteDSN returns the data source name of the database file at path. Foreign
+// keys are enforced like on Postgres, WAL lets reads run next to the writer and
+// transactions begin IMMEDIATE: they take the write lock up front, so writers
+// queue behind each other instead of failing when they upgrade from a read.
+func SQLiteDSN(path string) string {
+	query := url.Values{}
+	query.Add("_pragma", "foreign_keys(1)")
+	query.Add("_pragma", "journal_mode(WAL)")
+	query.Add("_pragma", "busy_timeout(5000)")
+	query.Set("_txlock", "immediate")
+	return "file:" + path + "?" + query.Encode()
+}
+
+// NewSQLiteDialector returns the GORM dialector of a database opened with SQLiteDSN.
+//
+// SQLite stores times as text and compares them as text, so every time passed
+// to a query is converted to UTC first. Rows written in different zones would
+// otherwise not sort by time.
+func NewSQLiteDialector(db *sql.DB) gorm.Dialector {
+	return &sqlite.Dialector{Conn: &sqliteConnPool{db: db}}
+}
+
+// isSQLite reports whether the session runs on SQLite. Transactions there hold
+// the write lock from their start, which serializes them: row locks, SKIP
+// LOCKED and advisory locks have nothing left to do and the dialector drops
+// the locking clauses of GORM queries on its own.
+func isSQLite(db *gorm.DB) bool {
+	return db.Dialector.Name() == "sqlite"
+}
+
+// skipLockedSQL returns the clause that claims rows for the caller and skips
+// rows claimed by others, appended to raw queries
+func skipLockedSQL(db *gorm.DB) string {
+	if isSQLite(db) {
+		return ""
+	}
+	return "FOR UPDATE SKIP LOCKED"
+}
+
+// isSQLiteBusy reports whether SQLite gave up waiting for the write lock
+func isSQLiteBusy(err error) bool {
+	var sqliteErr *gosqlite.Error
+	return errors.As(err, &sqliteErr) && sqliteErr.Code()&0xff == sqliteBusy
+}
+
+// isSQLiteUniqueViolation reports whether a write violated a unique constraint
+func isSQLiteUniqueViolation(err error) bool {
+	var sqliteErr *gosqlite.Error
+	return errors.As(err, &sqliteErr) && sqliteErr.Code() == sqliteConstraintUnique
+}
+
+// sqliteConnPool passes queries to the database with their times in UTC
+type sqliteConnPool struct {
+	db *sql.DB
+}
+
+func (p *sqliteConnPool) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return p.db.PrepareContext(ctx, query)
+}
+
+func (p *sqliteConnPool) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return p.db.ExecContext(ctx, query, utcArgs(args)...)
+}
+
+func (p *sqliteConnPool) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return p.db.QueryContext(ctx, query, utcArgs(args)...)
+}
+
+func (p *sqliteConnPool) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return p.db.QueryRowContext(ctx, query, utcArgs(args)...)
+}
+
+func (p *sqliteConnPool) BeginTx(ctx context.Context, opts *sql.TxOptions) (gorm.ConnPool, error) {
+	conn, err := p.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := conn.BeginTx(ctx, opts)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return &sqliteTx{conn: conn, tx: tx}, nil
+}
+
+// GetDBConn lets gorm.DB.DB return the underlying database
+func (p *sqliteConnPool) GetDBConn() (*sql.DB, error) {
+	return p.db, nil
+}
+
+// sqliteTx holds its connection, so a failed commit can be cleaned up on it
+type sqliteTx struct {
+	conn *sql.Conn
+	tx   *sql.Tx
+}
+
+func (t *sqliteTx) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return t.tx.PrepareContext(ctx, query)
+}
+
+func (t *sqliteTx) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return t.tx.ExecContext(ctx, query, utcArgs(args)...)
+}
+
+func (t *sqliteTx) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return t.tx.QueryContext(ctx, query, utcArgs(args)...)
+}
+
+func (t *sqliteTx) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return t.tx.QueryRowContext(ctx, query, utcArgs(args)...)
+}
+
+// Commit commits the transaction. SQLite keeps the transaction open when the
+// commit fails, e.g. on a deferred foreign key violation, so it is rolled back
+// before the connection goes back to the pool with its write lock.
+func (t *sqliteTx) Commit() error {
+	defer t.conn.Close()
+	err := t.tx.Commit()
+	if err != nil {
+		t.conn.ExecContext(context.Background(), "ROLLBACK")
+	}
+	return err
+}
+
+func (t *sqliteTx) Rollback() error {
+	defer t.conn.Close()
+	return t.tx.Rollback()
+}
+
+// utcArgs converts the times among args to UTC, including the ones behind a
+// driver.Valuer such as gorm.DeletedAt
+func utcArgs(args []interface{}) []interface{} {
+	for i, arg := range args {
+		if valuer, ok := arg.(driver.Valuer); ok {
+			if value, err := valuer.Value(); err == nil {
+				if t, ok := value.(time.Time); ok {
+					args[i] = t.UTC()
+				}
+			}
+			continue
+		}
+		switch t := arg.(type) {
+		case time.Time:
+			args[i] = t.UTC()
+		case *time.Time:
+			if t != nil {
+				args[i] = t.UTC()
+			}
+		}
+	}
+	return args
+}
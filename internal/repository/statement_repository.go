@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"gorm.io/gorm"
+)
+
+// mismatchBatchSize keeps a single insert of mismatches below the bind parameter limit
+const mismatchBatchSize = 1000
+
+type IStatementRepository interface {
+	// Create stores the import together with its mismatches
+	Create(ctx context.Context, statementImport *entities.StatementImport, outTx *gorm.DB) error
+	GetAll(ctx context.Context, operatorID, providerID string, limit int, outTx *gorm.DB) ([]*entities.StatementImport, error)
+	// GetByID loads the import with its mismatches, only those of kind when it is set
+	GetByID(ctx context.Context, operatorID string, id uint64, kind entities.StatementMismatchKind, outTx *gorm.DB) (*entities.StatementImport, error)
+}
+
+type statementRepository struct {
+	IGormRepository
+}
+
+func NewStatementRepository(repository IGormRepository) IStatementRepository {
+	return &statementRepository{
+		IGormRepository: repository,
+	}
+}
+
+func (r *statementRepository) Create(ctx context.Context, statementImport *entities.StatementImport, outTx *gorm.DB) error {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "StatementRepository.Create")
+	defer span.End()
+	if err := outTx.Omit("Mismatches").Create(statementImport).Error; err != nil {
+		return err
+	}
+	if len(statementImport.Mismatches) == 0 {
+		return nil
+	}
+	for i := range statementImport.Mismatches {
+		statementImport.Mismatches[i].ImportID = statementImport.ID
+	}
+	return outTx.CreateInBatches(statementImport.Mismatches, mismatchBatchSize).Error
+}
+
+func (r *statementRepository) GetAll(ctx context.Context, operatorID, providerID string, limit int, outTx *gorm.DB) ([]*entities.StatementImport, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "StatementRepository.GetAll")
+	defer span.End()
+	query := outTx.Where("operator_id = ?", operatorID)
+	if providerID != "" {
+		query = query.Where("provider_id = ?", providerID)
+	}
+	var imports []*entities.StatementImport
+	if err := query.Order("id DESC").Limit(limit).Find(&imports).Error; err != nil {
+		return nil, err
+	}
+	return imports, nil
+}
+
+func (r *statementRepository) GetByID(ctx context.Context, operatorID string, id uint64, kind entities.StatementMismatchKind, outTx *gorm.DB) (*entities.StatementImport, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "StatementRepository.GetByID")
+	defer span.End()
+	var statementImport entities.StatementImport
+	if err := outTx.Preload("Mismatches", func(db *gorm.DB) *gorm.DB {
+		if kind != "" {
+			db = db.Where("kind = ?", kind)
+		}
+		return db.Order("id")
+	}).First(&statementImport, "operator_id = ? AND id = ?", operatorID, id).Error; err != nil {
+		return nil, err
+	}
+	return &statementImport, nil
+}
@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"gorm.io/gorm"
+)
+
+// transactionPartitionLockKey is the advisory lock that keeps partition
+// maintenance runs of all replicas from overlapping
+const transactionPartitionLockKey = 7_201_000_004
+
+// transactionPartitionPrefix is followed by YYYY_MM in the names of the monthly
+// partitions, create_transactions_partition names them the same way
+const transactionPartitionPrefix = "transactions_"
+
+// detachLockTimeout bounds the wait for the transactions still reading the
+// table before a partition is detached, the next run tries again
+const detachLockTimeout = "10s"
+
+// ErrArchiveMismatch is returned when the partition of an archived month holds
+// other transactions than the ones written to its file
+var ErrArchiveMismatch = errors.New("partition changed while it was archived")
+
+type ITransactionArchiveRepository interface {
+	// TryLock takes the transaction scoped partition lock and reports false
+	// when another run holds it. It has to run in a transaction.
+	TryLock(ctx context.Context, outTx *gorm.DB) (bool, error)
+	// CreatePartition creates the partition of the month starting at month unless it exists
+	CreatePartition(ctx context.Context, month time.Time, outTx *gorm.DB) error
+	// GetPartitionMonths returns the months starting before before that have a
+	// partition, oldest first
+	GetPartitionMonths(ctx context.Context, before time.Time, outTx *gorm.DB) ([]time.Time, error)
+	// ReadPartition blocks writes to the partition of month until the
+	// transaction ends and passes its transactions to each in created_at order.
+	// Soft deleted transactions are passed as well, with their DeletedAt set.
+	ReadPartition(ctx context.Context, month time.Time, each func(transaction *entities.Transaction) error, outTx *gorm.DB) error
+	// Archive records the archive of a month, adds the effect of its
+	// transactions to the opening balances of the wallets and drops its
+	// partition. Reconciliation runs are waited for, they would see the
+	// balances change under them.
+	Archive(ctx context.Context, archive *entities.TransactionArchive, outTx *gorm.DB) error
+	// GetArchives returns the archived months, oldest first
+	GetArchives(ctx context.Context, outTx *gorm.DB) ([]*entities.TransactionArchive, error)
+}
+
+type transactionArchiveRepository struct {
+	IGormRepository
+}
+
+func NewTransactionArchiveRepository(repository IGormRepository) ITransactionArchiveRepository {
+	return &transactionArchiveRepository{
+		IGormRepository: repository,
+	}
+}
+
+// transactionPartitionName returns the partition of the month starting at month
+func transactionPartitionName(month time.Time) string {
+	return transactionPartitionPrefix + month.UTC().Format("2006_01")
+}
+
+func (r *transactionArchiveRepository) TryLock(ctx context.Context, outTx *gorm.DB) (bool, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "TransactionArchiveRepository.TryLock")
+	defer span.End()
+	var locked bool
+	if err := outTx.Raw("SELECT pg_try_advisory_xact_lock(?)", transactionPartitionLockKey).Scan(&locked).Error; err != nil {
+		return false, err
+	}
+	return locked, nil
+}
+
+func (r *transactionArchiveRepository) CreatePartition(ctx context.Context, month time.Time, outTx *gorm.DB) error {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "TransactionArchiveRepository.CreatePartition")
+	defer span.End()
+	return outTx.Exec("SELECT create_transactions_partition(?)", month).Error
+}
+
+func (r *transactionArchiveRepository) GetPartitionMonths(ctx context.Context, before time.Time, outTx *gorm.DB) ([]time.Time, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "TransactionArchiveRepository.GetPartitionMonths")
+	defer span.End()
+	var names []string
+	if err := outTx.Raw(`SELECT c.relname
+		FROM pg_inherits i
+		JOIN pg_class c ON c.oid = i.inhrelid
+		WHERE i.inhparent = 'transactions'::regclass
+		ORDER BY c.relname`).Scan(&names).Error; err != nil {
+		return nil, err
+	}
+	// The default partition and partitions created by hand do not parse as a month
+	var months []time.Time
+	for _, name := range names {
+		month, err := time.Parse("2006_01", strings.TrimPrefix(name, transactionPartitionPrefix))
+		if err != nil || transactionPartitionName(month) != name {
+			continue
+		}
+		if month.Before(before) {
+			months = append(months, month)
+		}
+	}
+	return months, nil
+}
+
+func (r *transactionArchiveRepository) ReadPartition(ctx context.Context, month time.Time, each func(transaction *entities.Transaction) error, outTx *gorm.DB) error {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "TransactionArchiveRepository.ReadPartition")
+	defer span.End()
+	partition := transactionPartitionName(month)
+	// Reads and row locks of the parent table go on, only writes to the month wait
+	if err := outTx.Exec(fmt.Sprintf("LOCK TABLE %s IN SHARE MODE", partition)).Error; err != nil {
+		return err
+	}
+
+	rows, err := outTx.Table(partition).
+		Order("created_at, id").
+		Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var transaction entities.Transaction
+		if err := outTx.ScanRows(rows, &transaction); err != nil {
+			return err
+		}
+		if err := each(&transaction); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (r *transactionArchiveRepository) Archive(ctx context.Context, archive *entities.TransactionArchive, outTx *gorm.DB) error {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "TransactionArchiveRepository.Archive")
+	defer span.End()
+	partition := transactionPartitionName(archive.Month)
+
+	if err := outTx.Exec("SELECT pg_advisory_xact_lock(?)", reconciliationLockKey).Error; err != nil {
+		return err
+	}
+	if err := outTx.Create(archive).Error; err != nil {
+		return err
+	}
+	if err := outTx.Exec(`INSERT INTO transaction_archive_totals (month, operator_id, player_id, amount, transaction_count)
+		SELECT ?, t.operator_id, t.player_id, SUM(`+signedAmountSQL+`), COUNT(*)
+		FROM `+partition+` t
+		WHERE t.deleted_at IS NULL
+		GROUP BY t.operator_id, t.player_id`, archive.Month).Error; err != nil {
+		return err
+	}
+	// The file holds the soft deleted transactions too, only the totals leave them out
+	var count int64
+	if err := outTx.Raw("SELECT COUNT(*) FROM " + partition).Scan(&count).Error; err != nil {
+		return err
+	}
+	if count != archive.TransactionCount {
+		return fmt.Errorf("%w: %s has %d transactions, the archive %d", ErrArchiveMismatch, partition, count, archive.TransactionCount)
+	}
+
+	// Detaching waits for every transaction using the table. It comes before the
+	// balance update, so wallet transactions queue up on the table before they
+	// lock a player this transaction is about to update.
+	if err := outTx.Exec("SET LOCAL lock_timeout = '" + detachLockTimeout + "'").Error; err != nil {
+		return err
+	}
+	if err := outTx.Exec("ALTER TABLE transactions DETACH PARTITION " + partition).Error; err != nil {
+		return err
+	}
+	if err := outTx.Exec(`UPDATE players p SET initial_balance = p.initial_balance + a.amount
+		FROM transaction_archive_totals a
+		WHERE a.month = ? AND a.operator_id = p.operator_id AND a.player_id = p.id`, archive.Month).Error; err != nil {
+		return err
+	}
+	return outTx.Exec("DROP TABLE " + partition).Error
+}
+
+func (r *transactionArchiveRepository) GetArchives(ctx context.Context, outTx *gorm.DB) ([]*entities.TransactionArchive, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "TransactionArchiveRepository.GetArchives")
+	defer span.End()
+	var archives []*entities.TransactionArchive
+	if err := outTx.Order("month").Find(&archives).Error; err != nil {
+		return nil, err
+	}
+	return archives, nil
+}
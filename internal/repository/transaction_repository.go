@@ -1,22 +1,54 @@
 package repository
 
 import (
+	"context"
+	"errors"
 	"time"
 
 	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"github.com/jackc/pgx/v5/pgconn"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
+// ITransactionRepository scopes every query to a single operator, request and
+// round IDs are only unique within an operator
 type ITransactionRepository interface {
-	Create(transaction *entities.Transaction, outTx *gorm.DB) error
-	GetByReqID(reqID string, outTx *gorm.DB) (*entities.Transaction, error)
-	GetByRoundID(roundID string, outTx *gorm.DB) (*entities.Transaction, error)
-	GetByPlayerID(playerID string, outTx *gorm.DB) ([]*entities.Transaction, error)
-	GetByReqIDWithLock(reqID string, outTx *gorm.DB) (*entities.Transaction, error)
-	GetByRoundIDAndPlayerIDAndWalletIDWithLock(roundID, walletID string, transactionType entities.TransactionType, outTx *gorm.DB) (*entities.Transaction, error)
+	Create(ctx context.Context, transaction *entities.Transaction, outTx *gorm.DB) error
+	GetByReqID(ctx context.Context, operatorID, reqID string, outTx *gorm.DB) (*entities.Transaction, error)
+	GetByRoundID(ctx context.Context, operatorID, roundID string, outTx *gorm.DB) (*entities.Transaction, error)
+	GetByPlayerID(ctx context.Context, operatorID, playerID string, outTx *gorm.DB) ([]*entities.Transaction, error)
+	GetByReqIDWithLock(ctx context.Context, operatorID, reqID string, outTx *gorm.DB) (*entities.Transaction, error)
+	// GetByRoundIDAndPlayerIDAndWalletID is GetByRoundIDAndPlayerIDAndWalletIDWithLock without the row lock
+	GetByRoundIDAndPlayerIDAndWalletID(ctx context.Context, operatorID, roundID, walletID string, transactionType entities.TransactionType, outTx *gorm.DB) (*entities.Transaction, error)
+	GetByRoundIDAndPlayerIDAndWalletIDWithLock(ctx context.Context, operatorID, roundID, walletID string, transactionType entities.TransactionType, outTx *gorm.DB) (*entities.Transaction, error)
+	// GetByProviderAndPeriod returns the transactions the provider sent in [from, to)
+	GetByProviderAndPeriod(ctx context.Context, operatorID, providerID string, from, to time.Time, outTx *gorm.DB) ([]*entities.Transaction, error)
+	GetByReqIDs(ctx context.Context, operatorID string, reqIDs []string, outTx *gorm.DB) ([]*entities.Transaction, error)
+	GetByRoundIDs(ctx context.Context, operatorID string, roundIDs []string, outTx *gorm.DB) ([]*entities.Transaction, error)
 }
 
+// transactionReqIDConstraint keeps request IDs unique within an operator. On
+// Postgres it lives on transaction_request_ids, so it covers archived months too.
+const transactionReqIDConstraint = "transactions_operator_req_key"
+
+// pgUniqueViolation is the Postgres error code of a violated unique constraint
+const pgUniqueViolation = "23505"
+
+// IsDuplicateReqID reports whether storing a transaction failed because its
+// request ID was already used, by a concurrent request or by a transaction
+// that has been archived since
+func IsDuplicateReqID(err error) bool {
+	if errors.Is(err, gorm.ErrDuplicatedKey) || isSQLiteUniqueViolation(err) {
+		return true
+	}
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation && pgErr.ConstraintName == transactionReqIDConstraint
+}
+
+// idLookupChunkSize keeps IN lists well below the bind parameter limit of Postgres
+const idLookupChunkSize = 5000
+
 type transactionRepository struct {
 	IGormRepository
 }
@@ -27,73 +59,149 @@ func NewTransactionRepository(repository IGormRepository) ITransactionRepository
 	}
 }
 
-func (r *transactionRepository) Create(transaction *entities.Transaction, outTx *gorm.DB) error {
+func (r *transactionRepository) Create(ctx context.Context, transaction *entities.Transaction, outTx *gorm.DB) error {
 	if outTx == nil {
-		outTx = r.GetDB()
+		outTx = r.GetDB(ctx)
 	}
+	outTx, span := startSpan(ctx, outTx, "TransactionRepository.Create")
+	defer span.End()
 	transaction.CreatedAt = time.Now()
 	transaction.UpdatedAt = time.Now()
 	return outTx.Create(transaction).Error
 }
 
-func (r *transactionRepository) GetByReqID(reqID string, outTx *gorm.DB) (*entities.Transaction, error) {
+func (r *transactionRepository) GetByReqID(ctx context.Context, operatorID, reqID string, outTx *gorm.DB) (*entities.Transaction, error) {
 
 	if outTx == nil {
-		outTx = r.GetDB()
+		outTx = r.GetDB(ctx)
 	}
+	outTx, span := startSpan(ctx, outTx, "TransactionRepository.GetByReqID")
+	defer span.End()
 
 	var transaction entities.Transaction
-	if err := outTx.Where("req_id = ?", reqID).First(&transaction).Error; err != nil {
+	if err := outTx.Where("operator_id = ? AND req_id = ?", operatorID, reqID).First(&transaction).Error; err != nil {
 		return nil, err
 	}
 	return &transaction, nil
 }
 
-func (r *transactionRepository) GetByRoundID(roundID string, outTx *gorm.DB) (*entities.Transaction, error) {
+func (r *transactionRepository) GetByRoundID(ctx context.Context, operatorID, roundID string, outTx *gorm.DB) (*entities.Transaction, error) {
 	if outTx == nil {
-		outTx = r.GetDB()
+		outTx = r.GetDB(ctx)
 	}
+	outTx, span := startSpan(ctx, outTx, "TransactionRepository.GetByRoundID")
+	defer span.End()
 	var transaction entities.Transaction
-	if err := outTx.Where("round_id = ?", roundID).First(&transaction).Error; err != nil {
+	if err := outTx.Where("operator_id = ? AND round_id = ?", operatorID, roundID).First(&transaction).Error; err != nil {
 		return nil, err
 	}
 	return &transaction, nil
 }
 
-func (r *transactionRepository) GetByPlayerID(playerID string, outTx *gorm.DB) ([]*entities.Transaction, error) {
+func (r *transactionRepository) GetByPlayerID(ctx context.Context, operatorID, playerID string, outTx *gorm.DB) ([]*entities.Transaction, error) {
 	if outTx == nil {
-		outTx = r.GetDB()
+		outTx = r.GetPlayerReadDB(ctx, operatorID, playerID)
 	}
+	outTx, span := startSpan(ctx, outTx, "TransactionRepository.GetByPlayerID")
+	defer span.End()
 	var transactions []*entities.Transaction
-	if err := outTx.Where("player_id = ?", playerID).Find(&transactions).Error; err != nil {
+	if err := outTx.Where("operator_id = ? AND player_id = ?", operatorID, playerID).Find(&transactions).Error; err != nil {
 		return nil, err
 	}
 	return transactions, nil
 }
 
-func (r *transactionRepository) GetByReqIDWithLock(reqID string, outTx *gorm.DB) (*entities.Transaction, error) {
+func (r *transactionRepository) GetByReqIDWithLock(ctx context.Context, operatorID, reqID string, outTx *gorm.DB) (*entities.Transaction, error) {
 	if outTx == nil {
-		outTx = r.GetDB()
+		outTx = r.GetDB(ctx)
 	}
+	outTx, span := startSpan(ctx, outTx, "TransactionRepository.GetByReqIDWithLock")
+	defer span.End()
+	defer observeLockWait("transaction_by_req_id", time.Now())
 	var transaction entities.Transaction
 	if err := outTx.Clauses(clause.Locking{Strength: "UPDATE"}).
-		Where("req_id = ?", reqID).
+		Where("operator_id = ? AND req_id = ?", operatorID, reqID).
+		First(&transaction).Error; err != nil {
+		return nil, err
+	}
+	return &transaction, nil
+}
+
+func (r *transactionRepository) GetByRoundIDAndPlayerIDAndWalletID(ctx context.Context, operatorID, roundID, walletID string, transactionType entities.TransactionType, outTx *gorm.DB) (*entities.Transaction, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "TransactionRepository.GetByRoundIDAndPlayerIDAndWalletID")
+	defer span.End()
+	var transaction entities.Transaction
+	if err := outTx.Where("operator_id = ? AND round_id = ? AND wallet_id = ? AND type = ?",
+		operatorID, roundID, walletID, transactionType).
 		First(&transaction).Error; err != nil {
 		return nil, err
 	}
 	return &transaction, nil
 }
 
-func (r *transactionRepository) GetByRoundIDAndPlayerIDAndWalletIDWithLock(roundID, walletID string, transactionType entities.TransactionType, outTx *gorm.DB) (*entities.Transaction, error) {
+func (r *transactionRepository) GetByRoundIDAndPlayerIDAndWalletIDWithLock(ctx context.Context, operatorID, roundID, walletID string, transactionType entities.TransactionType, outTx *gorm.DB) (*entities.Transaction, error) {
 	if outTx == nil {
-		outTx = r.GetDB()
+		outTx = r.GetDB(ctx)
 	}
+	outTx, span := startSpan(ctx, outTx, "TransactionRepository.GetByRoundIDAndPlayerIDAndWalletIDWithLock")
+	defer span.End()
+	defer observeLockWait("transaction_by_round", time.Now())
 	var transaction entities.Transaction
 	if err := outTx.Clauses(clause.Locking{Strength: "UPDATE"}).
-		Where("round_id = ? AND wallet_id = ? AND type = ?",
-			roundID, walletID, transactionType).
+		Where("operator_id = ? AND round_id = ? AND wallet_id = ? AND type = ?",
+			operatorID, roundID, walletID, transactionType).
 		First(&transaction).Error; err != nil {
 		return nil, err
 	}
 	return &transaction, nil
 }
+
+func (r *transactionRepository) GetByProviderAndPeriod(ctx context.Context, operatorID, providerID string, from, to time.Time, outTx *gorm.DB) ([]*entities.Transaction, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "TransactionRepository.GetByProviderAndPeriod")
+	defer span.End()
+	var transactions []*entities.Transaction
+	if err := outTx.Where("operator_id = ? AND provider_id = ? AND created_at >= ? AND created_at < ?",
+		operatorID, providerID, from, to).
+		Order("id").
+		Find(&transactions).Error; err != nil {
+		return nil, err
+	}
+	return transactions, nil
+}
+
+func (r *transactionRepository) GetByReqIDs(ctx context.Context, operatorID string, reqIDs []string, outTx *gorm.DB) ([]*entities.Transaction, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "TransactionRepository.GetByReqIDs")
+	defer span.End()
+	return findInChunks(outTx, "operator_id = ? AND req_id IN ?", operatorID, reqIDs)
+}
+
+func (r *transactionRepository) GetByRoundIDs(ctx context.Context, operatorID string, roundIDs []string, outTx *gorm.DB) ([]*entities.Transaction, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "TransactionRepository.GetByRoundIDs")
+	defer span.End()
+	return findInChunks(outTx, "operator_id = ? AND round_id IN ?", operatorID, roundIDs)
+}
+
+func findInChunks(outTx *gorm.DB, query, operatorID string, values []string) ([]*entities.Transaction, error) {
+	var transactions []*entities.Transaction
+	for start := 0; start < len(values); start += idLookupChunkSize {
+		end := min(start+idLookupChunkSize, len(values))
+		var chunk []*entities.Transaction
+		if err := outTx.Where(query, operatorID, values[start:end]).Find(&chunk).Error; err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, chunk...)
+	}
+	return transactions, nil
+}
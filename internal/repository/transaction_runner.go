@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/metrics"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	IsolationReadCommitted  = "READ COMMITTED"
+	IsolationRepeatableRead = "REPEATABLE READ"
+	IsolationSerializable   = "SERIALIZABLE"
+)
+
+// Postgres error codes after which the whole transaction can simply be run again
+const (
+	pgSerializationFailure = "40001"
+	pgDeadlockDetected     = "40P01"
+)
+
+// TxFunc is a unit of work. It may run more than once, so it must not have side
+// effects outside of tx and should return errors of tx queries unchanged or wrapped.
+type TxFunc func(tx *gorm.DB) error
+
+type ITransactionRunner interface {
+	// Run executes fn in a transaction and commits it when fn returns nil.
+	// Serialization failures and deadlocks are retried with jittered backoff.
+	Run(ctx context.Context, fn TxFunc) error
+}
+
+type TransactionRunnerConfig struct {
+	// IsolationLevel is one of the Isolation constants, empty means the database default
+	IsolationLevel string
+	// MaxAttempts includes the first attempt
+	MaxAttempts int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+}
+
+type transactionRunner struct {
+	gormRepository IGormRepository
+	config         TransactionRunnerConfig
+}
+
+func NewTransactionRunner(gormRepository IGormRepository, config TransactionRunnerConfig) ITransactionRunner {
+	if config.MaxAttempts < 1 {
+		config.MaxAttempts = 1
+	}
+	return &transactionRunner{
+		gormRepository: gormRepository,
+		config:         config,
+	}
+}
+
+// IsValidIsolationLevel reports whether level can be passed to SET TRANSACTION ISOLATION LEVEL
+func IsValidIsolationLevel(level string) bool {
+	switch level {
+	case "", IsolationReadCommitted, IsolationRepeatableRead, IsolationSerializable:
+		return true
+	}
+	return false
+}
+
+func (r *transactionRunner) Run(ctx context.Context, fn TxFunc) error {
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = r.runOnce(ctx, fn)
+		reason, retryable := retryReason(err)
+		if !retryable {
+			return err
+		}
+		if attempt >= r.config.MaxAttempts {
+			metrics.DBTransactionRetriesExhausted.WithLabelValues(reason).Inc()
+			zap.L().Warn("Giving up on transaction after retryable errors",
+				zap.String("reason", reason),
+				zap.Int("attempts", attempt),
+				zap.Error(err))
+			return err
+		}
+
+		metrics.DBTransactionRetries.WithLabelValues(reason).Inc()
+		zap.L().Debug("Retrying aborted transaction",
+			zap.String("reason", reason),
+			zap.Int("attempt", attempt))
+
+		timer := time.NewTimer(r.backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+func (r *transactionRunner) runOnce(ctx context.Context, fn TxFunc) error {
+	var (
+		tx  *gorm.DB
+		err error
+	)
+	if r.config.IsolationLevel == "" {
+		tx, err = r.gormRepository.StartTransaction(ctx)
+	} else {
+		tx, err = r.gormRepository.StartTransactionWithIsolation(ctx, r.config.IsolationLevel)
+	}
+	if err != nil {
+		return err
+	}
+
+	// A panicking unit of work must not leave the transaction and its row locks open
+	defer func() {
+		if p := recover(); p != nil {
+			r.gormRepository.RollbackTransaction(tx)
+			panic(p)
+		}
+	}()
+
+	return r.gormRepository.FinishTransaction(tx, fn(tx))
+}
+
+// backoff doubles with every attempt up to MaxBackoff and picks a random
+// duration below it, so transactions that collided do not collide again
+func (r *transactionRunner) backoff(attempt int) time.Duration {
+	backoff := r.config.BaseBackoff << (attempt - 1)
+	if backoff <= 0 || (r.config.MaxBackoff > 0 && backoff > r.config.MaxBackoff) {
+		backoff = r.config.MaxBackoff
+	}
+	if backoff <= 0 {
+		return 0
+	}
+	return backoff/2 + rand.N(backoff/2+1)
+}
+
+// retryReason classifies errors Postgres reports when it aborts a transaction
+// that would succeed if it ran again. On SQLite that is a transaction that
+// could not take the write lock within the busy timeout. Optimistic balance
+// updates that lost to another transaction are run again as well.
+func retryReason(err error) (string, bool) {
+	if errors.Is(err, ErrVersionConflict) {
+		return "version_conflict", true
+	}
+	if isSQLiteBusy(err) {
+		return "database_busy", true
+	}
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return "", false
+	}
+	switch pgErr.Code {
+	case pgSerializationFailure:
+		return "serialization_failure", true
+	case pgDeadlockDetected:
+		return "deadlock_detected", true
+	}
+	return "", false
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sqliteBusyError returns the error of a transaction that could not take the
+// write lock held by another connection
+func sqliteBusyError(t *testing.T) error {
+	t.Helper()
+	query := url.Values{}
+	query.Add("_pragma", "busy_timeout(0)")
+	query.Set("_txlock", "immediate")
+	dsn := "file:" + filepath.Join(t.TempDir(), "busy.db") + "?" + query.Encode()
+
+	open := func() *sql.DB {
+		db, err := sql.Open(sqlite.DriverName, dsn)
+		require.NoError(t, err)
+		t.Cleanup(func() { db.Close() })
+		return db
+	}
+	holder, err := open().Begin()
+	require.NoError(t, err)
+	defer holder.Rollback()
+
+	_, err = open().Begin()
+	require.Error(t, err)
+	return err
+}
+
+func TestRetryReason(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		reason    string
+		retryable bool
+	}{
+		{name: "serialization failure", err: &pgconn.PgError{Code: "40001"}, reason: "serialization_failure", retryable: true},
+		{name: "deadlock", err: &pgconn.PgError{Code: "40P01"}, reason: "deadlock_detected", retryable: true},
+		{name: "wrapped deadlock", err: fmt.Errorf("debit: %w", &pgconn.PgError{Code: "40P01"}), reason: "deadlock_detected", retryable: true},
+		{name: "version conflict", err: fmt.Errorf("update balance: %w", ErrVersionConflict), reason: "version_conflict", retryable: true},
+		{name: "sqlite busy", err: sqliteBusyError(t), reason: "database_busy", retryable: true},
+		{name: "unique violation", err: &pgconn.PgError{Code: "23505"}},
+		{name: "other error", err: errors.New("connection refused")},
+		{name: "no error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, retryable := retryReason(tt.err)
+			assert.Equal(t, tt.reason, reason)
+			assert.Equal(t, tt.retryable, retryable)
+		})
+	}
+}
+
+func TestTransactionRunnerBackoff(t *testing.T) {
+	runner := &transactionRunner{config: TransactionRunnerConfig{
+		BaseBackoff: 10 * time.Millisecond,
+		MaxBackoff:  50 * time.Millisecond,
+	}}
+	// The upper bound doubles per attempt up to MaxBackoff, the jitter keeps
+	// every backoff within the upper half of it
+	for attempt, bound := range map[int]time.Duration{1: 10 * time.Millisecond, 2: 20 * time.Millisecond, 3: 40 * time.Millisecond, 4: 50 * time.Millisecond, 40: 50 * time.Millisecond} {
+		seen := make(map[time.Duration]bool)
+		for range 200 {
+			backoff := runner.backoff(attempt)
+			assert.GreaterOrEqual(t, backoff, bound/2, "attempt %d", attempt)
+			assert.LessOrEqual(t, backoff, bound, "attempt %d", attempt)
+			seen[backoff] = true
+		}
+		assert.Greater(t, len(seen), 1, "attempt %d is jittered", attempt)
+	}
+
+	assert.Zero(t, (&transactionRunner{}).backoff(1))
+}
@@ -0,0 +1,133 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/metrics"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/repository"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/testutil"
+	"github.com/jackc/pgx/v5/pgconn"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+var errSerialization = &pgconn.PgError{Code: "40001"}
+
+// insertNonce writes a row in tx, so the test can tell committed attempts from rolled back ones
+func insertNonce(tx *gorm.DB, nonce string) error {
+	return tx.Exec("INSERT INTO request_nonces (provider_id, nonce, expires_at) VALUES (?, ?, ?)", "runner", nonce, time.Now()).Error
+}
+
+func countNonces(t *testing.T, db *gorm.DB) int64 {
+	t.Helper()
+	var count int64
+	require.NoError(t, db.Raw("SELECT COUNT(*) FROM request_nonces WHERE provider_id = ?", "runner").Scan(&count).Error)
+	return count
+}
+
+func newTestRunner(db *gorm.DB, maxAttempts int) repository.ITransactionRunner {
+	return repository.NewTransactionRunner(repository.NewGormRepository(db), repository.TransactionRunnerConfig{
+		MaxAttempts: maxAttempts,
+		BaseBackoff: time.Millisecond,
+		MaxBackoff:  5 * time.Millisecond,
+	})
+}
+
+func TestTransactionRunnerRetries(t *testing.T) {
+	db := testutil.OpenSQLite(t)
+	retries := promtestutil.ToFloat64(metrics.DBTransactionRetries.WithLabelValues("serialization_failure"))
+
+	attempts := 0
+	err := newTestRunner(db, 5).Run(context.Background(), func(tx *gorm.DB) error {
+		attempts++
+		if err := insertNonce(tx, "retried"); err != nil {
+			return err
+		}
+		if attempts < 3 {
+			return errSerialization
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, 2.0, promtestutil.ToFloat64(metrics.DBTransactionRetries.WithLabelValues("serialization_failure"))-retries)
+	// The failed attempts were rolled back, only the last one was committed
+	assert.Equal(t, int64(1), countNonces(t, db))
+}
+
+func TestTransactionRunnerGivesUp(t *testing.T) {
+	db := testutil.OpenSQLite(t)
+	exhausted := promtestutil.ToFloat64(metrics.DBTransactionRetriesExhausted.WithLabelValues("serialization_failure"))
+
+	attempts := 0
+	err := newTestRunner(db, 3).Run(context.Background(), func(tx *gorm.DB) error {
+		attempts++
+		if err := insertNonce(tx, "exhausted"); err != nil {
+			return err
+		}
+		return errSerialization
+	})
+	assert.Same(t, errSerialization, err)
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, 1.0, promtestutil.ToFloat64(metrics.DBTransactionRetriesExhausted.WithLabelValues("serialization_failure"))-exhausted)
+	assert.Zero(t, countNonces(t, db))
+}
+
+func TestTransactionRunnerDoesNotRetryOtherErrors(t *testing.T) {
+	db := testutil.OpenSQLite(t)
+	errInsufficient := errors.New("insufficient balance")
+
+	attempts := 0
+	err := newTestRunner(db, 5).Run(context.Background(), func(tx *gorm.DB) error {
+		attempts++
+		return errInsufficient
+	})
+	assert.Same(t, errInsufficient, err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestTransactionRunnerStopsOnCancel(t *testing.T) {
+	db := testutil.OpenSQLite(t)
+	runner := repository.NewTransactionRunner(repository.NewGormRepository(db), repository.TransactionRunnerConfig{
+		MaxAttempts: 5,
+		BaseBackoff: time.Hour,
+		MaxBackoff:  time.Hour,
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+
+	attempts := 0
+	err := runner.Run(ctx, func(tx *gorm.DB) error {
+		attempts++
+		cancel()
+		return errSerialization
+	})
+	assert.Same(t, errSerialization, err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestTransactionRunnerRollsBackOnPanic(t *testing.T) {
+	db := testutil.OpenSQLite(t)
+	runner := newTestRunner(db, 1)
+
+	assert.PanicsWithValue(t, "boom", func() {
+		_ = runner.Run(context.Background(), func(tx *gorm.DB) error {
+			require.NoError(t, insertNonce(tx, "panicked"))
+			panic("boom")
+		})
+	})
+	assert.Zero(t, countNonces(t, db))
+
+	// The write lock was released with the rollback, a new transaction does
+	// not have to wait for the busy timeout
+	start := time.Now()
+	require.NoError(t, runner.Run(context.Background(), func(tx *gorm.DB) error {
+		return insertNonce(tx, "after-panic")
+	}))
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, int64(1), countNonces(t, db))
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// Repositories are bound to the transaction of a unit of work, their methods
+// can be called with a nil outTx and still run inside the transaction
+type Repositories struct {
+	Players             IPlayerRepository
+	Transactions        ITransactionRepository
+	Operators           IOperatorRepository
+	APIKeys             IAPIKeyRepository
+	Outbox              IOutboxRepository
+	Webhooks            IWebhookRepository
+	Notifications       INotificationRepository
+	Reconciliation      IReconciliationRepository
+	Reports             IReportRepository
+	BalanceCheckpoints  IBalanceCheckpointRepository
+	TransactionArchives ITransactionArchiveRepository
+}
+
+type UnitOfWorkFunc func(repos Repositories) error
+
+// IUnitOfWork owns begin, commit and rollback of a transaction. Do commits when
+// fn returns nil and returns the commit error, rolls back when fn returns an
+// error or panics, and retries fn as a whole when Postgres aborts the
+// transaction, so fn must not have side effects outside of repos.
+type IUnitOfWork interface {
+	Do(ctx context.Context, fn UnitOfWorkFunc) error
+}
+
+type unitOfWork struct {
+	runner ITransactionRunner
+	// replicas is nil when no replicas are configured
+	replicas *ReplicaSet
+}
+
+func NewUnitOfWork(runner ITransactionRunner) IUnitOfWork {
+	return &unitOfWork{
+		runner: runner,
+	}
+}
+
+// NewUnitOfWorkWithReplicas keeps the reads of the players written in a unit
+// of work on the primary for the read-your-writes window
+func NewUnitOfWorkWithReplicas(runner ITransactionRunner, replicas *ReplicaSet) IUnitOfWork {
+	return &unitOfWork{
+		runner:   runner,
+		replicas: replicas,
+	}
+}
+
+func (u *unitOfWork) Do(ctx context.Context, fn UnitOfWorkFunc) error {
+	if u.replicas == nil {
+		return u.runner.Run(ctx, func(tx *gorm.DB) error {
+			return fn(newRepositories(NewGormRepository(tx)))
+		})
+	}
+
+	var writes []playerWrite
+	err := u.runner.Run(ctx, func(tx *gorm.DB) error {
+		writes = writes[:0]
+		return fn(newRepositories(&gormRepository{db: tx, writes: &writes}))
+	})
+	// The window starts after the commit, a failed commit may still have been
+	// applied, so the writes are recorded either way
+	for _, write := range writes {
+		u.replicas.RecordWrite(write.operatorID, write.playerID)
+	}
+	return err
+}
+
+func newRepositories(gormRepository IGormRepository) Repositories {
+	return Repositories{
+		Players:             NewPlayerRepository(gormRepository),
+		Transactions:        NewTransactionRepository(gormRepository),
+		Operators:           NewOperatorRepository(gormRepository),
+		APIKeys:             NewAPIKeyRepository(gormRepository),
+		Outbox:              NewOutboxRepository(gormRepository),
+		Webhooks:            NewWebhookRepository(gormRepository),
+		Notifications:       NewNotificationRepository(gormRepository),
+		Reconciliation:      NewReconciliationRepository(gormRepository),
+		Reports:             NewReportRepository(gormRepository),
+		BalanceCheckpoints:  NewBalanceCheckpointRepository(gormRepository),
+		TransactionArchives: NewTransactionArchiveRepository(gormRepository),
+	}
+}
@@ -0,0 +1,260 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"gorm.io/gorm"
+)
+
+// ErrWebhookLeaseLost is returned when a delivery was claimed again or
+// redelivered while its attempt was being sent
+var ErrWebhookLeaseLost = errors.New("webhook delivery lease was lost")
+
+type IWebhookRepository interface {
+	Create(ctx context.Context, webhook *entities.Webhook, outTx *gorm.DB) error
+	GetByID(ctx context.Context, operatorID string, id uint64, outTx *gorm.DB) (*entities.Webhook, error)
+	GetAll(ctx context.Context, operatorID string, outTx *gorm.DB) ([]*entities.Webhook, error)
+	Update(ctx context.Context, webhook *entities.Webhook, outTx *gorm.DB) error
+	// Delete removes the webhook together with its pending deliveries
+	Delete(ctx context.Context, operatorID string, id uint64, outTx *gorm.DB) error
+
+	// EnqueueDeliveries creates a pending delivery for every active webhook of the
+	// operator subscribed to eventType and returns how many were created
+	EnqueueDeliveries(ctx context.Context, operatorID, eventType string, payload []byte, outTx *gorm.DB) (int64, error)
+	GetDeliveries(ctx context.Context, operatorID string, webhookID uint64, limit int, outTx *gorm.DB) ([]*entities.WebhookDelivery, error)
+	// GetDeliveryByID loads the delivery with its attempt log
+	GetDeliveryByID(ctx context.Context, operatorID string, id uint64, outTx *gorm.DB) (*entities.WebhookDelivery, error)
+	// ClaimDueDeliveries locks due deliveries, skipping rows another dispatcher
+	// holds, and pushes their next attempt to leaseUntil so they are not picked
+	// up again while being sent. It has to run in a transaction.
+	ClaimDueDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int, outTx *gorm.DB) ([]*entities.WebhookDelivery, error)
+	// RecordAttempt stores the attempt and the resulting state of the delivery
+	// as long as its next attempt is still at leaseUntil, the lease it was
+	// claimed with. Otherwise nothing is stored and ErrWebhookLeaseLost is returned.
+	RecordAttempt(ctx context.Context, delivery *entities.WebhookDelivery, attempt *entities.WebhookDeliveryAttempt, leaseUntil time.Time, outTx *gorm.DB) error
+	Redeliver(ctx context.Context, operatorID string, id uint64, at time.Time, outTx *gorm.DB) error
+}
+
+type webhookRepository struct {
+	IGormRepository
+}
+
+func NewWebhookRepository(repository IGormRepository) IWebhookRepository {
+	return &webhookRepository{
+		IGormRepository: repository,
+	}
+}
+
+func (r *webhookRepository) Create(ctx context.Context, webhook *entities.Webhook, outTx *gorm.DB) error {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "WebhookRepository.Create")
+	defer span.End()
+	webhook.CreatedAt = time.Now()
+	webhook.UpdatedAt = time.Now()
+	return outTx.Create(webhook).Error
+}
+
+func (r *webhookRepository) GetByID(ctx context.Context, operatorID string, id uint64, outTx *gorm.DB) (*entities.Webhook, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "WebhookRepository.GetByID")
+	defer span.End()
+	var webhook entities.Webhook
+	if err := outTx.First(&webhook, "operator_id = ? AND id = ?", operatorID, id).Error; err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (r *webhookRepository) GetAll(ctx context.Context, operatorID string, outTx *gorm.DB) ([]*entities.Webhook, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "WebhookRepository.GetAll")
+	defer span.End()
+	var webhooks []*entities.Webhook
+	if err := outTx.Where("operator_id = ?", operatorID).Order("id").Find(&webhooks).Error; err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+func (r *webhookRepository) Update(ctx context.Context, webhook *entities.Webhook, outTx *gorm.DB) error {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "WebhookRepository.Update")
+	defer span.End()
+	webhook.UpdatedAt = time.Now()
+	return outTx.Model(webhook).
+		Where("operator_id = ?", webhook.OperatorID).
+		Select("url", "event_types", "active", "updated_at").
+		Updates(webhook).Error
+}
+
+func (r *webhookRepository) Delete(ctx context.Context, operatorID string, id uint64, outTx *gorm.DB) error {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "WebhookRepository.Delete")
+	defer span.End()
+	if err := outTx.Where("operator_id = ? AND webhook_id = ? AND status = ?", operatorID, id, entities.WebhookDeliveryStatusPending).
+		Delete(&entities.WebhookDelivery{}).Error; err != nil {
+		return err
+	}
+	return outTx.Delete(&entities.Webhook{}, "operator_id = ? AND id = ?", operatorID, id).Error
+}
+
+func (r *webhookRepository) EnqueueDeliveries(ctx context.Context, operatorID, eventType string, payload []byte, outTx *gorm.DB) (int64, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "WebhookRepository.EnqueueDeliveries")
+	defer span.End()
+	now := time.Now()
+	if isSQLite(outTx) {
+		// SQLite has no containment operators, the subscribed event types are
+		// searched with json_each. The payload is passed as bytes to be stored
+		// as a BLOB, which scans back into json.RawMessage.
+		result := outTx.Exec(`
+			INSERT INTO webhook_deliveries (webhook_id, operator_id, event_type, payload, status, attempts, next_attempt_at, created_at, updated_at)
+			SELECT id, operator_id, ?, ?, ?, 0, ?, ?, ?
+			FROM webhooks
+			WHERE operator_id = ? AND active AND deleted_at IS NULL
+			AND EXISTS (SELECT 1 FROM json_each(webhooks.event_types) WHERE json_each.value = ?)`,
+			eventType, payload, entities.WebhookDeliveryStatusPending, now, now, now,
+			operatorID, eventType)
+		return result.RowsAffected, result.Error
+	}
+	eventTypes, err := json.Marshal([]string{eventType})
+	if err != nil {
+		return 0, err
+	}
+	result := outTx.Exec(`
+		INSERT INTO webhook_deliveries (webhook_id, operator_id, event_type, payload, status, attempts, next_attempt_at, created_at, updated_at)
+		SELECT id, operator_id, ?, ?::jsonb, ?, 0, ?::timestamptz, ?::timestamptz, ?::timestamptz
+		FROM webhooks
+		WHERE operator_id = ? AND active AND deleted_at IS NULL AND event_types @> ?::jsonb`,
+		eventType, string(payload), entities.WebhookDeliveryStatusPending, now, now, now,
+		operatorID, string(eventTypes))
+	return result.RowsAffected, result.Error
+}
+
+func (r *webhookRepository) GetDeliveries(ctx context.Context, operatorID string, webhookID uint64, limit int, outTx *gorm.DB) ([]*entities.WebhookDelivery, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "WebhookRepository.GetDeliveries")
+	defer span.End()
+	var deliveries []*entities.WebhookDelivery
+	if err := outTx.Where("operator_id = ? AND webhook_id = ?", operatorID, webhookID).
+		Order("id DESC").
+		Limit(limit).
+		Find(&deliveries).Error; err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (r *webhookRepository) GetDeliveryByID(ctx context.Context, operatorID string, id uint64, outTx *gorm.DB) (*entities.WebhookDelivery, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "WebhookRepository.GetDeliveryByID")
+	defer span.End()
+	var delivery entities.WebhookDelivery
+	if err := outTx.Preload("AttemptLog", func(db *gorm.DB) *gorm.DB {
+		return db.Order("attempt")
+	}).First(&delivery, "operator_id = ? AND id = ?", operatorID, id).Error; err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (r *webhookRepository) ClaimDueDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int, outTx *gorm.DB) ([]*entities.WebhookDelivery, error) {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "WebhookRepository.ClaimDueDeliveries")
+	defer span.End()
+	var ids []uint64
+	if err := outTx.Raw(`
+		SELECT id FROM webhook_deliveries
+		WHERE status = ? AND next_attempt_at <= ?
+		ORDER BY next_attempt_at, id
+		LIMIT ?
+		`+skipLockedSQL(outTx), entities.WebhookDeliveryStatusPending, now, limit).Scan(&ids).Error; err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	if err := outTx.Model(&entities.WebhookDelivery{}).
+		Where("id IN ?", ids).
+		Update("next_attempt_at", leaseUntil).Error; err != nil {
+		return nil, err
+	}
+
+	var deliveries []*entities.WebhookDelivery
+	if err := outTx.Preload("Webhook").Where("id IN ?", ids).Order("id").Find(&deliveries).Error; err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (r *webhookRepository) RecordAttempt(ctx context.Context, delivery *entities.WebhookDelivery, attempt *entities.WebhookDeliveryAttempt, leaseUntil time.Time, outTx *gorm.DB) error {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "WebhookRepository.RecordAttempt")
+	defer span.End()
+	delivery.UpdatedAt = time.Now()
+	result := outTx.Model(&entities.WebhookDelivery{}).
+		Where("id = ? AND status = ? AND next_attempt_at = ?", delivery.ID, entities.WebhookDeliveryStatusPending, leaseUntil).
+		Updates(map[string]interface{}{
+			"status":           delivery.Status,
+			"attempts":         delivery.Attempts,
+			"last_status_code": delivery.LastStatusCode,
+			"last_error":       delivery.LastError,
+			"next_attempt_at":  delivery.NextAttemptAt,
+			"delivered_at":     delivery.DeliveredAt,
+			"updated_at":       delivery.UpdatedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrWebhookLeaseLost
+	}
+	return outTx.Create(attempt).Error
+}
+
+func (r *webhookRepository) Redeliver(ctx context.Context, operatorID string, id uint64, at time.Time, outTx *gorm.DB) error {
+	if outTx == nil {
+		outTx = r.GetDB(ctx)
+	}
+	outTx, span := startSpan(ctx, outTx, "WebhookRepository.Redeliver")
+	defer span.End()
+	result := outTx.Model(&entities.WebhookDelivery{}).
+		Where("operator_id = ? AND id = ?", operatorID, id).
+		Updates(map[string]interface{}{
+			"status":          entities.WebhookDeliveryStatusPending,
+			"next_attempt_at": at,
+			"updated_at":      at,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -27,17 +27,7 @@ func SeedPlayers(db *gorm.DB) error {
 		return nil
 	}
 
-	samplePlayers := []entities.Player{}
-	for i := 0; i < SamplePlayerCount; i++ {
-		samplePlayers = append(samplePlayers, entities.Player{
-			ID:       fmt.Sprintf("player%d", i+1),
-			WalletID: fmt.Sprintf("wallet%d", i+1),
-			Balance:  100000.00,
-			Currency: "INR",
-		})
-	}
-
-	// Add players
+	samplePlayers := SamplePlayers()
 	for i := range samplePlayers {
 		samplePlayers[i].CreatedAt = time.Now()
 		samplePlayers[i].UpdatedAt = time.Now()
@@ -50,3 +40,20 @@ func SeedPlayers(db *gorm.DB) error {
 	log.Printf("%d sample players successfully added", len(samplePlayers))
 	return nil
 }
+
+// SamplePlayers returns the players SeedPlayers adds to an empty database
+func SamplePlayers() []entities.Player {
+	samplePlayers := make([]entities.Player, 0, SamplePlayerCount)
+	for i := 0; i < SamplePlayerCount; i++ {
+		samplePlayers = append(samplePlayers, entities.Player{
+			OperatorID:     entities.DefaultOperatorID,
+			ID:             fmt.Sprintf("player%d", i+1),
+			WalletID:       fmt.Sprintf("wallet%d", i+1),
+			Balance:        100000.00,
+			InitialBalance: 100000.00,
+			Currency:       "INR",
+			Status:         entities.PlayerStatusActive,
+		})
+	}
+	return samplePlayers
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/repository"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	apiKeyPrefix      = "cwk_"
+	apiKeyRandomBytes = 24
+	apiKeyPrefixLen   = 12
+)
+
+var (
+	ErrAPIKeyNotFound = errors.New("api key not found")
+	ErrAPIKeyRevoked  = errors.New("api key revoked")
+	ErrInvalidRole    = errors.New("invalid api key role")
+)
+
+type IAPIKeyService interface {
+	Authenticate(ctx context.Context, rawKey string) (*entities.APIKey, error)
+	IssueKey(ctx context.Context, operatorID string, name string, role entities.APIKeyRole) (*entities.APIKey, string, error)
+	EnsureKey(ctx context.Context, operatorID string, name string, rawKey string, role entities.APIKeyRole) error
+	RevokeKey(ctx context.Context, operatorID string, id uint64) error
+	ListKeys(ctx context.Context, operatorID string) ([]*entities.APIKey, error)
+}
+
+type APIKeyService struct {
+	apiKeyRepo repository.IAPIKeyRepository
+}
+
+func NewAPIKeyService(apiKeyRepo repository.IAPIKeyRepository) IAPIKeyService {
+	return &APIKeyService{
+		apiKeyRepo: apiKeyRepo,
+	}
+}
+
+// HashAPIKey returns the hex encoded SHA-256 of the key, which is what gets stored in the database
+func HashAPIKey(rawKey string) string {
+	sum := sha256.Sum256([]byte(rawKey))
+	return hex.EncodeToString(sum[:])
+}
+
+func (s *APIKeyService) Authenticate(ctx context.Context, rawKey string) (*entities.APIKey, error) {
+	apiKey, err := s.apiKeyRepo.GetByHash(ctx, HashAPIKey(rawKey), nil)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAPIKeyNotFound
+		}
+		zap.L().Error("Error while looking up api key", zap.Error(err))
+		return nil, err
+	}
+	if apiKey.IsRevoked() {
+		return nil, ErrAPIKeyRevoked
+	}
+	return apiKey, nil
+}
+
+func (s *APIKeyService) IssueKey(ctx context.Context, operatorID string, name string, role entities.APIKeyRole) (*entities.APIKey, string, error) {
+	if !role.IsValid() {
+		return nil, "", ErrInvalidRole
+	}
+
+	randomBytes := make([]byte, apiKeyRandomBytes)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return nil, "", fmt.Errorf("api key generation failed: %w", err)
+	}
+	rawKey := apiKeyPrefix + hex.EncodeToString(randomBytes)
+
+	apiKey, err := s.create(ctx, operatorID, name, rawKey, role)
+	if err != nil {
+		return nil, "", err
+	}
+
+	zap.L().Info("API key issued",
+		zap.String("operator_id", operatorID),
+		zap.Uint64("api_key_id", apiKey.ID),
+		zap.String("name", name),
+		zap.String("role", string(role)))
+	return apiKey, rawKey, nil
+}
+
+// EnsureKey stores a key chosen by the operator if it does not exist yet. It is
+// used to bootstrap the first admin key from configuration.
+func (s *APIKeyService) EnsureKey(ctx context.Context, operatorID string, name string, rawKey string, role entities.APIKeyRole) error {
+	if !role.IsValid() {
+		return ErrInvalidRole
+	}
+
+	_, err := s.apiKeyRepo.GetByHash(ctx, HashAPIKey(rawKey), nil)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	apiKey, err := s.create(ctx, operatorID, name, rawKey, role)
+	if err != nil {
+		return err
+	}
+
+	zap.L().Info("Bootstrap API key created",
+		zap.String("operator_id", operatorID),
+		zap.Uint64("api_key_id", apiKey.ID),
+		zap.String("name", name),
+		zap.String("role", string(role)))
+	return nil
+}
+
+func (s *APIKeyService) RevokeKey(ctx context.Context, operatorID string, id uint64) error {
+	apiKey, err := s.apiKeyRepo.GetByID(ctx, operatorID, id, nil)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrAPIKeyNotFound
+		}
+		return err
+	}
+	if apiKey.IsRevoked() {
+		return nil
+	}
+
+	if err := s.apiKeyRepo.Revoke(ctx, operatorID, id, time.Now(), nil); err != nil {
+		zap.L().Error("Error while revoking api key",
+			zap.Uint64("api_key_id", id),
+			zap.Error(err))
+		return err
+	}
+
+	zap.L().Info("API key revoked",
+		zap.String("operator_id", operatorID),
+		zap.Uint64("api_key_id", id))
+	return nil
+}
+
+func (s *APIKeyService) ListKeys(ctx context.Context, operatorID string) ([]*entities.APIKey, error) {
+	return s.apiKeyRepo.GetAll(ctx, operatorID, nil)
+}
+
+func (s *APIKeyService) create(ctx context.Context, operatorID string, name string, rawKey string, role entities.APIKeyRole) (*entities.APIKey, error) {
+	apiKey := &entities.APIKey{
+		OperatorID: operatorID,
+		Name:       name,
+		Prefix:     rawKey[:min(apiKeyPrefixLen, len(rawKey))],
+		KeyHash:    HashAPIKey(rawKey),
+		Role:       role,
+	}
+	if err := s.apiKeyRepo.Create(ctx, apiKey, nil); err != nil {
+		zap.L().Error("Error while saving api key",
+			zap.String("name", name),
+			zap.Error(err))
+		return nil, err
+	}
+	return apiKey, nil
+}
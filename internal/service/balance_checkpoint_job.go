@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// BalanceCheckpointJob stores balance checkpoints on a fixed interval. Runs of
+// several replicas do not overlap, a replica that finds the lock taken skips
+// its turn.
+type BalanceCheckpointJob struct {
+	balanceHistoryService IBalanceHistoryService
+	interval              time.Duration
+}
+
+func NewBalanceCheckpointJob(balanceHistoryService IBalanceHistoryService, interval time.Duration) *BalanceCheckpointJob {
+	return &BalanceCheckpointJob{
+		balanceHistoryService: balanceHistoryService,
+		interval:              interval,
+	}
+}
+
+// Run stores checkpoints until ctx is cancelled, it returns at once when the interval is not positive
+func (j *BalanceCheckpointJob) Run(ctx context.Context) {
+	if j.interval <= 0 {
+		zap.L().Info("Balance checkpoints disabled")
+		return
+	}
+	zap.L().Info("Balance checkpoints started", zap.Duration("interval", j.interval))
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			zap.L().Info("Balance checkpoints stopped")
+			return
+		case <-ticker.C:
+		}
+		// Errors are logged and counted by the service, the next tick retries
+		_ = j.balanceHistoryService.CreateCheckpoints(ctx)
+	}
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/metrics"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/repository"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrCheckpointInProgress = errors.New("another balance checkpoint run is in progress")
+	ErrInvalidBalanceTime   = errors.New("at must be an RFC3339 time")
+	ErrBalanceTimeInFuture  = errors.New("at must not be in the future")
+	ErrBalanceTimeArchived  = errors.New("at falls into an archived month")
+)
+
+type IBalanceHistoryService interface {
+	// GetBalanceAt reconstructs the balance of the player at a past time from its transactions
+	GetBalanceAt(ctx context.Context, operatorID, playerID, at string) (*entities.HistoricalBalance, error)
+	// CreateCheckpoints stores a balance checkpoint for every wallet with new transactions
+	CreateCheckpoints(ctx context.Context) error
+}
+
+type BalanceHistoryService struct {
+	unitOfWork     repository.IUnitOfWork
+	checkpointRepo repository.IBalanceCheckpointRepository
+	archiveRepo    repository.ITransactionArchiveRepository
+	// checkpointLag keeps checkpoints behind now, a transaction stored with an
+	// earlier created_at may still be uncommitted when the checkpoint is taken
+	checkpointLag time.Duration
+}
+
+func NewBalanceHistoryService(unitOfWork repository.IUnitOfWork, checkpointRepo repository.IBalanceCheckpointRepository, archiveRepo repository.ITransactionArchiveRepository, checkpointLag time.Duration) IBalanceHistoryService {
+	return &BalanceHistoryService{
+		unitOfWork:     unitOfWork,
+		checkpointRepo: checkpointRepo,
+		archiveRepo:    archiveRepo,
+		checkpointLag:  checkpointLag,
+	}
+}
+
+func (s *BalanceHistoryService) GetBalanceAt(ctx context.Context, operatorID, playerID, at string) (*entities.HistoricalBalance, error) {
+	atTime, err := time.Parse(time.RFC3339Nano, at)
+	if err != nil {
+		return nil, ErrInvalidBalanceTime
+	}
+	// The balance at a future time would change as transactions arrive
+	if atTime.After(time.Now()) {
+		return nil, ErrBalanceTimeInFuture
+	}
+	// Archived months are left out of the replay, the checkpoints at their
+	// ends keep the balances after them replayable
+	archives, err := s.archiveRepo.GetArchives(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(archives) > 0 && atTime.Before(archives[len(archives)-1].End()) {
+		return nil, ErrBalanceTimeArchived
+	}
+
+	balance, err := s.checkpointRepo.GetBalanceAt(ctx, operatorID, playerID, atTime, nil)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPlayerNotFound
+		}
+		zap.L().Error("Error while reconstructing balance",
+			zap.String("operator_id", operatorID),
+			zap.String("player_id", playerID),
+			zap.Time("at", atTime),
+			zap.Error(err))
+		return nil, err
+	}
+	balance.Balance = roundAmount(balance.Balance)
+	return balance, nil
+}
+
+func (s *BalanceHistoryService) CreateCheckpoints(ctx context.Context) error {
+	startedAt := time.Now()
+	cutoff := startedAt.Add(-s.checkpointLag)
+
+	var created int64
+	err := s.unitOfWork.Do(ctx, func(repos repository.Repositories) error {
+		// The lock is released with the transaction, so a crashed run never blocks the next one
+		locked, err := repos.BalanceCheckpoints.TryLock(ctx, nil)
+		if err != nil {
+			return err
+		}
+		if !locked {
+			return ErrCheckpointInProgress
+		}
+		created, err = repos.BalanceCheckpoints.CreateCheckpoints(ctx, cutoff, nil)
+		return err
+	})
+	if errors.Is(err, ErrCheckpointInProgress) {
+		metrics.BalanceCheckpointRuns.WithLabelValues("skipped").Inc()
+		return err
+	}
+	if err != nil {
+		metrics.BalanceCheckpointRuns.WithLabelValues("error").Inc()
+		zap.L().Error("Balance checkpoint run failed", zap.Error(err))
+		return err
+	}
+
+	metrics.BalanceCheckpointRuns.WithLabelValues("success").Inc()
+	zap.L().Info("Balance checkpoints created",
+		zap.Time("cutoff", cutoff),
+		zap.Int64("wallets", created),
+		zap.Duration("duration", time.Since(startedAt)))
+	return nil
+}
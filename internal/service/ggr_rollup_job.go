@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// GGRRollupJob keeps the daily GGR statistics up to date. Rollups of several
+// replicas do not overlap, a replica that finds the lock taken skips its turn.
+type GGRRollupJob struct {
+	reportService IReportService
+	interval      time.Duration
+}
+
+func NewGGRRollupJob(reportService IReportService, interval time.Duration) *GGRRollupJob {
+	return &GGRRollupJob{
+		reportService: reportService,
+		interval:      interval,
+	}
+}
+
+// Run rolls up until ctx is cancelled, it returns at once when the interval is
+// not positive. The first rollup runs on start, so reports are current right
+// after a deploy.
+func (j *GGRRollupJob) Run(ctx context.Context) {
+	if j.interval <= 0 {
+		zap.L().Info("GGR rollup disabled")
+		return
+	}
+	zap.L().Info("GGR rollup started", zap.Duration("interval", j.interval))
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		// Errors are logged and counted by the service, the next tick retries
+		_ = j.reportService.RollupGGR(ctx)
+		select {
+		case <-ctx.Done():
+			zap.L().Info("GGR rollup stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
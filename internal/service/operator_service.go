@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/repository"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrOperatorNotFound      = errors.New("operator not found")
+	ErrInvalidOperatorConfig = errors.New("invalid operator configuration")
+)
+
+type IOperatorService interface {
+	GetOperator(ctx context.Context, operatorID string) (*entities.Operator, error)
+	UpdateOperator(ctx context.Context, operator *entities.Operator) (*entities.Operator, error)
+}
+
+type OperatorService struct {
+	operatorRepo repository.IOperatorRepository
+}
+
+func NewOperatorService(operatorRepo repository.IOperatorRepository) IOperatorService {
+	return &OperatorService{
+		operatorRepo: operatorRepo,
+	}
+}
+
+func (s *OperatorService) GetOperator(ctx context.Context, operatorID string) (*entities.Operator, error) {
+	operator, err := s.operatorRepo.GetByID(ctx, operatorID, nil)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOperatorNotFound
+		}
+		return nil, err
+	}
+	return operator, nil
+}
+
+func (s *OperatorService) UpdateOperator(ctx context.Context, operator *entities.Operator) (*entities.Operator, error) {
+	if len(operator.Currencies) == 0 || len(operator.SpendOrder) == 0 {
+		return nil, ErrInvalidOperatorConfig
+	}
+	if operator.MaxBet > 0 && operator.MinBet > operator.MaxBet {
+		return nil, ErrInvalidOperatorConfig
+	}
+	for _, bucket := range operator.SpendOrder {
+		if !bucket.IsValid() {
+			return nil, ErrInvalidOperatorConfig
+		}
+	}
+
+	if _, err := s.GetOperator(ctx, operator.ID); err != nil {
+		return nil, err
+	}
+
+	if err := s.operatorRepo.Update(ctx, operator, nil); err != nil {
+		zap.L().Error("Error while updating operator",
+			zap.String("operator_id", operator.ID),
+			zap.Error(err))
+		return nil, err
+	}
+
+	zap.L().Info("Operator configuration updated",
+		zap.String("operator_id", operator.ID))
+	return s.GetOperator(ctx, operator.ID)
+}
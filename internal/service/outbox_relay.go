@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/events"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/metrics"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/repository"
+	"go.uber.org/zap"
+)
+
+type OutboxRelayConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	// MaxAttempts is the number of failed publishes after which an event is dead-lettered
+	MaxAttempts    int
+	RetryBaseDelay time.Duration
+	RetryMaxDelay  time.Duration
+}
+
+// OutboxRelay publishes outbox events. A batch is locked, published and removed
+// in one database transaction, so several replicas can run the relay and an
+// event is only removed after the publisher acknowledged it. Delivery is at
+// least once: a crash between publish and commit publishes the event again.
+type OutboxRelay struct {
+	unitOfWork repository.IUnitOfWork
+	outboxRepo repository.IOutboxRepository
+	publisher  events.Publisher
+	config     OutboxRelayConfig
+}
+
+func NewOutboxRelay(unitOfWork repository.IUnitOfWork, outboxRepo repository.IOutboxRepository, publisher events.Publisher, config OutboxRelayConfig) *OutboxRelay {
+	return &OutboxRelay{
+		unitOfWork: unitOfWork,
+		outboxRepo: outboxRepo,
+		publisher:  publisher,
+		config:     config,
+	}
+}
+
+// Run publishes outbox events until ctx is cancelled
+func (r *OutboxRelay) Run(ctx context.Context) {
+	zap.L().Info("Outbox relay started")
+	ticker := time.NewTicker(r.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		processed, err := r.relayBatch(ctx)
+		if err != nil && ctx.Err() == nil {
+			zap.L().Error("Error while relaying outbox events", zap.Error(err))
+		}
+
+		// A full batch means there is a backlog, keep going without waiting
+		if err == nil && processed == r.config.BatchSize && ctx.Err() == nil {
+			continue
+		}
+
+		if pending, err := r.outboxRepo.CountPending(ctx, nil); err == nil {
+			metrics.OutboxPending.Set(float64(pending))
+		}
+
+		select {
+		case <-ctx.Done():
+			zap.L().Info("Outbox relay stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *OutboxRelay) relayBatch(ctx context.Context) (int, error) {
+	var processed int
+	err := r.unitOfWork.Do(ctx, func(repos repository.Repositories) error {
+		processed = 0
+		now := time.Now()
+		batch, err := repos.Outbox.GetPublishableWithLock(ctx, now, r.config.BatchSize, nil)
+		if err != nil {
+			return err
+		}
+
+		for _, event := range batch {
+			if err := r.relay(ctx, repos, event, now); err != nil {
+				return err
+			}
+			processed++
+		}
+		return nil
+	})
+	return processed, err
+}
+
+// relay publishes one event and records the result. Only database errors are
+// returned, a failed publish is stored on the event and retried later.
+func (r *OutboxRelay) relay(ctx context.Context, repos repository.Repositories, event *entities.OutboxEvent, now time.Time) error {
+	publishErr := r.publisher.Publish(ctx, events.Message{
+		ID:         event.ID,
+		OperatorID: event.OperatorID,
+		Key:        event.AggregateKey,
+		Type:       event.EventType,
+		Payload:    event.Payload,
+		CreatedAt:  event.CreatedAt,
+	})
+	if publishErr == nil {
+		metrics.OutboxPublished.WithLabelValues(event.EventType).Inc()
+		metrics.OutboxPublishLag.Observe(time.Since(event.CreatedAt).Seconds())
+		return repos.Outbox.Delete(ctx, event.ID, nil)
+	}
+	if ctx.Err() != nil {
+		// Shutting down, the rollback leaves the event untouched for the next run
+		return ctx.Err()
+	}
+
+	metrics.OutboxPublishFailures.WithLabelValues(event.EventType).Inc()
+	event.Attempts++
+	if event.Attempts >= r.config.MaxAttempts {
+		zap.L().Error("Moving outbox event to dead-letter table",
+			zap.Uint64("event_id", event.ID),
+			zap.String("type", event.EventType),
+			zap.Int("attempts", event.Attempts),
+			zap.Error(publishErr))
+		metrics.OutboxDeadLettered.WithLabelValues(event.EventType).Inc()
+		return repos.Outbox.MoveToDeadLetter(ctx, event, publishErr.Error(), nil)
+	}
+
+	zap.L().Warn("Failed to publish outbox event",
+		zap.Uint64("event_id", event.ID),
+		zap.String("type", event.EventType),
+		zap.Int("attempts", event.Attempts),
+		zap.Error(publishErr))
+	return repos.Outbox.MarkFailed(ctx, event.ID, event.Attempts, publishErr.Error(), now.Add(r.retryDelay(event.Attempts)), nil)
+}
+
+// retryDelay doubles with every failed attempt up to RetryMaxDelay
+func (r *OutboxRelay) retryDelay(attempts int) time.Duration {
+	delay := r.config.RetryBaseDelay << (attempts - 1)
+	if delay <= 0 || delay > r.config.RetryMaxDelay {
+		return r.config.RetryMaxDelay
+	}
+	return delay
+}
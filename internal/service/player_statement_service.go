@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/archive"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/playerstatement"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/repository"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrPlayerNotFound          = errors.New("player not found")
+	ErrInvalidStatementPeriod  = errors.New("from and to must be formatted as YYYY-MM-DD")
+	ErrStatementPeriodReversed = errors.New("statement end date is before the start date")
+	ErrStatementArchived       = errors.New("statement period covers archived months whose files are not available")
+)
+
+// PlayerStatementRequest covers the days From to To, both inclusive, in UTC
+type PlayerStatementRequest struct {
+	OperatorID string
+	PlayerID   string
+	From       string
+	To         string
+}
+
+type IPlayerStatementService interface {
+	// Write streams the statement of the player to writer. Errors returned
+	// before the header was written leave writer untouched.
+	Write(ctx context.Context, request *PlayerStatementRequest, writer playerstatement.Writer) error
+}
+
+// PlayerStatementService reads the transactions of archived months from their
+// files, a statement reads the same with or without archiving
+type PlayerStatementService struct {
+	statementRepo repository.IPlayerStatementRepository
+	archiveStore  *archive.Store
+}
+
+func NewPlayerStatementService(statementRepo repository.IPlayerStatementRepository, archiveStore *archive.Store) IPlayerStatementService {
+	return &PlayerStatementService{
+		statementRepo: statementRepo,
+		archiveStore:  archiveStore,
+	}
+}
+
+func (s *PlayerStatementService) Write(ctx context.Context, request *PlayerStatementRequest, writer playerstatement.Writer) error {
+	from, err := time.Parse(entities.ReportDateLayout, request.From)
+	if err != nil {
+		return ErrInvalidStatementPeriod
+	}
+	to, err := time.Parse(entities.ReportDateLayout, request.To)
+	if err != nil {
+		return ErrInvalidStatementPeriod
+	}
+	if to.Before(from) {
+		return ErrStatementPeriodReversed
+	}
+	to = to.AddDate(0, 0, 1)
+
+	var balance float64
+	footer := playerstatement.Footer{}
+	writeLine := func(transaction *entities.Transaction) error {
+		amount := transaction.Amount
+		switch transaction.Type {
+		case entities.TransactionTypeBet:
+			amount = -amount
+			footer.TotalBets += transaction.Amount
+		case entities.TransactionTypeResult:
+			footer.TotalWins += transaction.Amount
+		}
+		balance = roundAmount(balance + amount)
+		footer.TransactionCount++
+		return writer.WriteLine(playerstatement.Line{
+			Transaction: transaction,
+			Amount:      amount,
+			Balance:     balance,
+		})
+	}
+	err = s.statementRepo.Read(ctx, request.OperatorID, request.PlayerID, from, to,
+		func(player *entities.Player, openingBalance float64, archives []*entities.TransactionArchive) error {
+			for _, monthArchive := range archives {
+				exists, err := s.archiveStore.Exists(monthArchive.FileName)
+				if err != nil {
+					return err
+				}
+				if !exists {
+					return ErrStatementArchived
+				}
+			}
+			// The transactions of the month the period starts in are split at
+			// from, the ones after it are part of the opening balance yet
+			var carried []*entities.Transaction
+			if len(archives) > 0 && archives[0].Month.Before(from) {
+				err := s.readArchive(archives[0], player, func(transaction *entities.Transaction) error {
+					if !transaction.CreatedAt.Before(from) {
+						openingBalance -= signedAmount(transaction)
+						carried = append(carried, transaction)
+					}
+					return nil
+				})
+				if err != nil {
+					return err
+				}
+				archives = archives[1:]
+			}
+
+			balance = roundAmount(openingBalance)
+			err := writer.WriteHeader(playerstatement.Header{
+				OperatorID:     player.OperatorID,
+				PlayerID:       player.ID,
+				WalletID:       player.WalletID,
+				Currency:       player.Currency,
+				From:           from,
+				To:             to,
+				OpeningBalance: balance,
+				GeneratedAt:    time.Now(),
+			})
+			if err != nil {
+				return err
+			}
+			for _, transaction := range carried {
+				if transaction.CreatedAt.Before(to) {
+					if err := writeLine(transaction); err != nil {
+						return err
+					}
+				}
+			}
+			for _, monthArchive := range archives {
+				err := s.readArchive(monthArchive, player, func(transaction *entities.Transaction) error {
+					if !transaction.CreatedAt.Before(to) {
+						return nil
+					}
+					return writeLine(transaction)
+				})
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		writeLine)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrPlayerNotFound
+		}
+		if errors.Is(err, ErrStatementArchived) {
+			return err
+		}
+		zap.L().Error("Error while writing player statement",
+			zap.String("operator_id", request.OperatorID),
+			zap.String("player_id", request.PlayerID),
+			zap.Int("transaction_count", footer.TransactionCount),
+			zap.Error(err))
+		return err
+	}
+
+	footer.ClosingBalance = balance
+	footer.TotalBets = roundAmount(footer.TotalBets)
+	footer.TotalWins = roundAmount(footer.TotalWins)
+	return writer.WriteFooter(footer)
+}
+
+// readArchive passes the transactions of the player in the archived month to
+// each, soft deleted ones are skipped like in the database
+func (s *PlayerStatementService) readArchive(monthArchive *entities.TransactionArchive, player *entities.Player, each func(transaction *entities.Transaction) error) error {
+	return s.archiveStore.Read(monthArchive.FileName, func(transaction *entities.Transaction) error {
+		if transaction.OperatorID != player.OperatorID || transaction.PlayerID != player.ID || transaction.DeletedAt.Valid {
+			return nil
+		}
+		return each(transaction)
+	})
+}
+
+// signedAmount is the effect of the transaction on the balance, like signedAmountSQL of the repository
+func signedAmount(transaction *entities.Transaction) float64 {
+	switch transaction.Type {
+	case entities.TransactionTypeBet:
+		return -transaction.Amount
+	case entities.TransactionTypeResult:
+		return transaction.Amount
+	default:
+		return 0
+	}
+}
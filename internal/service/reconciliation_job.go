@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"go.uber.org/zap"
+)
+
+// ReconciliationJob reconciles every wallet on a fixed interval. Runs of
+// several replicas do not overlap, a replica that finds the lock taken skips
+// its turn.
+type ReconciliationJob struct {
+	reconciliationService IReconciliationService
+	interval              time.Duration
+}
+
+func NewReconciliationJob(reconciliationService IReconciliationService, interval time.Duration) *ReconciliationJob {
+	return &ReconciliationJob{
+		reconciliationService: reconciliationService,
+		interval:              interval,
+	}
+}
+
+// Run reconciles until ctx is cancelled, it returns at once when the interval is not positive
+func (j *ReconciliationJob) Run(ctx context.Context) {
+	if j.interval <= 0 {
+		zap.L().Info("Scheduled reconciliation disabled")
+		return
+	}
+	zap.L().Info("Scheduled reconciliation started", zap.Duration("interval", j.interval))
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			zap.L().Info("Scheduled reconciliation stopped")
+			return
+		case <-ticker.C:
+		}
+		if _, err := j.reconciliationService.Reconcile(ctx, "", entities.ReconciliationTriggerSchedule); err != nil {
+			if errors.Is(err, ErrReconciliationInProgress) {
+				zap.L().Info("Skipping scheduled reconciliation, another run is in progress")
+			}
+		}
+	}
+}
@@ -0,0 +1,310 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/cache"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/metrics"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/repository"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	// suspectTransactionLimit caps the transaction ids stored per discrepancy
+	suspectTransactionLimit = 100
+	// discrepancyListLimit caps the discrepancies returned by ListDiscrepancies
+	discrepancyListLimit = 500
+)
+
+var (
+	ErrReconciliationInProgress = errors.New("another reconciliation is running")
+	ErrReconciliationNotFound   = errors.New("reconciliation run not found")
+	ErrDiscrepancyNotFound      = errors.New("discrepancy not found")
+	ErrDiscrepancyNotOpen       = errors.New("discrepancy is not open")
+	ErrDiscrepancyChanged       = errors.New("drift changed since the reconciliation run, reconcile again")
+	ErrDiscrepancyResolved      = errors.New("wallet no longer drifts, discrepancy marked as resolved")
+	ErrInvalidDiscrepancyStatus = errors.New("invalid discrepancy status")
+	ErrMissingReason            = errors.New("correction reason is required")
+)
+
+type IReconciliationService interface {
+	// Reconcile recomputes the balances of the operator's wallets, or of every
+	// wallet when operatorID is empty, and stores the run with its discrepancies
+	Reconcile(ctx context.Context, operatorID string, trigger entities.ReconciliationTrigger) (*entities.ReconciliationRun, error)
+	GetRun(ctx context.Context, operatorID string, id uint64) (*entities.ReconciliationRun, error)
+	ListDiscrepancies(ctx context.Context, operatorID string, status entities.DiscrepancyStatus) ([]*entities.ReconciliationDiscrepancy, error)
+	// ApproveCorrection sets the wallet balance to the balance its transactions
+	// add up to. It is refused when the drift changed since the run, and marks
+	// the discrepancy resolved without an adjustment when the drift is gone.
+	ApproveCorrection(ctx context.Context, operatorID string, id uint64, reason, approvedBy string) (*entities.BalanceAdjustment, error)
+}
+
+type ReconciliationService struct {
+	unitOfWork         repository.IUnitOfWork
+	reconciliationRepo repository.IReconciliationRepository
+	balanceCache       cache.IBalanceCache
+}
+
+func NewReconciliationService(unitOfWork repository.IUnitOfWork, reconciliationRepo repository.IReconciliationRepository, balanceCache cache.IBalanceCache) IReconciliationService {
+	return &ReconciliationService{
+		unitOfWork:         unitOfWork,
+		reconciliationRepo: reconciliationRepo,
+		balanceCache:       balanceCache,
+	}
+}
+
+// roundAmount rounds to the two decimals balances are stored with
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+func (s *ReconciliationService) Reconcile(ctx context.Context, operatorID string, trigger entities.ReconciliationTrigger) (*entities.ReconciliationRun, error) {
+	var run *entities.ReconciliationRun
+	err := s.unitOfWork.Do(ctx, func(repos repository.Repositories) error {
+		// The lock is released with the transaction, so a crashed run never blocks the next one
+		locked, err := repos.Reconciliation.TryLock(ctx, nil)
+		if err != nil {
+			return err
+		}
+		if !locked {
+			return ErrReconciliationInProgress
+		}
+
+		run = &entities.ReconciliationRun{
+			TriggeredBy: trigger,
+			StartedAt:   time.Now(),
+		}
+		if operatorID != "" {
+			run.OperatorID = &operatorID
+		}
+
+		walletCount, err := repos.Reconciliation.CountWallets(ctx, operatorID, nil)
+		if err != nil {
+			return err
+		}
+		wallets, err := repos.Reconciliation.GetDriftingWallets(ctx, operatorID, nil)
+		if err != nil {
+			return err
+		}
+
+		for _, wallet := range wallets {
+			since, err := repos.Reconciliation.GetLastConsistentCheck(ctx, wallet.OperatorID, wallet.PlayerID, nil)
+			if err != nil {
+				return err
+			}
+			transactionIDs, err := repos.Reconciliation.GetSuspectTransactionIDs(ctx, wallet, since, suspectTransactionLimit, nil)
+			if err != nil {
+				return err
+			}
+			run.Discrepancies = append(run.Discrepancies, entities.ReconciliationDiscrepancy{
+				OperatorID:      wallet.OperatorID,
+				PlayerID:        wallet.PlayerID,
+				WalletID:        wallet.WalletID,
+				Currency:        wallet.Currency,
+				InitialBalance:  wallet.InitialBalance,
+				ExpectedBalance: wallet.ExpectedBalance,
+				ActualBalance:   wallet.ActualBalance,
+				Drift:           roundAmount(wallet.ActualBalance - wallet.ExpectedBalance),
+				TransactionIDs:  transactionIDs,
+				Status:          entities.DiscrepancyStatusOpen,
+			})
+		}
+
+		run.WalletsChecked = int(walletCount)
+		run.DiscrepancyCount = len(run.Discrepancies)
+		run.FinishedAt = time.Now()
+		return repos.Reconciliation.CreateRun(ctx, run, nil)
+	})
+	if errors.Is(err, ErrReconciliationInProgress) {
+		return nil, err
+	}
+	if err != nil {
+		metrics.ReconciliationRuns.WithLabelValues("error").Inc()
+		zap.L().Error("Reconciliation failed",
+			zap.String("operator_id", operatorID),
+			zap.String("trigger", string(trigger)),
+			zap.Error(err))
+		return nil, err
+	}
+
+	metrics.ReconciliationRuns.WithLabelValues("success").Inc()
+	if operatorID == "" {
+		metrics.ReconciliationDiscrepancies.Set(float64(run.DiscrepancyCount))
+	}
+	for _, discrepancy := range run.Discrepancies {
+		zap.L().Warn("Balance discrepancy found",
+			zap.Uint64("run_id", run.ID),
+			zap.String("operator_id", discrepancy.OperatorID),
+			zap.String("player_id", discrepancy.PlayerID),
+			zap.Float64("expected_balance", discrepancy.ExpectedBalance),
+			zap.Float64("actual_balance", discrepancy.ActualBalance),
+			zap.Float64("drift", discrepancy.Drift),
+			zap.Uint64s("transaction_ids", discrepancy.TransactionIDs))
+	}
+	zap.L().Info("Reconciliation finished",
+		zap.Uint64("run_id", run.ID),
+		zap.String("operator_id", operatorID),
+		zap.String("trigger", string(trigger)),
+		zap.Int("wallets_checked", run.WalletsChecked),
+		zap.Int("discrepancy_count", run.DiscrepancyCount),
+		zap.Duration("duration", run.FinishedAt.Sub(run.StartedAt)))
+	return run, nil
+}
+
+func (s *ReconciliationService) GetRun(ctx context.Context, operatorID string, id uint64) (*entities.ReconciliationRun, error) {
+	run, err := s.reconciliationRepo.GetRunByID(ctx, operatorID, id, nil)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrReconciliationNotFound
+		}
+		return nil, err
+	}
+	return run, nil
+}
+
+func (s *ReconciliationService) ListDiscrepancies(ctx context.Context, operatorID string, status entities.DiscrepancyStatus) ([]*entities.ReconciliationDiscrepancy, error) {
+	if status != "" && !status.IsValid() {
+		return nil, ErrInvalidDiscrepancyStatus
+	}
+	return s.reconciliationRepo.GetDiscrepancies(ctx, operatorID, status, discrepancyListLimit, nil)
+}
+
+func (s *ReconciliationService) ApproveCorrection(ctx context.Context, operatorID string, id uint64, reason, approvedBy string) (*entities.BalanceAdjustment, error) {
+	if reason == "" {
+		return nil, ErrMissingReason
+	}
+
+	var adjustment *entities.BalanceAdjustment
+	err := s.unitOfWork.Do(ctx, func(repos repository.Repositories) error {
+		adjustment = nil
+
+		discrepancy, err := repos.Reconciliation.GetDiscrepancyByIDWithLock(ctx, operatorID, id, nil)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrDiscrepancyNotFound
+			}
+			return err
+		}
+		if discrepancy.Status != entities.DiscrepancyStatusOpen {
+			return ErrDiscrepancyNotOpen
+		}
+
+		// The player lock keeps bets and results of the wallet out until the correction commits
+		player, err := repos.Players.GetByIDWithLock(ctx, operatorID, discrepancy.PlayerID, nil)
+		if err != nil {
+			return err
+		}
+		wallet, err := repos.Reconciliation.GetWalletBalanceCheck(ctx, operatorID, player.ID, nil)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		discrepancy.ResolvedBy = &approvedBy
+		discrepancy.ResolvedAt = &now
+
+		drift := roundAmount(wallet.ActualBalance - wallet.ExpectedBalance)
+		if drift == 0 {
+			discrepancy.Status = entities.DiscrepancyStatusResolved
+			return repos.Reconciliation.ResolveDiscrepancy(ctx, discrepancy, nil)
+		}
+		if drift != discrepancy.Drift {
+			return ErrDiscrepancyChanged
+		}
+
+		adjustment = &entities.BalanceAdjustment{
+			OperatorID:      operatorID,
+			PlayerID:        player.ID,
+			WalletID:        player.WalletID,
+			DiscrepancyID:   &discrepancy.ID,
+			PreviousBalance: wallet.ActualBalance,
+			NewBalance:      wallet.ExpectedBalance,
+			Amount:          roundAmount(wallet.ExpectedBalance - wallet.ActualBalance),
+			Currency:        player.Currency,
+			Reason:          reason,
+			ApprovedBy:      approvedBy,
+			CreatedAt:       now,
+		}
+		if err := repos.Reconciliation.ApplyAdjustment(ctx, adjustment, nil); err != nil {
+			return err
+		}
+		discrepancy.Status = entities.DiscrepancyStatusCorrected
+		if err := repos.Reconciliation.ResolveDiscrepancy(ctx, discrepancy, nil); err != nil {
+			return err
+		}
+		return s.publishAdjustment(ctx, repos, adjustment)
+	})
+	if adjustment != nil {
+		s.balanceCache.Invalidate(ctx, operatorID, adjustment.PlayerID)
+	}
+	if err != nil {
+		zap.L().Error("Error while approving balance correction",
+			zap.String("operator_id", operatorID),
+			zap.Uint64("discrepancy_id", id),
+			zap.Error(err))
+		return nil, err
+	}
+	if adjustment == nil {
+		zap.L().Info("Discrepancy resolved without correction",
+			zap.String("operator_id", operatorID),
+			zap.Uint64("discrepancy_id", id),
+			zap.String("approved_by", approvedBy))
+		return nil, ErrDiscrepancyResolved
+	}
+
+	zap.L().Info("Balance correction applied",
+		zap.String("operator_id", operatorID),
+		zap.Uint64("discrepancy_id", id),
+		zap.Uint64("adjustment_id", adjustment.ID),
+		zap.String("player_id", adjustment.PlayerID),
+		zap.Float64("amount", adjustment.Amount),
+		zap.String("approved_by", approvedBy))
+	return adjustment, nil
+}
+
+// publishAdjustment tells event consumers, webhooks and balance streams about
+// the corrected balance in the transaction of the correction
+func (s *ReconciliationService) publishAdjustment(ctx context.Context, repos repository.Repositories, adjustment *entities.BalanceAdjustment) error {
+	event, err := entities.NewBalanceAdjustedOutboxEvent(adjustment)
+	if err != nil {
+		return err
+	}
+	if err := repos.Outbox.Create(ctx, event, nil); err != nil {
+		return err
+	}
+
+	balancePayload, err := json.Marshal(entities.BalanceEvent{
+		OperatorID: adjustment.OperatorID,
+		PlayerID:   adjustment.PlayerID,
+		WalletID:   adjustment.WalletID,
+		Balance:    adjustment.NewBalance,
+		Currency:   adjustment.Currency,
+		UpdatedAt:  adjustment.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := repos.Webhooks.EnqueueDeliveries(ctx, adjustment.OperatorID, entities.EventTypeBalanceUpdated, balancePayload, nil); err != nil {
+		return err
+	}
+
+	notification, err := json.Marshal(entities.BalanceNotification{
+		OperatorID: adjustment.OperatorID,
+		PlayerID:   adjustment.PlayerID,
+		WalletID:   adjustment.WalletID,
+		Balance:    adjustment.NewBalance,
+		Currency:   adjustment.Currency,
+		Amount:     adjustment.Amount,
+		UpdatedAt:  adjustment.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+	return repos.Notifications.Notify(ctx, entities.BalanceNotificationChannel, notification, nil)
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/metrics"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/repository"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrGGRRollupInProgress = errors.New("another GGR rollup is running")
+	ErrInvalidReportDate   = errors.New("report dates must be formatted as YYYY-MM-DD")
+	ErrInvalidReportRange  = errors.New("report end date is before the start date")
+	ErrReportRangeTooLong  = errors.New("report date range is too long")
+	ErrInvalidGroupBy      = errors.New("group_by must list day, provider_id, game_code or currency")
+)
+
+type ReportConfig struct {
+	// RollupLookbackDays is the number of days, today included, every rollup recomputes
+	RollupLookbackDays int
+	// MaxReportDays caps the days a single report covers
+	MaxReportDays int
+}
+
+type GGRReportRequest struct {
+	OperatorID string
+	From       string
+	To         string
+	// GroupBy is a comma separated list of dimensions, empty groups by all of
+	// them. Currency is always grouped by unless the report is for one currency.
+	GroupBy    string
+	ProviderID string
+	GameCode   string
+	Currency   string
+}
+
+type IReportService interface {
+	// RollupGGR recomputes the daily GGR statistics of the recent days
+	RollupGGR(ctx context.Context) error
+	GetGGR(ctx context.Context, request *GGRReportRequest) (*entities.GGRReport, error)
+}
+
+type ReportService struct {
+	unitOfWork repository.IUnitOfWork
+	reportRepo repository.IReportRepository
+	config     ReportConfig
+}
+
+func NewReportService(unitOfWork repository.IUnitOfWork, reportRepo repository.IReportRepository, config ReportConfig) IReportService {
+	if config.RollupLookbackDays < 1 {
+		config.RollupLookbackDays = 1
+	}
+	return &ReportService{
+		unitOfWork: unitOfWork,
+		reportRepo: reportRepo,
+		config:     config,
+	}
+}
+
+func (s *ReportService) RollupGGR(ctx context.Context) error {
+	startedAt := time.Now()
+	to := startedAt.UTC().Truncate(24 * time.Hour)
+	from := to.AddDate(0, 0, 1-s.config.RollupLookbackDays)
+
+	err := s.unitOfWork.Do(ctx, func(repos repository.Repositories) error {
+		// The lock is released with the transaction, so a crashed rollup never blocks the next one
+		locked, err := repos.Reports.TryLockRollup(ctx, nil)
+		if err != nil {
+			return err
+		}
+		if !locked {
+			return ErrGGRRollupInProgress
+		}
+		return repos.Reports.RollupGGR(ctx, from, to, nil)
+	})
+	if errors.Is(err, ErrGGRRollupInProgress) {
+		metrics.GGRRollups.WithLabelValues("skipped").Inc()
+		return err
+	}
+	if err != nil {
+		metrics.GGRRollups.WithLabelValues("error").Inc()
+		zap.L().Error("GGR rollup failed", zap.Error(err))
+		return err
+	}
+
+	metrics.GGRRollups.WithLabelValues("success").Inc()
+	zap.L().Debug("GGR rollup finished",
+		zap.String("from", from.Format(entities.ReportDateLayout)),
+		zap.String("to", to.Format(entities.ReportDateLayout)),
+		zap.Duration("duration", time.Since(startedAt)))
+	return nil
+}
+
+func (s *ReportService) GetGGR(ctx context.Context, request *GGRReportRequest) (*entities.GGRReport, error) {
+	from, err := time.Parse(entities.ReportDateLayout, request.From)
+	if err != nil {
+		return nil, ErrInvalidReportDate
+	}
+	to, err := time.Parse(entities.ReportDateLayout, request.To)
+	if err != nil {
+		return nil, ErrInvalidReportDate
+	}
+	if to.Before(from) {
+		return nil, ErrInvalidReportRange
+	}
+	if s.config.MaxReportDays > 0 && to.Sub(from) >= time.Duration(s.config.MaxReportDays)*24*time.Hour {
+		return nil, ErrReportRangeTooLong
+	}
+	groupBy, err := parseGroupBy(request.GroupBy, request.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := &entities.GGRReportFilter{
+		OperatorID: request.OperatorID,
+		From:       from,
+		To:         to,
+		GroupBy:    groupBy,
+		ProviderID: request.ProviderID,
+		GameCode:   request.GameCode,
+		Currency:   request.Currency,
+	}
+	rows, err := s.reportRepo.GetGGR(ctx, filter, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &entities.GGRReport{
+		Filter: filter,
+		Rows:   rows,
+	}, nil
+}
+
+// parseGroupBy returns the listed dimensions in report order, so the columns
+// of a report do not depend on how the request listed them. Amounts of
+// different currencies are never added up, so currency is grouped by as well
+// unless the report is filtered to a single currency.
+func parseGroupBy(value, currency string) ([]entities.GGRDimension, error) {
+	if strings.TrimSpace(value) == "" {
+		return entities.GGRDimensions, nil
+	}
+	listed := make(map[entities.GGRDimension]bool)
+	for _, item := range strings.Split(value, ",") {
+		dimension := entities.GGRDimension(strings.TrimSpace(item))
+		if !dimension.IsValid() {
+			return nil, ErrInvalidGroupBy
+		}
+		listed[dimension] = true
+	}
+	if currency == "" {
+		listed[entities.GGRDimensionCurrency] = true
+	}
+	var groupBy []entities.GGRDimension
+	for _, dimension := range entities.GGRDimensions {
+		if listed[dimension] {
+			groupBy = append(groupBy, dimension)
+		}
+	}
+	return groupBy, nil
+}
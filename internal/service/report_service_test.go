@@ -0,0 +1,67 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseGroupBy(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		currency string
+		expected []entities.GGRDimension
+		err      error
+	}{
+		{
+			name:     "default",
+			expected: entities.GGRDimensions,
+		},
+		{
+			name:     "default filtered to one currency",
+			currency: "EUR",
+			expected: entities.GGRDimensions,
+		},
+		{
+			name:     "day adds the currency",
+			value:    "day",
+			expected: []entities.GGRDimension{entities.GGRDimensionDay, entities.GGRDimensionCurrency},
+		},
+		{
+			name:     "day filtered to one currency",
+			value:    "day",
+			currency: "EUR",
+			expected: []entities.GGRDimension{entities.GGRDimensionDay},
+		},
+		{
+			name:     "report order and duplicates",
+			value:    " game_code , day,game_code",
+			expected: []entities.GGRDimension{entities.GGRDimensionDay, entities.GGRDimensionGame, entities.GGRDimensionCurrency},
+		},
+		{
+			name:     "currency listed",
+			value:    "currency,provider_id",
+			currency: "EUR",
+			expected: []entities.GGRDimension{entities.GGRDimensionProvider, entities.GGRDimensionCurrency},
+		},
+		{
+			name:  "unknown dimension",
+			value: "day,player_id",
+			err:   ErrInvalidGroupBy,
+		},
+		{
+			name:  "empty item",
+			value: "day,",
+			err:   ErrInvalidGroupBy,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			groupBy, err := parseGroupBy(tt.value, tt.currency)
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.expected, groupBy)
+		})
+	}
+}
@@ -0,0 +1,301 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/providerstatement"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/repository"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// statementImportListLimit caps the imports returned by ListImports
+const statementImportListLimit = 100
+
+var (
+	ErrStatementProviderNotFound = errors.New("statement provider not found")
+	ErrStatementImportNotFound   = errors.New("statement import not found")
+	ErrInvalidStatementDate      = errors.New("statement date must be formatted as YYYY-MM-DD")
+	ErrInvalidMismatchKind       = errors.New("invalid mismatch kind")
+	// ErrStatementDateArchived is returned for days whose transactions were archived, all rows would be missing
+	ErrStatementDateArchived = errors.New("statement date falls into an archived month")
+)
+
+type StatementImportRequest struct {
+	OperatorID string
+	ProviderID string
+	// Date is the statement day as YYYY-MM-DD in the time zone of the provider's format
+	Date       string
+	FileName   string
+	ImportedBy string
+	Body       io.Reader
+}
+
+type IStatementService interface {
+	// Import parses the statement of a provider and matches its rows against the
+	// transactions by req_id, or by round_id and type for rows without req_id
+	Import(ctx context.Context, request *StatementImportRequest) (*entities.StatementImport, error)
+	ListImports(ctx context.Context, operatorID, providerID string) ([]*entities.StatementImport, error)
+	GetImport(ctx context.Context, operatorID string, id uint64, kind entities.StatementMismatchKind) (*entities.StatementImport, error)
+}
+
+type StatementService struct {
+	statementRepo   repository.IStatementRepository
+	transactionRepo repository.ITransactionRepository
+	archiveRepo     repository.ITransactionArchiveRepository
+	// providerOperators holds the operator of every known provider
+	providerOperators map[string]string
+	formats           map[string]providerstatement.Format
+}
+
+func NewStatementService(statementRepo repository.IStatementRepository, transactionRepo repository.ITransactionRepository,
+	archiveRepo repository.ITransactionArchiveRepository, providerOperators map[string]string, formats map[string]providerstatement.Format) IStatementService {
+	return &StatementService{
+		statementRepo:     statementRepo,
+		transactionRepo:   transactionRepo,
+		archiveRepo:       archiveRepo,
+		providerOperators: providerOperators,
+		formats:           formats,
+	}
+}
+
+func (s *StatementService) format(providerID string) providerstatement.Format {
+	if format, ok := s.formats[providerID]; ok {
+		return format
+	}
+	return providerstatement.DefaultFormat()
+}
+
+func (s *StatementService) Import(ctx context.Context, request *StatementImportRequest) (*entities.StatementImport, error) {
+	// Operators only see statements of their own providers
+	if operatorID, ok := s.providerOperators[request.ProviderID]; !ok || operatorID != request.OperatorID {
+		return nil, ErrStatementProviderNotFound
+	}
+
+	format := s.format(request.ProviderID)
+	day, err := time.ParseInLocation(entities.StatementDateLayout, request.Date, format.Location())
+	if err != nil {
+		return nil, ErrInvalidStatementDate
+	}
+	archives, err := s.archiveRepo.GetArchives(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(archives) > 0 && day.Before(archives[len(archives)-1].End()) {
+		return nil, ErrStatementDateArchived
+	}
+
+	rows, err := providerstatement.Parse(request.Body, format)
+	if err != nil {
+		zap.L().Info("Rejected provider statement",
+			zap.String("provider_id", request.ProviderID),
+			zap.String("date", request.Date),
+			zap.Error(err))
+		return nil, err
+	}
+
+	ours, err := s.transactionRepo.GetByProviderAndPeriod(ctx, request.OperatorID, request.ProviderID, day, day.AddDate(0, 0, 1), nil)
+	if err != nil {
+		return nil, err
+	}
+	// Rows near midnight may have been stored on the neighbouring day, so rows
+	// that are not in the day are looked up on their own before being reported
+	matcher := newStatementMatcher(ours)
+	if err := s.loadMissing(ctx, request.OperatorID, rows, matcher); err != nil {
+		return nil, err
+	}
+
+	statementImport := &entities.StatementImport{
+		OperatorID:    request.OperatorID,
+		ProviderID:    request.ProviderID,
+		StatementDate: day,
+		Format:        format.Type,
+		FileName:      request.FileName,
+		RowCount:      len(rows),
+		ImportedBy:    request.ImportedBy,
+		CreatedAt:     time.Now(),
+	}
+	for _, row := range rows {
+		transaction := matcher.match(row)
+		if transaction == nil {
+			statementImport.MissingOnOurSideCount++
+			statementImport.Mismatches = append(statementImport.Mismatches, newRowMismatch(entities.StatementMismatchMissingOnOurSide, row, nil))
+			continue
+		}
+		// A row with the wrong type is an amount mismatch as well, the balance moved the other way
+		if roundAmount(transaction.Amount) != row.Amount || transaction.Type != row.Type {
+			statementImport.AmountMismatchCount++
+			statementImport.Mismatches = append(statementImport.Mismatches, newRowMismatch(entities.StatementMismatchAmount, row, transaction))
+			continue
+		}
+		statementImport.MatchedCount++
+	}
+	for _, transaction := range matcher.unmatched(ours) {
+		statementImport.MissingOnProviderSideCount++
+		statementImport.Mismatches = append(statementImport.Mismatches, entities.StatementMismatch{
+			Kind:          entities.StatementMismatchMissingOnProviderSide,
+			TransactionID: &transaction.ID,
+			ReqID:         transaction.ReqID,
+			RoundID:       transaction.RoundID,
+			PlayerID:      transaction.PlayerID,
+			Type:          transaction.Type,
+			Currency:      transaction.Currency,
+			OurAmount:     &transaction.Amount,
+		})
+	}
+
+	if err := s.statementRepo.Create(ctx, statementImport, nil); err != nil {
+		zap.L().Error("Error while saving statement import",
+			zap.String("provider_id", request.ProviderID),
+			zap.Error(err))
+		return nil, err
+	}
+
+	zap.L().Info("Provider statement imported",
+		zap.Uint64("import_id", statementImport.ID),
+		zap.String("operator_id", request.OperatorID),
+		zap.String("provider_id", request.ProviderID),
+		zap.String("date", request.Date),
+		zap.Int("row_count", statementImport.RowCount),
+		zap.Int("matched_count", statementImport.MatchedCount),
+		zap.Int("missing_on_our_side_count", statementImport.MissingOnOurSideCount),
+		zap.Int("missing_on_provider_side_count", statementImport.MissingOnProviderSideCount),
+		zap.Int("amount_mismatch_count", statementImport.AmountMismatchCount))
+	return statementImport, nil
+}
+
+// loadMissing adds the transactions of rows that are not in the statement day to the matcher
+func (s *StatementService) loadMissing(ctx context.Context, operatorID string, rows []providerstatement.Row, matcher *statementMatcher) error {
+	var reqIDs, roundIDs []string
+	for _, row := range rows {
+		if row.ReqID != "" {
+			if _, ok := matcher.byReqID[row.ReqID]; !ok {
+				reqIDs = append(reqIDs, row.ReqID)
+			}
+		} else if len(matcher.byRound[roundKey{row.RoundID, row.Type}]) == 0 {
+			roundIDs = append(roundIDs, row.RoundID)
+		}
+	}
+
+	if len(reqIDs) > 0 {
+		transactions, err := s.transactionRepo.GetByReqIDs(ctx, operatorID, reqIDs, nil)
+		if err != nil {
+			return err
+		}
+		matcher.add(transactions)
+	}
+	if len(roundIDs) > 0 {
+		transactions, err := s.transactionRepo.GetByRoundIDs(ctx, operatorID, roundIDs, nil)
+		if err != nil {
+			return err
+		}
+		matcher.add(transactions)
+	}
+	return nil
+}
+
+func newRowMismatch(kind entities.StatementMismatchKind, row providerstatement.Row, transaction *entities.Transaction) entities.StatementMismatch {
+	amount := row.Amount
+	mismatch := entities.StatementMismatch{
+		Kind:           kind,
+		Line:           row.Line,
+		ReqID:          row.ReqID,
+		RoundID:        row.RoundID,
+		PlayerID:       row.PlayerID,
+		Type:           row.Type,
+		Currency:       row.Currency,
+		ProviderAmount: &amount,
+	}
+	if transaction != nil {
+		mismatch.TransactionID = &transaction.ID
+		mismatch.OurAmount = &transaction.Amount
+	}
+	return mismatch
+}
+
+type roundKey struct {
+	roundID         string
+	transactionType entities.TransactionType
+}
+
+// statementMatcher pairs statement rows with transactions, every transaction is
+// used for one row at most, so a duplicated row is reported as missing on our side
+type statementMatcher struct {
+	byReqID map[string]*entities.Transaction
+	// byRound may hold several transactions, round ids are only unique per wallet
+	byRound map[roundKey][]*entities.Transaction
+	matched map[uint64]bool
+}
+
+func newStatementMatcher(transactions []*entities.Transaction) *statementMatcher {
+	matcher := &statementMatcher{
+		byReqID: make(map[string]*entities.Transaction),
+		byRound: make(map[roundKey][]*entities.Transaction),
+		matched: make(map[uint64]bool),
+	}
+	matcher.add(transactions)
+	return matcher
+}
+
+func (m *statementMatcher) add(transactions []*entities.Transaction) {
+	for _, transaction := range transactions {
+		if _, ok := m.byReqID[transaction.ReqID]; ok {
+			continue
+		}
+		m.byReqID[transaction.ReqID] = transaction
+		key := roundKey{transaction.RoundID, transaction.Type}
+		m.byRound[key] = append(m.byRound[key], transaction)
+	}
+}
+
+func (m *statementMatcher) match(row providerstatement.Row) *entities.Transaction {
+	if row.ReqID != "" {
+		transaction, ok := m.byReqID[row.ReqID]
+		if !ok || m.matched[transaction.ID] {
+			return nil
+		}
+		m.matched[transaction.ID] = true
+		return transaction
+	}
+
+	for _, transaction := range m.byRound[roundKey{row.RoundID, row.Type}] {
+		if m.matched[transaction.ID] || (row.PlayerID != "" && row.PlayerID != transaction.PlayerID) {
+			continue
+		}
+		m.matched[transaction.ID] = true
+		return transaction
+	}
+	return nil
+}
+
+func (m *statementMatcher) unmatched(transactions []*entities.Transaction) []*entities.Transaction {
+	var result []*entities.Transaction
+	for _, transaction := range transactions {
+		if !m.matched[transaction.ID] {
+			result = append(result, transaction)
+		}
+	}
+	return result
+}
+
+func (s *StatementService) ListImports(ctx context.Context, operatorID, providerID string) ([]*entities.StatementImport, error) {
+	return s.statementRepo.GetAll(ctx, operatorID, providerID, statementImportListLimit, nil)
+}
+
+func (s *StatementService) GetImport(ctx context.Context, operatorID string, id uint64, kind entities.StatementMismatchKind) (*entities.StatementImport, error) {
+	if kind != "" && !kind.IsValid() {
+		return nil, ErrInvalidMismatchKind
+	}
+	statementImport, err := s.statementRepo.GetByID(ctx, operatorID, id, kind, nil)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrStatementImportNotFound
+		}
+		return nil, err
+	}
+	return statementImport, nil
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/archive"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/metrics"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/repository"
+	"go.uber.org/zap"
+)
+
+var ErrPartitionRunInProgress = errors.New("another transaction partition run is in progress")
+
+type TransactionArchiveConfig struct {
+	// MonthsAhead is the number of months after the current one whose partitions are created in advance
+	MonthsAhead int
+	// RetentionMonths is the number of full months kept in the database before
+	// the current one, older months are archived. Zero disables archiving.
+	RetentionMonths int
+}
+
+type ITransactionArchiveService interface {
+	// MaintainPartitions creates the partitions of the coming months and
+	// archives the months older than the retention, oldest first
+	MaintainPartitions(ctx context.Context) error
+}
+
+type TransactionArchiveService struct {
+	unitOfWork repository.IUnitOfWork
+	store      *archive.Store
+	config     TransactionArchiveConfig
+}
+
+func NewTransactionArchiveService(unitOfWork repository.IUnitOfWork, store *archive.Store, config TransactionArchiveConfig) ITransactionArchiveService {
+	return &TransactionArchiveService{
+		unitOfWork: unitOfWork,
+		store:      store,
+		config:     config,
+	}
+}
+
+// startOfMonth returns the first day of the month of t in UTC, partitions are cut at these times
+func startOfMonth(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func (s *TransactionArchiveService) MaintainPartitions(ctx context.Context) error {
+	startedAt := time.Now()
+	currentMonth := startOfMonth(startedAt)
+
+	// The lock is released with the transaction, so a crashed run never blocks the next one
+	err := s.unitOfWork.Do(ctx, func(repos repository.Repositories) error {
+		locked, err := repos.TransactionArchives.TryLock(ctx, nil)
+		if err != nil {
+			return err
+		}
+		if !locked {
+			return ErrPartitionRunInProgress
+		}
+		for i := 0; i <= s.config.MonthsAhead; i++ {
+			if err := repos.TransactionArchives.CreatePartition(ctx, currentMonth.AddDate(0, i, 0), nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	var archived []*entities.TransactionArchive
+	if err == nil && s.config.RetentionMonths > 0 {
+		archived, err = s.archiveMonths(ctx, currentMonth.AddDate(0, -s.config.RetentionMonths, 0))
+	}
+	if errors.Is(err, ErrPartitionRunInProgress) {
+		metrics.TransactionPartitionRuns.WithLabelValues("skipped").Inc()
+		return err
+	}
+	if err != nil {
+		metrics.TransactionPartitionRuns.WithLabelValues("error").Inc()
+		zap.L().Error("Transaction partition run failed", zap.Int("archived_months", len(archived)), zap.Error(err))
+		return err
+	}
+
+	metrics.TransactionPartitionRuns.WithLabelValues("success").Inc()
+	zap.L().Info("Transaction partitions maintained",
+		zap.Time("current_month", currentMonth),
+		zap.Int("months_ahead", s.config.MonthsAhead),
+		zap.Int("archived_months", len(archived)),
+		zap.Duration("duration", time.Since(startedAt)))
+	return nil
+}
+
+// archiveMonths archives the months starting before before one at a time, a
+// failed month stops the run so the archived months never have a gap
+func (s *TransactionArchiveService) archiveMonths(ctx context.Context, before time.Time) ([]*entities.TransactionArchive, error) {
+	var archived []*entities.TransactionArchive
+	for {
+		var monthArchive *entities.TransactionArchive
+		err := s.unitOfWork.Do(ctx, func(repos repository.Repositories) error {
+			monthArchive = nil
+			locked, err := repos.TransactionArchives.TryLock(ctx, nil)
+			if err != nil {
+				return err
+			}
+			if !locked {
+				return ErrPartitionRunInProgress
+			}
+			months, err := repos.TransactionArchives.GetPartitionMonths(ctx, before, nil)
+			if err != nil || len(months) == 0 {
+				return err
+			}
+			monthArchive, err = s.archiveMonth(ctx, repos, months[0])
+			return err
+		})
+		if err != nil {
+			return archived, err
+		}
+		if monthArchive == nil {
+			return archived, nil
+		}
+
+		archived = append(archived, monthArchive)
+		metrics.TransactionsArchived.Add(float64(monthArchive.TransactionCount))
+		zap.L().Info("Transactions archived",
+			zap.Time("month", monthArchive.Month),
+			zap.String("file_name", monthArchive.FileName),
+			zap.Int64("transactions", monthArchive.TransactionCount),
+			zap.String("checksum", monthArchive.Checksum))
+	}
+}
+
+// archiveMonth writes the transactions of the month to its file and drops its
+// partition. A retried or failed attempt leaves a file without an archive
+// record behind, the next attempt writes it again.
+func (s *TransactionArchiveService) archiveMonth(ctx context.Context, repos repository.Repositories, month time.Time) (*entities.TransactionArchive, error) {
+	fileName := archive.FileName(month)
+	writer, err := s.store.Create(fileName)
+	if err != nil {
+		return nil, err
+	}
+	if err := repos.TransactionArchives.ReadPartition(ctx, month, writer.Write, nil); err != nil {
+		writer.Abort()
+		return nil, err
+	}
+	count, checksum, err := writer.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	// A checkpoint at the end of the month keeps the balances after it
+	// replayable without the transactions of the month
+	end := month.AddDate(0, 1, 0)
+	if _, err := repos.BalanceCheckpoints.CreateCheckpoints(ctx, end.Add(-time.Microsecond), nil); err != nil {
+		return nil, err
+	}
+
+	monthArchive := &entities.TransactionArchive{
+		Month:            month,
+		FileName:         fileName,
+		TransactionCount: count,
+		Checksum:         checksum,
+		ArchivedAt:       time.Now(),
+	}
+	if err := repos.TransactionArchives.Archive(ctx, monthArchive, nil); err != nil {
+		return nil, err
+	}
+	return monthArchive, nil
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// TransactionPartitionJob creates transaction partitions ahead of time and
+// archives old months on a fixed interval. Runs of several replicas do not
+// overlap, a replica that finds the lock taken skips its turn.
+type TransactionPartitionJob struct {
+	transactionArchiveService ITransactionArchiveService
+	interval                  time.Duration
+}
+
+func NewTransactionPartitionJob(transactionArchiveService ITransactionArchiveService, interval time.Duration) *TransactionPartitionJob {
+	return &TransactionPartitionJob{
+		transactionArchiveService: transactionArchiveService,
+		interval:                  interval,
+	}
+}
+
+// Run maintains the partitions until ctx is cancelled, it returns at once when
+// the interval is not positive. The first run is on start, so a replica that
+// was down for a while creates the missing partitions right away.
+func (j *TransactionPartitionJob) Run(ctx context.Context) {
+	if j.interval <= 0 {
+		zap.L().Warn("Transaction partition maintenance disabled, transactions of months without a partition go to the default partition")
+		return
+	}
+	zap.L().Info("Transaction partition maintenance started", zap.Duration("interval", j.interval))
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		// Errors are logged and counted by the service, the next tick retries
+		_ = j.transactionArchiveService.MaintainPartitions(ctx)
+		select {
+		case <-ctx.Done():
+			zap.L().Info("Transaction partition maintenance stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
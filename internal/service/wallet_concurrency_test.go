@@ -0,0 +1,178 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/cache"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/repository"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/service"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// yieldingUnitOfWork lets the other goroutines run after a transaction read the
+// player, so concurrent transactions of the player overlap between the read
+// and the balance update even on a single CPU
+type yieldingUnitOfWork struct {
+	repository.IUnitOfWork
+}
+
+func (u yieldingUnitOfWork) Do(ctx context.Context, fn repository.UnitOfWorkFunc) error {
+	return u.IUnitOfWork.Do(ctx, func(repos repository.Repositories) error {
+		repos.Players = yieldingPlayerRepository{repos.Players}
+		return fn(repos)
+	})
+}
+
+type yieldingPlayerRepository struct {
+	repository.IPlayerRepository
+}
+
+func (r yieldingPlayerRepository) GetByID(ctx context.Context, operatorID, id string, outTx *gorm.DB) (*entities.Player, error) {
+	player, err := r.IPlayerRepository.GetByID(ctx, operatorID, id, outTx)
+	time.Sleep(time.Millisecond)
+	return player, err
+}
+
+// Parallel bets of one player are applied one after the other in both modes.
+// The balance never goes below zero and ends at the opening balance less the
+// applied bets, a bet lost to concurrent updates changes nothing.
+func TestWalletConcurrentBets(t *testing.T) {
+	const (
+		bets    = 50
+		amount  = 10.0
+		balance = 300.0
+	)
+	for _, backend := range walletBackends {
+		for _, concurrency := range balanceConcurrencies {
+			t.Run(backend.name+"/"+string(concurrency), func(t *testing.T) {
+				ctx := context.Background()
+				playerRepo, operatorRepo, unitOfWork := backend.open(t)
+				player := createPlayer(t, playerRepo, balance)
+				walletService := service.NewWalletService(playerRepo, operatorRepo, yieldingUnitOfWork{unitOfWork}, cache.NewNopBalanceCache(), concurrency)
+
+				var applied, rejected, conflicts atomic.Int64
+				var wg sync.WaitGroup
+				for i := 0; i < bets; i++ {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						id := fmt.Sprintf("%d", i)
+						err := walletService.ProcessTransaction(ctx, newTransaction(player, "req-"+id, "round-"+id, entities.TransactionTypeBet, amount))
+						switch {
+						case err == nil:
+							applied.Add(1)
+						case errors.Is(err, service.ErrInsufficientBalance):
+							rejected.Add(1)
+						case errors.Is(err, service.ErrConcurrentUpdate):
+							conflicts.Add(1)
+						default:
+							t.Errorf("bet %s: %v", id, err)
+						}
+					}()
+				}
+				wg.Wait()
+
+				stored, err := walletService.GetPlayerBalance(ctx, player.OperatorID, player.ID)
+				require.NoError(t, err)
+				assert.GreaterOrEqual(t, stored.Balance, 0.0)
+				assert.LessOrEqual(t, applied.Load(), int64(balance/amount))
+				assert.Equal(t, balance-float64(applied.Load())*amount, stored.Balance)
+				if conflicts.Load() == 0 {
+					// Nothing gave up, so the bets were taken until the balance ran out
+					assert.Equal(t, int64(balance/amount), applied.Load())
+					assert.Equal(t, int64(bets)-applied.Load(), rejected.Load())
+				}
+			})
+		}
+	}
+}
+
+// BenchmarkWalletProcessTransaction runs bet and result pairs of one player
+// from parallel goroutines, the worst contention for a player. Next to the time
+// per pair it reports the 99th percentile latency of a transaction, the
+// retries per transaction and the share given up on after concurrent updates.
+//
+// The locking mode queues the transactions of the player. The optimistic mode
+// runs every transaction that lost its balance update again, so under this
+// contention its retries and p99 are higher. It pays off when the transactions
+// of a player rarely overlap, see the bench command for loads over several
+// players.
+func BenchmarkWalletProcessTransaction(b *testing.B) {
+	for _, backend := range walletBackends {
+		for _, concurrency := range balanceConcurrencies {
+			b.Run(backend.name+"/"+string(concurrency), func(b *testing.B) {
+				ctx := context.Background()
+				walletService, player := newWalletService(b, backend, concurrency, 1e12)
+
+				retries := gatherRetries()
+				var rounds, conflicts atomic.Int64
+				var mu sync.Mutex
+				var latencies []time.Duration
+				// As many goroutines as the workers of the bench command, even on a single CPU
+				b.SetParallelism(32)
+				b.ResetTimer()
+				b.RunParallel(func(pb *testing.PB) {
+					var local []time.Duration
+					for pb.Next() {
+						round := fmt.Sprintf("round-%d", rounds.Add(1))
+						for _, transactionType := range []entities.TransactionType{entities.TransactionTypeBet, entities.TransactionTypeResult} {
+							startedAt := time.Now()
+							err := walletService.ProcessTransaction(ctx, newTransaction(player, round+"-"+string(transactionType), round, transactionType, 1))
+							local = append(local, time.Since(startedAt))
+							if errors.Is(err, service.ErrConcurrentUpdate) {
+								conflicts.Add(1)
+								break
+							}
+							if err != nil {
+								b.Error(err)
+								return
+							}
+						}
+					}
+					mu.Lock()
+					latencies = append(latencies, local...)
+					mu.Unlock()
+				})
+				b.StopTimer()
+
+				if len(latencies) == 0 {
+					return
+				}
+				sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+				p99 := latencies[(len(latencies)*99)/100]
+				b.ReportMetric(float64(p99.Microseconds())/1000, "p99-ms")
+				b.ReportMetric((gatherRetries()-retries)/float64(len(latencies)), "retries/tx")
+				b.ReportMetric(float64(conflicts.Load())/float64(len(latencies)), "conflicts/tx")
+			})
+		}
+	}
+}
+
+// gatherRetries sums the transaction retry counter over all reasons
+func gatherRetries() float64 {
+	families, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		return 0
+	}
+	var retries float64
+	for _, family := range families {
+		if family.GetName() != "wallet_db_transaction_retries_total" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			retries += metric.GetCounter().GetValue()
+		}
+	}
+	return retries
+}
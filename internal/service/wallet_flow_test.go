@@ -0,0 +1,123 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/cache"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/repository"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/service"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// walletBackend opens the repositories the wallet service needs on one storage backend
+type walletBackend struct {
+	name string
+	open func(t testing.TB) (repository.IPlayerRepository, repository.IOperatorRepository, repository.IUnitOfWork)
+}
+
+var walletBackends = []walletBackend{
+	{
+		name: "memory",
+		open: func(t testing.TB) (repository.IPlayerRepository, repository.IOperatorRepository, repository.IUnitOfWork) {
+			store := repository.NewMemoryStore()
+			return repository.NewMemoryPlayerRepository(store), repository.NewMemoryOperatorRepository(store), repository.NewMemoryUnitOfWork(store)
+		},
+	},
+	{
+		name: "sqlite",
+		open: func(t testing.TB) (repository.IPlayerRepository, repository.IOperatorRepository, repository.IUnitOfWork) {
+			gormRepository := repository.NewGormRepository(testutil.OpenSQLite(t))
+			runner := repository.NewTransactionRunner(gormRepository, repository.TransactionRunnerConfig{
+				MaxAttempts: 5,
+				BaseBackoff: time.Millisecond,
+				MaxBackoff:  10 * time.Millisecond,
+			})
+			return repository.NewPlayerRepository(gormRepository), repository.NewOperatorRepository(gormRepository), repository.NewUnitOfWork(runner)
+		},
+	},
+}
+
+var balanceConcurrencies = []service.BalanceConcurrency{service.BalanceConcurrencyLocking, service.BalanceConcurrencyOptimistic}
+
+// newWalletService returns a wallet service on the backend with a player of the given balance
+func newWalletService(t testing.TB, backend walletBackend, concurrency service.BalanceConcurrency, balance float64) (service.IWalletService, *entities.Player) {
+	t.Helper()
+	playerRepo, operatorRepo, unitOfWork := backend.open(t)
+	player := createPlayer(t, playerRepo, balance)
+	return service.NewWalletService(playerRepo, operatorRepo, unitOfWork, cache.NewNopBalanceCache(), concurrency), player
+}
+
+// createPlayer stores player-1 of the default operator with the given balance
+func createPlayer(t testing.TB, playerRepo repository.IPlayerRepository, balance float64) *entities.Player {
+	t.Helper()
+	player := &entities.Player{
+		OperatorID: entities.DefaultOperatorID,
+		ID:         "player-1",
+		WalletID:   "wallet-1",
+		Balance:    balance,
+		Currency:   "INR",
+	}
+	require.NoError(t, playerRepo.Create(context.Background(), player, nil))
+	return player
+}
+
+func newTransaction(player *entities.Player, reqID, roundID string, transactionType entities.TransactionType, amount float64) *entities.Transaction {
+	return &entities.Transaction{
+		OperatorID: player.OperatorID,
+		ProviderID: "provider-1",
+		ReqID:      reqID,
+		PlayerID:   player.ID,
+		WalletID:   player.WalletID,
+		RoundID:    roundID,
+		SessionID:  "session-1",
+		GameCode:   "game-1",
+		Type:       transactionType,
+		Amount:     amount,
+		Currency:   player.Currency,
+	}
+}
+
+func TestWalletFlow(t *testing.T) {
+	bet, result := entities.TransactionTypeBet, entities.TransactionTypeResult
+	steps := []struct {
+		name            string
+		reqID, roundID  string
+		transactionType entities.TransactionType
+		amount          float64
+		err             error
+		balance         float64
+	}{
+		{name: "bet", reqID: "req-1", roundID: "round-1", transactionType: bet, amount: 30, balance: 70},
+		{name: "duplicate bet", reqID: "req-1", roundID: "round-1", transactionType: bet, amount: 30, err: service.ErrDuplicateRequest, balance: 70},
+		{name: "second bet of the round", reqID: "req-2", roundID: "round-1", transactionType: bet, amount: 10, err: service.ErrDuplicateRound, balance: 70},
+		{name: "result", reqID: "req-3", roundID: "round-1", transactionType: result, amount: 50, balance: 120},
+		{name: "duplicate result", reqID: "req-3", roundID: "round-1", transactionType: result, amount: 50, err: service.ErrDuplicateRequest, balance: 120},
+		{name: "result without bet", reqID: "req-4", roundID: "round-2", transactionType: result, amount: 50, err: service.ErrBetNotFound, balance: 120},
+		{name: "insufficient funds", reqID: "req-5", roundID: "round-3", transactionType: bet, amount: 120.01, err: service.ErrInsufficientBalance, balance: 120},
+		{name: "whole balance after rejected bet", reqID: "req-6", roundID: "round-3", transactionType: bet, amount: 120, balance: 0},
+	}
+	for _, backend := range walletBackends {
+		for _, concurrency := range balanceConcurrencies {
+			t.Run(backend.name+"/"+string(concurrency), func(t *testing.T) {
+				ctx := context.Background()
+				walletService, player := newWalletService(t, backend, concurrency, 100)
+				for _, step := range steps {
+					err := walletService.ProcessTransaction(ctx, newTransaction(player, step.reqID, step.roundID, step.transactionType, step.amount))
+					if step.err == nil {
+						require.NoError(t, err, step.name)
+					} else {
+						require.ErrorIs(t, err, step.err, step.name)
+					}
+					stored, err := walletService.GetPlayerBalance(ctx, player.OperatorID, player.ID)
+					require.NoError(t, err)
+					assert.Equal(t, step.balance, stored.Balance, step.name)
+				}
+			})
+		}
+	}
+}
@@ -1,12 +1,25 @@
 package service
 
 import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/cache"
 	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/metrics"
 	"github.com/BarisKilicGsu/casino-wallet-service/internal/repository"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 )
 
 var (
@@ -18,34 +31,105 @@ var (
 	ErrGameCodeMismatch    = errors.New("game code mismatch")
 	ErrWalletIDMismatch    = errors.New("wallet ID mismatch")
 	ErrPlayerIDMismatch    = errors.New("player ID mismatch")
+	ErrCurrencyNotAllowed  = errors.New("currency not allowed for operator")
+	ErrCurrencyMismatch    = errors.New("currency mismatch")
+	ErrBetLimitExceeded    = errors.New("bet amount outside of operator limits")
+	ErrConcurrentUpdate    = errors.New("player was updated concurrently, retry the request")
+
+	ErrInvalidPlayerLimit  = errors.New("limit must be a number between 1 and 500")
+	ErrInvalidPlayerCursor = errors.New("cursor is invalid or belongs to another sort")
+	ErrInvalidPlayerSort   = errors.New("sort must be created_at, balance or id, optionally prefixed with -")
+	ErrInvalidPlayerStatus = errors.New("status must be active, suspended or closed")
+	ErrInvalidPlayerFilter = errors.New("min_balance and max_balance must be numbers, created_from and created_to RFC3339 times")
+)
+
+const (
+	playerListDefaultLimit = 50
+	playerListMaxLimit     = 500
+)
+
+// BalanceConcurrency is how the transactions of a player are kept from
+// overwriting each other's balance changes
+type BalanceConcurrency string
+
+const (
+	// BalanceConcurrencyLocking locks the player and the transactions of the
+	// round with SELECT ... FOR UPDATE, transactions of a player queue up
+	BalanceConcurrencyLocking BalanceConcurrency = "locking"
+	// BalanceConcurrencyOptimistic reads without locks and updates the balance
+	// only when the player version is unchanged, a transaction that lost is run again
+	BalanceConcurrencyOptimistic BalanceConcurrency = "optimistic"
 )
 
+func (c BalanceConcurrency) IsValid() bool {
+	switch c {
+	case BalanceConcurrencyLocking, BalanceConcurrencyOptimistic:
+		return true
+	}
+	return false
+}
+
+// ListPlayersRequest holds the query parameters of the players listing as sent by the client
+type ListPlayersRequest struct {
+	OperatorID string
+	Limit      string
+	Cursor     string
+	// Sort is a sort field, a leading minus sorts descending
+	Sort         string
+	Currency     string
+	Status       string
+	MinBalance   string
+	MaxBalance   string
+	CreatedFrom  string
+	CreatedTo    string
+	IncludeTotal bool
+}
+
 type IWalletService interface {
-	GetPlayerBalance(playerID string) (*entities.Player, error)
-	GetAllPlayers() ([]*entities.Player, error)
-	ProcessTransaction(transaction *entities.Transaction) error
+	GetPlayerBalance(ctx context.Context, operatorID, playerID string) (*entities.Player, error)
+	// ListPlayers returns one page of the players of the operator
+	ListPlayers(ctx context.Context, request *ListPlayersRequest) (*entities.PlayerPage, error)
+	ProcessTransaction(ctx context.Context, transaction *entities.Transaction) error
 }
 
 type WalletService struct {
-	playerRepo      repository.IPlayerRepository
-	transactionRepo repository.ITransactionRepository
-	gormRepository  repository.IGormRepository
+	playerRepo   repository.IPlayerRepository
+	operatorRepo repository.IOperatorRepository
+	unitOfWork   repository.IUnitOfWork
+	balanceCache cache.IBalanceCache
+	concurrency  BalanceConcurrency
 }
 
-func NewWalletService(playerRepo repository.IPlayerRepository, transactionRepo repository.ITransactionRepository, gormRepository repository.IGormRepository) IWalletService {
+func NewWalletService(playerRepo repository.IPlayerRepository, operatorRepo repository.IOperatorRepository, unitOfWork repository.IUnitOfWork, balanceCache cache.IBalanceCache, concurrency BalanceConcurrency) IWalletService {
 	return &WalletService{
-		playerRepo:      playerRepo,
-		transactionRepo: transactionRepo,
-		gormRepository:  gormRepository,
+		playerRepo:   playerRepo,
+		operatorRepo: operatorRepo,
+		unitOfWork:   unitOfWork,
+		balanceCache: balanceCache,
+		concurrency:  concurrency,
 	}
 }
 
-func (s *WalletService) GetPlayerBalance(playerID string) (*entities.Player, error) {
-	zap.L().Debug("Querying player balance", zap.String("player_id", playerID))
+func (s *WalletService) GetPlayerBalance(ctx context.Context, operatorID, playerID string) (*entities.Player, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "WalletService.GetPlayerBalance",
+		trace.WithAttributes(
+			attribute.String("operator_id", operatorID),
+			attribute.String("player_id", playerID),
+		))
+	defer span.End()
+
+	zap.L().Debug("Querying player balance",
+		zap.String("operator_id", operatorID),
+		zap.String("player_id", playerID))
 
-	player, err := s.playerRepo.GetByID(playerID, nil)
+	player, err := s.balanceCache.Get(ctx, operatorID, playerID, func(ctx context.Context) (*entities.Player, error) {
+		return s.playerRepo.GetByID(ctx, operatorID, playerID, nil)
+	})
 	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
 		zap.L().Error("Error while querying player balance",
+			zap.String("operator_id", operatorID),
 			zap.String("player_id", playerID),
 			zap.Error(err))
 		return nil, fmt.Errorf("player not found: %w", err)
@@ -57,103 +141,360 @@ func (s *WalletService) GetPlayerBalance(playerID string) (*entities.Player, err
 	return player, nil
 }
 
-func (s *WalletService) GetAllPlayers() ([]*entities.Player, error) {
-	zap.L().Debug("Listing all players")
+func (s *WalletService) ListPlayers(ctx context.Context, request *ListPlayersRequest) (*entities.PlayerPage, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "WalletService.ListPlayers",
+		trace.WithAttributes(attribute.String("operator_id", request.OperatorID)))
+	defer span.End()
+
+	limit := playerListDefaultLimit
+	if request.Limit != "" {
+		var err error
+		if limit, err = strconv.Atoi(request.Limit); err != nil || limit < 1 || limit > playerListMaxLimit {
+			return nil, ErrInvalidPlayerLimit
+		}
+	}
+	sort := request.Sort
+	if sort == "" {
+		sort = string(entities.PlayerSortCreatedAt)
+	}
+	order := entities.PlayerOrder{Field: entities.PlayerSortField(strings.TrimPrefix(sort, "-")), Descending: strings.HasPrefix(sort, "-")}
+	if !order.Field.IsValid() {
+		return nil, ErrInvalidPlayerSort
+	}
+	var after *entities.PlayerCursor
+	if request.Cursor != "" {
+		var err error
+		if after, err = decodePlayerCursor(request.Cursor, sort); err != nil {
+			return nil, ErrInvalidPlayerCursor
+		}
+	}
+	filter, err := parsePlayerListFilter(request)
+	if err != nil {
+		return nil, err
+	}
 
-	players, err := s.playerRepo.GetAll(nil)
+	// One extra player tells whether there is a next page
+	players, err := s.playerRepo.List(ctx, filter, order, after, limit+1, nil)
 	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
 		zap.L().Error("Error while listing players", zap.Error(err))
 		return nil, err
 	}
+	page := &entities.PlayerPage{Players: players}
+	if len(players) > limit {
+		page.Players = players[:limit]
+		last := page.Players[limit-1]
+		page.NextCursor = encodePlayerCursor(sort, &entities.PlayerCursor{CreatedAt: last.CreatedAt, Balance: last.Balance, ID: last.ID})
+	}
+	if request.IncludeTotal {
+		count, err := s.playerRepo.Count(ctx, filter, nil)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			zap.L().Error("Error while counting players", zap.Error(err))
+			return nil, err
+		}
+		page.TotalCount = &count
+	}
+
+	zap.L().Debug("Players listed",
+		zap.Int("player_count", len(page.Players)),
+		zap.Bool("has_more", page.NextCursor != ""))
+	return page, nil
+}
+
+func parsePlayerListFilter(request *ListPlayersRequest) (*entities.PlayerListFilter, error) {
+	filter := &entities.PlayerListFilter{
+		OperatorID: request.OperatorID,
+		Currency:   request.Currency,
+		Status:     entities.PlayerStatus(request.Status),
+	}
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return nil, ErrInvalidPlayerStatus
+	}
+	for _, bound := range []struct {
+		value  string
+		target **float64
+	}{{request.MinBalance, &filter.MinBalance}, {request.MaxBalance, &filter.MaxBalance}} {
+		if bound.value == "" {
+			continue
+		}
+		amount, err := strconv.ParseFloat(bound.value, 64)
+		if err != nil {
+			return nil, ErrInvalidPlayerFilter
+		}
+		*bound.target = &amount
+	}
+	for _, bound := range []struct {
+		value  string
+		target **time.Time
+	}{{request.CreatedFrom, &filter.CreatedFrom}, {request.CreatedTo, &filter.CreatedTo}} {
+		if bound.value == "" {
+			continue
+		}
+		at, err := time.Parse(time.RFC3339Nano, bound.value)
+		if err != nil {
+			return nil, ErrInvalidPlayerFilter
+		}
+		*bound.target = &at
+	}
+	return filter, nil
+}
+
+// playerCursor is the encoded form of a cursor, it carries the sort it was
+// made for so a cursor is not applied to another order
+type playerCursor struct {
+	Sort      string    `json:"s"`
+	CreatedAt time.Time `json:"c"`
+	Balance   float64   `json:"b"`
+	ID        string    `json:"id"`
+}
+
+func encodePlayerCursor(sort string, cursor *entities.PlayerCursor) string {
+	data, _ := json.Marshal(playerCursor{Sort: sort, CreatedAt: cursor.CreatedAt, Balance: cursor.Balance, ID: cursor.ID})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodePlayerCursor(value, sort string) (*entities.PlayerCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	var cursor playerCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, err
+	}
+	if cursor.Sort != sort || cursor.ID == "" {
+		return nil, ErrInvalidPlayerCursor
+	}
+	return &entities.PlayerCursor{CreatedAt: cursor.CreatedAt, Balance: cursor.Balance, ID: cursor.ID}, nil
+}
+
+func (s *WalletService) ProcessTransaction(ctx context.Context, transaction *entities.Transaction) error {
+	ctx, span := tracing.Tracer().Start(ctx, "WalletService.ProcessTransaction",
+		trace.WithAttributes(
+			attribute.String("operator_id", transaction.OperatorID),
+			attribute.String("player_id", transaction.PlayerID),
+			attribute.String("req_id", transaction.ReqID),
+			attribute.String("round_id", transaction.RoundID),
+			attribute.String("type", string(transaction.Type)),
+		))
+	defer span.End()
+
+	start := time.Now()
+	err := s.processTransaction(ctx, transaction)
+
+	outcome := TransactionOutcome(err)
+	switch outcome {
+	// Unknown errors, timeouts and cancellations may come after the commit, so they invalidate as well
+	case "success", "error", "timeout", "canceled":
+		s.balanceCache.Invalidate(ctx, transaction.OperatorID, transaction.PlayerID)
+	}
+	span.SetAttributes(attribute.String("outcome", outcome))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, outcome)
+	}
+	metrics.TransactionsProcessed.WithLabelValues(string(transaction.Type), outcome).Inc()
+	metrics.TransactionProcessingDuration.WithLabelValues(string(transaction.Type), outcome).Observe(time.Since(start).Seconds())
+	return err
+}
 
-	zap.L().Info("All players listed successfully",
-		zap.Int("player_count", len(players)))
-	return players, nil
+// TransactionOutcome maps the result of ProcessTransaction to a bounded metric label
+func TransactionOutcome(err error) string {
+	switch {
+	case err == nil:
+		return "success"
+	case errors.Is(err, ErrInsufficientBalance):
+		return "insufficient_balance"
+	case errors.Is(err, ErrDuplicateRequest):
+		return "duplicate_request"
+	case errors.Is(err, ErrDuplicateRound):
+		return "duplicate_round"
+	case errors.Is(err, ErrBetNotFound):
+		return "bet_not_found"
+	case errors.Is(err, ErrGameCodeMismatch):
+		return "game_code_mismatch"
+	case errors.Is(err, ErrWalletIDMismatch):
+		return "wallet_id_mismatch"
+	case errors.Is(err, ErrPlayerIDMismatch):
+		return "player_id_mismatch"
+	case errors.Is(err, ErrInvalidRequest):
+		return "invalid_request"
+	case errors.Is(err, ErrOperatorNotFound):
+		return "operator_not_found"
+	case errors.Is(err, ErrCurrencyNotAllowed):
+		return "currency_not_allowed"
+	case errors.Is(err, ErrCurrencyMismatch):
+		return "currency_mismatch"
+	case errors.Is(err, ErrBetLimitExceeded):
+		return "bet_limit_exceeded"
+	case errors.Is(err, ErrConcurrentUpdate):
+		return "concurrent_update"
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return "player_not_found"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	default:
+		return "error"
+	}
 }
 
-func (s *WalletService) ProcessTransaction(transaction *entities.Transaction) error {
+func (s *WalletService) processTransaction(ctx context.Context, transaction *entities.Transaction) error {
 	zap.L().Debug("Processing transaction",
+		zap.String("operator_id", transaction.OperatorID),
 		zap.String("req_id", transaction.ReqID),
 		zap.String("type", string(transaction.Type)),
 		zap.String("player_id", transaction.PlayerID))
 
-	// Transaction'ı SERIALIZABLE izolasyon seviyesinde başlat
-	tx, err := s.gormRepository.StartTransaction()
+	operator, err := s.operatorRepo.GetByID(ctx, transaction.OperatorID, nil)
 	if err != nil {
-		zap.L().Error("Error while starting transaction", zap.Error(err))
+		zap.L().Error("Error while loading operator",
+			zap.String("operator_id", transaction.OperatorID),
+			zap.Error(err))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrOperatorNotFound
+		}
 		return err
 	}
+	if !operator.SupportsCurrency(transaction.Currency) {
+		zap.L().Warn("Currency not allowed for operator",
+			zap.String("operator_id", transaction.OperatorID),
+			zap.String("currency", transaction.Currency))
+		return ErrCurrencyNotAllowed
+	}
+	if transaction.Type == entities.TransactionTypeBet && !operator.IsBetAllowed(transaction.Amount) {
+		zap.L().Warn("Bet amount outside of operator limits",
+			zap.String("operator_id", transaction.OperatorID),
+			zap.Float64("amount", transaction.Amount),
+			zap.Float64("min_bet", operator.MinBet),
+			zap.Float64("max_bet", operator.MaxBet))
+		return ErrBetLimitExceeded
+	}
+
+	// The unit of work is bound to the request context and runs again when
+	// Postgres aborts it with a serialization failure or a deadlock, or when an
+	// optimistic balance update finds the player changed
+	err = s.unitOfWork.Do(ctx, func(repos repository.Repositories) error {
+		// A previous attempt may have assigned an ID before its commit failed
+		transaction.ID = 0
+		return s.applyTransaction(ctx, transaction, repos)
+	})
+	if errors.Is(err, repository.ErrVersionConflict) {
+		zap.L().Warn("Giving up on transaction after concurrent updates of the player",
+			zap.String("operator_id", transaction.OperatorID),
+			zap.String("player_id", transaction.PlayerID),
+			zap.String("req_id", transaction.ReqID))
+		return ErrConcurrentUpdate
+	}
+	return err
+}
+
+// applyTransaction validates the event against the player and round and stores
+// it. Lookups that fail for any reason other than a missing row are returned
+// as is, so the runner can recognise aborted transactions.
+//
+// In the locking mode the player and the transactions of the round stay locked
+// until the commit. In the optimistic mode nothing is locked while the event is
+// validated, every stored transaction changes the balance and with it the
+// player version, so the conditional balance update at the end fails when any
+// of the rows read has changed since.
+func (s *WalletService) applyTransaction(ctx context.Context, transaction *entities.Transaction, repos repository.Repositories) error {
+	optimistic := s.concurrency == BalanceConcurrencyOptimistic
 
 	// Check for duplicate request
-	existingTx, err := s.transactionRepo.GetByReqIDWithLock(transaction.ReqID, tx)
+	getByReqID := repos.Transactions.GetByReqIDWithLock
+	if optimistic {
+		getByReqID = repos.Transactions.GetByReqID
+	}
+	existingTx, err := getByReqID(ctx, transaction.OperatorID, transaction.ReqID, nil)
 	if err == nil && existingTx != nil {
 		zap.L().Warn("Duplicate request detected",
 			zap.String("req_id", transaction.ReqID))
-		s.gormRepository.RollbackTransaction(tx)
 		return ErrDuplicateRequest
 	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
 
-	// SELECT FOR UPDATE ile player'ı kilitle
-	player, err := s.playerRepo.GetByIDWithLock(transaction.PlayerID, tx)
+	// Lock the player with SELECT FOR UPDATE, the optimistic mode only reads its version
+	getPlayer := repos.Players.GetByIDWithLock
+	if optimistic {
+		getPlayer = repos.Players.GetByID
+	}
+	player, err := getPlayer(ctx, transaction.OperatorID, transaction.PlayerID, nil)
 	if err != nil {
 		zap.L().Error("Player not found",
+			zap.String("operator_id", transaction.OperatorID),
 			zap.String("player_id", transaction.PlayerID),
 			zap.Error(err))
-		s.gormRepository.RollbackTransaction(tx)
 		return fmt.Errorf("player not found: %w", err)
 	}
 
+	if player.Currency != transaction.Currency {
+		zap.L().Warn("Currency mismatch between player and transaction",
+			zap.String("player_currency", player.Currency),
+			zap.String("transaction_currency", transaction.Currency))
+		return ErrCurrencyMismatch
+	}
+
+	getByRound := repos.Transactions.GetByRoundIDAndPlayerIDAndWalletIDWithLock
+	if optimistic {
+		getByRound = repos.Transactions.GetByRoundIDAndPlayerIDAndWalletID
+	}
+
+	var balanceChange float64
 	switch transaction.Type {
 	case entities.TransactionTypeBet:
 		// Check for existing bet with same round_id, player_id and wallet_id
-		existingBet, err := s.transactionRepo.GetByRoundIDAndPlayerIDAndWalletIDWithLock(
+		existingBet, err := getByRound(
+			ctx,
+			transaction.OperatorID,
 			transaction.RoundID,
 			transaction.WalletID,
 			entities.TransactionTypeBet,
-			tx,
+			nil,
 		)
 		if err == nil && existingBet != nil {
 			zap.L().Warn("Duplicate round detected for bet",
 				zap.String("round_id", transaction.RoundID),
 				zap.String("wallet_id", transaction.WalletID))
-			s.gormRepository.RollbackTransaction(tx)
 			return ErrDuplicateRound
 		}
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
 
 		if player.Balance < transaction.Amount {
 			zap.L().Warn("Insufficient balance",
 				zap.String("player_id", transaction.PlayerID),
 				zap.Float64("current_balance", player.Balance),
 				zap.Float64("requested_amount", transaction.Amount))
-			s.gormRepository.RollbackTransaction(tx)
 			return ErrInsufficientBalance
 		}
-		if err := s.playerRepo.UpdateBalance(player.ID, -transaction.Amount, tx); err != nil {
-			zap.L().Error("Error while updating balance",
-				zap.String("player_id", transaction.PlayerID),
-				zap.Float64("amount", transaction.Amount),
-				zap.Error(err))
-			s.gormRepository.RollbackTransaction(tx)
-			return fmt.Errorf("balance update failed: %w", err)
-		}
-		zap.L().Info("Bet transaction completed successfully",
-			zap.String("player_id", transaction.PlayerID),
-			zap.Float64("amount", transaction.Amount))
+		balanceChange = -transaction.Amount
 
 	case entities.TransactionTypeResult:
 		// Check for bet transaction with same round_id, player_id and wallet_id
-		betTx, err := s.transactionRepo.GetByRoundIDAndPlayerIDAndWalletIDWithLock(
+		betTx, err := getByRound(
+			ctx,
+			transaction.OperatorID,
 			transaction.RoundID,
 			transaction.WalletID,
 			entities.TransactionTypeBet,
-			tx,
+			nil,
 		)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
 		if err != nil || betTx == nil {
 			zap.L().Warn("Bet not found",
 				zap.String("round_id", transaction.RoundID),
 				zap.String("player_id", transaction.PlayerID),
 				zap.String("wallet_id", transaction.WalletID))
-			s.gormRepository.RollbackTransaction(tx)
 			return ErrBetNotFound
 		}
 
@@ -162,7 +503,6 @@ func (s *WalletService) ProcessTransaction(transaction *entities.Transaction) er
 			zap.L().Warn("Game code mismatch between bet and result",
 				zap.String("bet_game_code", betTx.GameCode),
 				zap.String("result_game_code", transaction.GameCode))
-			s.gormRepository.RollbackTransaction(tx)
 			return ErrGameCodeMismatch
 		}
 
@@ -170,7 +510,6 @@ func (s *WalletService) ProcessTransaction(transaction *entities.Transaction) er
 			zap.L().Warn("Wallet ID mismatch between bet and result",
 				zap.String("bet_wallet_id", betTx.WalletID),
 				zap.String("result_wallet_id", transaction.WalletID))
-			s.gormRepository.RollbackTransaction(tx)
 			return ErrWalletIDMismatch
 		}
 
@@ -178,61 +517,163 @@ func (s *WalletService) ProcessTransaction(transaction *entities.Transaction) er
 			zap.L().Warn("Player ID mismatch between bet and result",
 				zap.String("bet_player_id", betTx.PlayerID),
 				zap.String("result_player_id", transaction.PlayerID))
-			s.gormRepository.RollbackTransaction(tx)
 			return ErrPlayerIDMismatch
 		}
 
 		// Check for existing result with same round_id
-		existingResult, err := s.transactionRepo.GetByRoundIDAndPlayerIDAndWalletIDWithLock(
+		existingResult, err := getByRound(
+			ctx,
+			transaction.OperatorID,
 			transaction.RoundID,
 			transaction.WalletID,
 			entities.TransactionTypeResult,
-			tx,
+			nil,
 		)
 		if err == nil && existingResult != nil {
 			zap.L().Warn("Duplicate round detected for result",
 				zap.String("round_id", transaction.RoundID),
 				zap.String("player_id", transaction.PlayerID),
 				zap.String("wallet_id", transaction.WalletID))
-			s.gormRepository.RollbackTransaction(tx)
 			return ErrDuplicateRound
 		}
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
 
-		if transaction.Amount > 0 {
-			if err := s.playerRepo.UpdateBalance(player.ID, transaction.Amount, tx); err != nil {
-				zap.L().Error("Error while updating balance during win transaction",
-					zap.String("player_id", transaction.PlayerID),
-					zap.Float64("amount", transaction.Amount),
-					zap.Error(err))
-				s.gormRepository.RollbackTransaction(tx)
-				return fmt.Errorf("balance update failed: %w", err)
-			}
-		} else {
+		if transaction.Amount <= 0 {
 			zap.L().Info("Balance update is not allowed, amount is 0",
 				zap.String("player_id", transaction.PlayerID),
 				zap.Float64("amount", transaction.Amount))
-			s.gormRepository.RollbackTransaction(tx)
 			return ErrInvalidRequest
 		}
+		balanceChange = transaction.Amount
+	}
 
-		zap.L().Info("Win transaction completed successfully",
-			zap.String("player_id", transaction.PlayerID),
-			zap.Float64("amount", transaction.Amount))
+	// The optimistic mode changes the balance last, the row lock the update
+	// takes is then only held for the commit
+	if !optimistic {
+		if err := s.updateBalance(ctx, repos, transaction, player, balanceChange); err != nil {
+			return err
+		}
 	}
 
-	// Save transaction
-	if err := s.transactionRepo.Create(transaction, tx); err != nil {
+	// Save transaction. The lookup above misses request IDs of archived
+	// transactions and of concurrent requests, the unique constraint catches them.
+	if err := repos.Transactions.Create(ctx, transaction, nil); err != nil {
+		if repository.IsDuplicateReqID(err) {
+			zap.L().Warn("Duplicate request detected",
+				zap.String("req_id", transaction.ReqID))
+			return ErrDuplicateRequest
+		}
 		zap.L().Error("Error while saving transaction",
 			zap.String("req_id", transaction.ReqID),
 			zap.Error(err))
-		s.gormRepository.RollbackTransaction(tx)
 		return err
 	}
 
-	if err := s.gormRepository.FinishTransaction(tx, err); err != nil {
-		zap.L().Error("Error while finishing transaction", zap.Error(err))
+	// The event is committed together with the transaction, so downstream
+	// systems are told about exactly the transactions that were stored
+	balanceAfter := player.Balance + balanceChange
+	event, err := entities.NewTransactionOutboxEvent(transaction, balanceAfter)
+	if err != nil {
+		return err
+	}
+	if err := repos.Outbox.Create(ctx, event, nil); err != nil {
+		zap.L().Error("Error while saving outbox event",
+			zap.String("req_id", transaction.ReqID),
+			zap.Error(err))
+		return err
+	}
+
+	if err := s.enqueueWebhooks(ctx, repos, transaction, event, player, balanceAfter); err != nil {
+		return err
+	}
+
+	// Balance streams of every replica are told about the change once it commits
+	notification, err := json.Marshal(entities.BalanceNotification{
+		OperatorID:      player.OperatorID,
+		PlayerID:        player.ID,
+		WalletID:        player.WalletID,
+		Balance:         balanceAfter,
+		Currency:        player.Currency,
+		TransactionID:   transaction.ID,
+		TransactionType: transaction.Type,
+		RoundID:         transaction.RoundID,
+		Amount:          transaction.Amount,
+		UpdatedAt:       transaction.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+	if err := repos.Notifications.Notify(ctx, entities.BalanceNotificationChannel, notification, nil); err != nil {
+		return err
+	}
+
+	if optimistic {
+		if err := s.updateBalance(ctx, repos, transaction, player, balanceChange); err != nil {
+			return err
+		}
+	}
+	zap.L().Info("Transaction completed successfully",
+		zap.String("player_id", transaction.PlayerID),
+		zap.String("type", string(transaction.Type)),
+		zap.Float64("amount", transaction.Amount))
+	return nil
+}
+
+// updateBalance adds amount to the balance of the player. In the optimistic
+// mode it only does so while the player is at the version it was read at.
+func (s *WalletService) updateBalance(ctx context.Context, repos repository.Repositories, transaction *entities.Transaction, player *entities.Player, amount float64) error {
+	var err error
+	if s.concurrency == BalanceConcurrencyOptimistic {
+		err = repos.Players.UpdateBalanceIfVersion(ctx, player.OperatorID, player.ID, player.Version, amount, nil)
+	} else {
+		err = repos.Players.UpdateBalance(ctx, player.OperatorID, player.ID, amount, nil)
+	}
+	if errors.Is(err, repository.ErrVersionConflict) {
+		zap.L().Debug("Player changed since it was read",
+			zap.String("player_id", transaction.PlayerID),
+			zap.Int64("version", player.Version))
+		return err
+	}
+	if err != nil {
+		zap.L().Error("Error while updating balance",
+			zap.String("player_id", transaction.PlayerID),
+			zap.String("type", string(transaction.Type)),
+			zap.Float64("amount", transaction.Amount),
+			zap.Error(err))
+		return fmt.Errorf("balance update failed: %w", err)
+	}
+	return nil
+}
+
+// enqueueWebhooks creates the webhook deliveries of a processed transaction in
+// the same database transaction, so partners are only notified of committed changes
+func (s *WalletService) enqueueWebhooks(ctx context.Context, repos repository.Repositories, transaction *entities.Transaction, event *entities.OutboxEvent, player *entities.Player, balanceAfter float64) error {
+	if _, err := repos.Webhooks.EnqueueDeliveries(ctx, transaction.OperatorID, event.EventType, event.Payload, nil); err != nil {
+		zap.L().Error("Error while enqueuing webhook deliveries",
+			zap.String("req_id", transaction.ReqID),
+			zap.Error(err))
 		return err
 	}
 
+	balancePayload, err := json.Marshal(entities.BalanceEvent{
+		OperatorID:    player.OperatorID,
+		PlayerID:      player.ID,
+		WalletID:      player.WalletID,
+		Balance:       balanceAfter,
+		Currency:      player.Currency,
+		TransactionID: transaction.ID,
+		UpdatedAt:     transaction.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := repos.Webhooks.EnqueueDeliveries(ctx, transaction.OperatorID, entities.EventTypeBalanceUpdated, balancePayload, nil); err != nil {
+		zap.L().Error("Error while enqueuing webhook deliveries",
+			zap.String("req_id", transaction.ReqID),
+			zap.Error(err))
+		return err
+	}
 	return nil
 }
@@ -0,0 +1,135 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/cache"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/repository"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/service"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// archivedTransactions hides the transactions of the archived request IDs from
+// the lookups, like transactions moved out of their partition. createErr
+// replaces the error storing a transaction fails with when it is set.
+type archivedTransactions struct {
+	repository.ITransactionRepository
+	archived  map[string]bool
+	createErr error
+}
+
+func (r *archivedTransactions) GetByReqID(ctx context.Context, operatorID, reqID string, outTx *gorm.DB) (*entities.Transaction, error) {
+	if r.archived[reqID] {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return r.ITransactionRepository.GetByReqID(ctx, operatorID, reqID, outTx)
+}
+
+func (r *archivedTransactions) GetByReqIDWithLock(ctx context.Context, operatorID, reqID string, outTx *gorm.DB) (*entities.Transaction, error) {
+	if r.archived[reqID] {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return r.ITransactionRepository.GetByReqIDWithLock(ctx, operatorID, reqID, outTx)
+}
+
+func (r *archivedTransactions) GetByRoundIDAndPlayerIDAndWalletID(ctx context.Context, operatorID, roundID, walletID string, transactionType entities.TransactionType, outTx *gorm.DB) (*entities.Transaction, error) {
+	return r.visible(r.ITransactionRepository.GetByRoundIDAndPlayerIDAndWalletID(ctx, operatorID, roundID, walletID, transactionType, outTx))
+}
+
+func (r *archivedTransactions) GetByRoundIDAndPlayerIDAndWalletIDWithLock(ctx context.Context, operatorID, roundID, walletID string, transactionType entities.TransactionType, outTx *gorm.DB) (*entities.Transaction, error) {
+	return r.visible(r.ITransactionRepository.GetByRoundIDAndPlayerIDAndWalletIDWithLock(ctx, operatorID, roundID, walletID, transactionType, outTx))
+}
+
+func (r *archivedTransactions) visible(transaction *entities.Transaction, err error) (*entities.Transaction, error) {
+	if err == nil && r.archived[transaction.ReqID] {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return transaction, err
+}
+
+func (r *archivedTransactions) Create(ctx context.Context, transaction *entities.Transaction, outTx *gorm.DB) error {
+	if err := r.ITransactionRepository.Create(ctx, transaction, outTx); err != nil {
+		if r.createErr != nil {
+			return r.createErr
+		}
+		return err
+	}
+	return nil
+}
+
+type archivedUnitOfWork struct {
+	repository.IUnitOfWork
+	transactions *archivedTransactions
+}
+
+func (u *archivedUnitOfWork) Do(ctx context.Context, fn repository.UnitOfWorkFunc) error {
+	return u.IUnitOfWork.Do(ctx, func(repos repository.Repositories) error {
+		u.transactions.ITransactionRepository = repos.Transactions
+		repos.Transactions = u.transactions
+		return fn(repos)
+	})
+}
+
+func TestProcessTransactionRejectsArchivedReqID(t *testing.T) {
+	tests := []struct {
+		name      string
+		createErr error
+	}{
+		{name: "memory"},
+		{name: "postgres", createErr: &pgconn.PgError{Code: "23505", ConstraintName: "transactions_operator_req_key"}},
+	}
+	for _, tt := range tests {
+		for _, concurrency := range []service.BalanceConcurrency{service.BalanceConcurrencyLocking, service.BalanceConcurrencyOptimistic} {
+			t.Run(tt.name+"/"+string(concurrency), func(t *testing.T) {
+				ctx := context.Background()
+				store := repository.NewMemoryStore()
+				playerRepo := repository.NewMemoryPlayerRepository(store)
+				player := &entities.Player{
+					OperatorID: entities.DefaultOperatorID,
+					ID:         "player-1",
+					WalletID:   "wallet-1",
+					Balance:    100,
+					Currency:   "INR",
+				}
+				require.NoError(t, playerRepo.Create(ctx, player, nil))
+
+				transactions := &archivedTransactions{archived: make(map[string]bool)}
+				unitOfWork := &archivedUnitOfWork{
+					IUnitOfWork:  repository.NewMemoryUnitOfWork(store),
+					transactions: transactions,
+				}
+				walletService := service.NewWalletService(playerRepo, repository.NewMemoryOperatorRepository(store), unitOfWork, cache.NewNopBalanceCache(), concurrency)
+
+				bet := func() *entities.Transaction {
+					return &entities.Transaction{
+						OperatorID: player.OperatorID,
+						ReqID:      "req-1",
+						PlayerID:   player.ID,
+						WalletID:   player.WalletID,
+						RoundID:    "round-1",
+						SessionID:  "session-1",
+						GameCode:   "game-1",
+						Type:       entities.TransactionTypeBet,
+						Amount:     10,
+						Currency:   player.Currency,
+					}
+				}
+				require.NoError(t, walletService.ProcessTransaction(ctx, bet()))
+
+				transactions.archived["req-1"] = true
+				transactions.createErr = tt.createErr
+				err := walletService.ProcessTransaction(ctx, bet())
+				assert.ErrorIs(t, err, service.ErrDuplicateRequest)
+
+				stored, err := playerRepo.GetByID(ctx, player.OperatorID, player.ID, nil)
+				require.NoError(t, err)
+				assert.Equal(t, 90.0, stored.Balance)
+			})
+		}
+	}
+}
@@ -0,0 +1,256 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/metrics"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/repository"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/utils/signature"
+	"go.uber.org/zap"
+)
+
+const (
+	HeaderWebhookID        = "X-Webhook-Id"
+	HeaderWebhookEvent     = "X-Webhook-Event"
+	HeaderWebhookTimestamp = "X-Webhook-Timestamp"
+	HeaderWebhookSignature = "X-Webhook-Signature"
+)
+
+type WebhookDispatcherConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	Concurrency  int
+	// Timeout bounds one HTTP request. A batch is sent in waves of Concurrency,
+	// so claimed deliveries are leased for one Timeout per wave plus one more.
+	Timeout        time.Duration
+	MaxAttempts    int
+	RetryBaseDelay time.Duration
+	RetryMaxDelay  time.Duration
+}
+
+// webhookBody is what the endpoint receives. The id stays the same across
+// attempts, so receivers can deduplicate retried deliveries.
+type webhookBody struct {
+	ID         uint64          `json:"id"`
+	Type       string          `json:"type"`
+	OperatorID string          `json:"operator_id"`
+	CreatedAt  time.Time       `json:"created_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// WebhookDispatcher sends pending webhook deliveries. Deliveries are claimed
+// in a short transaction and sent outside of it, a dispatcher that dies while
+// sending leaves the delivery to be picked up again once its lease ran out.
+type WebhookDispatcher struct {
+	unitOfWork  repository.IUnitOfWork
+	webhookRepo repository.IWebhookRepository
+	client      *http.Client
+	config      WebhookDispatcherConfig
+}
+
+func NewWebhookDispatcher(unitOfWork repository.IUnitOfWork, webhookRepo repository.IWebhookRepository, config WebhookDispatcherConfig) *WebhookDispatcher {
+	if config.Concurrency < 1 {
+		config.Concurrency = 1
+	}
+	return &WebhookDispatcher{
+		unitOfWork:  unitOfWork,
+		webhookRepo: webhookRepo,
+		client: &http.Client{
+			Timeout: config.Timeout,
+			// A redirect is reported as a failed delivery instead of being followed
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		config: config,
+	}
+}
+
+// Run sends webhook deliveries until ctx is cancelled
+func (d *WebhookDispatcher) Run(ctx context.Context) {
+	zap.L().Info("Webhook dispatcher started")
+	ticker := time.NewTicker(d.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		claimed, err := d.dispatchBatch(ctx)
+		if err != nil && ctx.Err() == nil {
+			zap.L().Error("Error while dispatching webhooks", zap.Error(err))
+		}
+
+		if err == nil && claimed == d.config.BatchSize && ctx.Err() == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			zap.L().Info("Webhook dispatcher stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *WebhookDispatcher) dispatchBatch(ctx context.Context) (int, error) {
+	var deliveries []*entities.WebhookDelivery
+	err := d.unitOfWork.Do(ctx, func(repos repository.Repositories) error {
+		now := time.Now()
+		var err error
+		deliveries, err = repos.Webhooks.ClaimDueDeliveries(ctx, now, now.Add(d.leaseDuration()), d.config.BatchSize, nil)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	semaphore := make(chan struct{}, d.config.Concurrency)
+	var wg sync.WaitGroup
+	for _, delivery := range deliveries {
+		semaphore <- struct{}{}
+		wg.Add(1)
+		go func(delivery *entities.WebhookDelivery) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			d.deliver(ctx, delivery)
+		}(delivery)
+	}
+	wg.Wait()
+	return len(deliveries), nil
+}
+
+// leaseDuration covers sending a full batch, the last wave starts only after
+// the ones before it timed out
+func (d *WebhookDispatcher) leaseDuration() time.Duration {
+	waves := (d.config.BatchSize + d.config.Concurrency - 1) / d.config.Concurrency
+	return time.Duration(waves+1) * d.config.Timeout
+}
+
+func (d *WebhookDispatcher) deliver(ctx context.Context, delivery *entities.WebhookDelivery) {
+	// The claim stored the lease on the delivery, it is read back as stored
+	// so it compares equal when the attempt is recorded
+	leaseUntil := delivery.NextAttemptAt
+	start := time.Now()
+	statusCode, sendErr := d.send(ctx, delivery)
+	duration := time.Since(start)
+	if ctx.Err() != nil {
+		// Shutting down, the lease runs out and the delivery is sent again later
+		return
+	}
+	metrics.WebhookDeliveryDuration.Observe(duration.Seconds())
+
+	delivery.Attempts++
+	attempt := &entities.WebhookDeliveryAttempt{
+		DeliveryID: delivery.ID,
+		Attempt:    delivery.Attempts,
+		StatusCode: statusCode,
+		DurationMs: duration.Milliseconds(),
+		CreatedAt:  start,
+	}
+	if statusCode != 0 {
+		delivery.LastStatusCode = &statusCode
+	}
+
+	outcome := "delivered"
+	switch {
+	case sendErr == nil:
+		delivery.Status = entities.WebhookDeliveryStatusDelivered
+		deliveredAt := time.Now()
+		delivery.DeliveredAt = &deliveredAt
+		delivery.LastError = nil
+	case delivery.Attempts >= d.config.MaxAttempts:
+		outcome = "failed"
+		lastError := sendErr.Error()
+		attempt.Error = &lastError
+		delivery.Status = entities.WebhookDeliveryStatusFailed
+		delivery.LastError = &lastError
+	default:
+		outcome = "retry"
+		lastError := sendErr.Error()
+		attempt.Error = &lastError
+		delivery.Status = entities.WebhookDeliveryStatusPending
+		delivery.LastError = &lastError
+		delivery.NextAttemptAt = time.Now().Add(d.retryDelay(delivery.Attempts))
+	}
+	metrics.WebhookDeliveries.WithLabelValues(outcome).Inc()
+
+	if err := d.webhookRepo.RecordAttempt(ctx, delivery, attempt, leaseUntil, nil); err != nil {
+		if errors.Is(err, repository.ErrWebhookLeaseLost) {
+			zap.L().Warn("Webhook delivery was taken over while being sent, attempt not recorded",
+				zap.Uint64("delivery_id", delivery.ID))
+			return
+		}
+		zap.L().Error("Error while recording webhook delivery attempt",
+			zap.Uint64("delivery_id", delivery.ID),
+			zap.Error(err))
+		return
+	}
+	if outcome != "delivered" {
+		zap.L().Warn("Webhook delivery failed",
+			zap.Uint64("delivery_id", delivery.ID),
+			zap.Uint64("webhook_id", delivery.WebhookID),
+			zap.Int("attempts", delivery.Attempts),
+			zap.String("outcome", outcome),
+			zap.Error(sendErr))
+	}
+}
+
+// send posts the signed delivery and returns the response status, 0 when no
+// response was received. Any status outside of 2xx is an error.
+func (d *WebhookDispatcher) send(ctx context.Context, delivery *entities.WebhookDelivery) (int, error) {
+	if delivery.Webhook == nil {
+		return 0, ErrWebhookNotFound
+	}
+
+	body, err := json.Marshal(webhookBody{
+		ID:         delivery.ID,
+		Type:       delivery.EventType,
+		OperatorID: delivery.OperatorID,
+		CreatedAt:  delivery.CreatedAt,
+		Data:       delivery.Payload,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	deliveryID := strconv.FormatUint(delivery.ID, 10)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "casino-wallet-service-webhooks")
+	request.Header.Set(HeaderWebhookID, deliveryID)
+	request.Header.Set(HeaderWebhookEvent, delivery.EventType)
+	request.Header.Set(HeaderWebhookTimestamp, timestamp)
+	request.Header.Set(HeaderWebhookSignature, "sha256="+signature.Sign(delivery.Webhook.Secret, timestamp, deliveryID, body))
+
+	response, err := d.client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return response.StatusCode, fmt.Errorf("webhook endpoint responded with status %d", response.StatusCode)
+	}
+	return response.StatusCode, nil
+}
+
+// retryDelay doubles with every failed attempt up to RetryMaxDelay
+func (d *WebhookDispatcher) retryDelay(attempts int) time.Duration {
+	delay := d.config.RetryBaseDelay << (attempts - 1)
+	if delay <= 0 || delay > d.config.RetryMaxDelay {
+		return d.config.RetryMaxDelay
+	}
+	return delay
+}
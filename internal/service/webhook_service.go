@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/repository"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	webhookSecretPrefix      = "whsec_"
+	webhookSecretRandomBytes = 24
+	// webhookDeliveryListLimit caps the deliveries returned for a webhook
+	webhookDeliveryListLimit = 100
+)
+
+var (
+	ErrWebhookNotFound         = errors.New("webhook not found")
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+	ErrInvalidWebhookURL       = errors.New("webhook url must be an absolute http or https url")
+	ErrInvalidWebhookEventType = errors.New("invalid webhook event type")
+)
+
+type IWebhookService interface {
+	ListWebhooks(ctx context.Context, operatorID string) ([]*entities.Webhook, error)
+	// CreateWebhook returns the webhook and its signing secret, the secret is not shown again
+	CreateWebhook(ctx context.Context, webhook *entities.Webhook) (*entities.Webhook, string, error)
+	UpdateWebhook(ctx context.Context, webhook *entities.Webhook) (*entities.Webhook, error)
+	DeleteWebhook(ctx context.Context, operatorID string, id uint64) error
+	ListDeliveries(ctx context.Context, operatorID string, webhookID uint64) ([]*entities.WebhookDelivery, error)
+	GetDelivery(ctx context.Context, operatorID string, id uint64) (*entities.WebhookDelivery, error)
+	// Redeliver schedules the delivery for an immediate attempt, failed and
+	// delivered deliveries get exactly one more attempt
+	Redeliver(ctx context.Context, operatorID string, id uint64) (*entities.WebhookDelivery, error)
+}
+
+type WebhookService struct {
+	webhookRepo repository.IWebhookRepository
+}
+
+func NewWebhookService(webhookRepo repository.IWebhookRepository) IWebhookService {
+	return &WebhookService{
+		webhookRepo: webhookRepo,
+	}
+}
+
+func (s *WebhookService) ListWebhooks(ctx context.Context, operatorID string) ([]*entities.Webhook, error) {
+	return s.webhookRepo.GetAll(ctx, operatorID, nil)
+}
+
+func (s *WebhookService) CreateWebhook(ctx context.Context, webhook *entities.Webhook) (*entities.Webhook, string, error) {
+	if err := validateWebhook(webhook); err != nil {
+		return nil, "", err
+	}
+
+	randomBytes := make([]byte, webhookSecretRandomBytes)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return nil, "", fmt.Errorf("webhook secret generation failed: %w", err)
+	}
+	webhook.Secret = webhookSecretPrefix + hex.EncodeToString(randomBytes)
+
+	if err := s.webhookRepo.Create(ctx, webhook, nil); err != nil {
+		zap.L().Error("Error while saving webhook",
+			zap.String("operator_id", webhook.OperatorID),
+			zap.Error(err))
+		return nil, "", err
+	}
+
+	zap.L().Info("Webhook created",
+		zap.String("operator_id", webhook.OperatorID),
+		zap.Uint64("webhook_id", webhook.ID),
+		zap.Strings("event_types", webhook.EventTypes))
+	return webhook, webhook.Secret, nil
+}
+
+func (s *WebhookService) UpdateWebhook(ctx context.Context, webhook *entities.Webhook) (*entities.Webhook, error) {
+	if err := validateWebhook(webhook); err != nil {
+		return nil, err
+	}
+	if _, err := s.getWebhook(ctx, webhook.OperatorID, webhook.ID); err != nil {
+		return nil, err
+	}
+
+	if err := s.webhookRepo.Update(ctx, webhook, nil); err != nil {
+		zap.L().Error("Error while updating webhook",
+			zap.Uint64("webhook_id", webhook.ID),
+			zap.Error(err))
+		return nil, err
+	}
+
+	zap.L().Info("Webhook updated",
+		zap.String("operator_id", webhook.OperatorID),
+		zap.Uint64("webhook_id", webhook.ID))
+	return s.getWebhook(ctx, webhook.OperatorID, webhook.ID)
+}
+
+func (s *WebhookService) DeleteWebhook(ctx context.Context, operatorID string, id uint64) error {
+	if _, err := s.getWebhook(ctx, operatorID, id); err != nil {
+		return err
+	}
+	if err := s.webhookRepo.Delete(ctx, operatorID, id, nil); err != nil {
+		zap.L().Error("Error while deleting webhook",
+			zap.Uint64("webhook_id", id),
+			zap.Error(err))
+		return err
+	}
+
+	zap.L().Info("Webhook deleted",
+		zap.String("operator_id", operatorID),
+		zap.Uint64("webhook_id", id))
+	return nil
+}
+
+func (s *WebhookService) ListDeliveries(ctx context.Context, operatorID string, webhookID uint64) ([]*entities.WebhookDelivery, error) {
+	if _, err := s.getWebhook(ctx, operatorID, webhookID); err != nil {
+		return nil, err
+	}
+	return s.webhookRepo.GetDeliveries(ctx, operatorID, webhookID, webhookDeliveryListLimit, nil)
+}
+
+func (s *WebhookService) GetDelivery(ctx context.Context, operatorID string, id uint64) (*entities.WebhookDelivery, error) {
+	delivery, err := s.webhookRepo.GetDeliveryByID(ctx, operatorID, id, nil)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWebhookDeliveryNotFound
+		}
+		return nil, err
+	}
+	return delivery, nil
+}
+
+func (s *WebhookService) Redeliver(ctx context.Context, operatorID string, id uint64) (*entities.WebhookDelivery, error) {
+	if err := s.webhookRepo.Redeliver(ctx, operatorID, id, time.Now(), nil); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWebhookDeliveryNotFound
+		}
+		return nil, err
+	}
+
+	zap.L().Info("Webhook delivery scheduled for redelivery",
+		zap.String("operator_id", operatorID),
+		zap.Uint64("delivery_id", id))
+	return s.GetDelivery(ctx, operatorID, id)
+}
+
+func (s *WebhookService) getWebhook(ctx context.Context, operatorID string, id uint64) (*entities.Webhook, error) {
+	webhook, err := s.webhookRepo.GetByID(ctx, operatorID, id, nil)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWebhookNotFound
+		}
+		return nil, err
+	}
+	return webhook, nil
+}
+
+func validateWebhook(webhook *entities.Webhook) error {
+	parsed, err := url.Parse(webhook.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return ErrInvalidWebhookURL
+	}
+	if len(webhook.EventTypes) == 0 {
+		return ErrInvalidWebhookEventType
+	}
+	for _, eventType := range webhook.EventTypes {
+		if !entities.IsValidWebhookEventType(eventType) {
+			return ErrInvalidWebhookEventType
+		}
+	}
+	slices.Sort(webhook.EventTypes)
+	webhook.EventTypes = slices.Compact(webhook.EventTypes)
+	return nil
+}
@@ -0,0 +1,186 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/metrics"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+const (
+	// subscriberBuffer is how many notifications a slow client may fall behind
+	// before its stream is closed and it has to reconnect
+	subscriberBuffer  = 16
+	reconnectDelay    = time.Second
+	maxReconnectDelay = 30 * time.Second
+)
+
+var ErrTooManySubscribers = errors.New("too many balance streams")
+
+// Subscription receives the balance notifications of one player. C is closed
+// when the hub stops or the subscriber could not keep up.
+type Subscription struct {
+	C   <-chan entities.BalanceNotification
+	key string
+	ch  chan entities.BalanceNotification
+}
+
+// Hub fans balance notifications out to the streams of this replica. It keeps a
+// dedicated connection that LISTENs on the notification channel, so changes
+// committed by any replica reach every connected client.
+type Hub struct {
+	dsn            string
+	maxSubscribers int
+
+	mu          sync.Mutex
+	subscribers map[string]map[*Subscription]struct{}
+	count       int
+}
+
+func NewHub(dsn string, maxSubscribers int) *Hub {
+	return &Hub{
+		dsn:            dsn,
+		maxSubscribers: maxSubscribers,
+		subscribers:    make(map[string]map[*Subscription]struct{}),
+	}
+}
+
+func subscriptionKey(operatorID, playerID string) string {
+	return operatorID + "\n" + playerID
+}
+
+// Subscribe registers a stream for the player, call Unsubscribe when it ends
+func (h *Hub) Subscribe(operatorID, playerID string) (*Subscription, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.maxSubscribers > 0 && h.count >= h.maxSubscribers {
+		return nil, ErrTooManySubscribers
+	}
+
+	ch := make(chan entities.BalanceNotification, subscriberBuffer)
+	subscription := &Subscription{C: ch, key: subscriptionKey(operatorID, playerID), ch: ch}
+	if h.subscribers[subscription.key] == nil {
+		h.subscribers[subscription.key] = make(map[*Subscription]struct{})
+	}
+	h.subscribers[subscription.key][subscription] = struct{}{}
+	h.count++
+	metrics.BalanceStreams.Set(float64(h.count))
+	return subscription, nil
+}
+
+func (h *Hub) Unsubscribe(subscription *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.remove(subscription)
+}
+
+// remove drops the subscription and closes its channel, h.mu must be held
+func (h *Hub) remove(subscription *Subscription) {
+	subscriptions, ok := h.subscribers[subscription.key]
+	if !ok {
+		return
+	}
+	if _, ok := subscriptions[subscription]; !ok {
+		return
+	}
+	delete(subscriptions, subscription)
+	if len(subscriptions) == 0 {
+		delete(h.subscribers, subscription.key)
+	}
+	close(subscription.ch)
+	h.count--
+	metrics.BalanceStreams.Set(float64(h.count))
+}
+
+func (h *Hub) publish(notification entities.BalanceNotification) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for subscription := range h.subscribers[subscriptionKey(notification.OperatorID, notification.PlayerID)] {
+		select {
+		case subscription.ch <- notification:
+		default:
+			// Dropping a single notification would leave the client with a stale
+			// balance, closing the stream makes it reconnect and read a fresh one
+			zap.L().Warn("Closing balance stream of slow client",
+				zap.String("operator_id", notification.OperatorID),
+				zap.String("player_id", notification.PlayerID))
+			h.remove(subscription)
+		}
+	}
+}
+
+// Run listens for notifications until ctx is cancelled and reconnects when the
+// connection is lost. Streams are closed on shutdown.
+func (h *Hub) Run(ctx context.Context) {
+	defer h.closeAll()
+
+	delay := reconnectDelay
+	for ctx.Err() == nil {
+		connected, err := h.listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if connected {
+			delay = reconnectDelay
+			// Notifications sent while reconnecting are lost, closing the streams
+			// makes clients reconnect and start from a fresh balance
+			h.closeAll()
+		}
+		zap.L().Error("Balance notification listener disconnected, reconnecting",
+			zap.Duration("delay", delay),
+			zap.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay = min(2*delay, maxReconnectDelay)
+	}
+}
+
+// listen reports whether it got as far as listening before failing
+func (h *Hub) listen(ctx context.Context) (bool, error) {
+	conn, err := pgx.Connect(ctx, h.dsn)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{entities.BalanceNotificationChannel}.Sanitize()); err != nil {
+		return false, err
+	}
+	zap.L().Info("Listening for balance notifications")
+
+	for {
+		pgNotification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return true, err
+		}
+
+		var notification entities.BalanceNotification
+		if err := json.Unmarshal([]byte(pgNotification.Payload), &notification); err != nil {
+			zap.L().Warn("Ignoring malformed balance notification", zap.Error(err))
+			continue
+		}
+		h.publish(notification)
+	}
+}
+
+func (h *Hub) closeAll() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, subscriptions := range h.subscribers {
+		for subscription := range subscriptions {
+			h.remove(subscription)
+		}
+	}
+}
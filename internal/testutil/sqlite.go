@@ -0,0 +1,54 @@
+// Package testutil holds helpers shared by the tests of several packages
+package testutil
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/migration"
+	"github.com/BarisKilicGsu/casino-wallet-service/internal/repository"
+	"github.com/BarisKilicGsu/casino-wallet-service/migrations"
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	gormLogger "gorm.io/gorm/logger"
+)
+
+// OpenSQLiteDB opens an empty SQLite database in a temporary file, it is closed when the test ends
+func OpenSQLiteDB(t testing.TB) *sql.DB {
+	t.Helper()
+	sqlDB, err := sql.Open(sqlite.DriverName, repository.SQLiteDSN(filepath.Join(t.TempDir(), "wallet.db")))
+	if err != nil {
+		t.Fatalf("failed to open SQLite database: %v", err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+	return sqlDB
+}
+
+// SQLiteMigrator returns the migrator of the embedded SQLite migrations
+func SQLiteMigrator(t testing.TB, sqlDB *sql.DB) *migration.Migrator {
+	t.Helper()
+	loaded, err := migration.Load(migrations.SQLite, "sqlite")
+	if err != nil {
+		t.Fatalf("failed to load SQLite migrations: %v", err)
+	}
+	return migration.New(sqlDB, loaded, migration.SQLite)
+}
+
+// OpenSQLite returns a database with all SQLite migrations applied, the way
+// the sqlite storage backend opens it
+func OpenSQLite(t testing.TB) *gorm.DB {
+	t.Helper()
+	sqlDB := OpenSQLiteDB(t)
+	if _, err := SQLiteMigrator(t, sqlDB).Up(context.Background()); err != nil {
+		t.Fatalf("failed to migrate SQLite database: %v", err)
+	}
+	db, err := gorm.Open(repository.NewSQLiteDialector(sqlDB), &gorm.Config{
+		Logger: gormLogger.Default.LogMode(gormLogger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("failed to open SQLite database: %v", err)
+	}
+	return db
+}
@@ -0,0 +1,26 @@
+DROP INDEX IF EXISTS idx_api_keys_operator_id;
+DROP INDEX IF EXISTS idx_transactions_operator_wallet_round_type;
+DROP INDEX IF EXISTS idx_transactions_operator_player_id;
+
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_operator_wallet_fkey;
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_operator_player_fkey;
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_operator_req_key;
+ALTER TABLE players DROP CONSTRAINT IF EXISTS players_operator_wallet_key;
+ALTER TABLE players DROP CONSTRAINT IF EXISTS players_pkey;
+
+-- Fails if identifiers collide across operators, which cannot be represented without tenants
+ALTER TABLE players ADD PRIMARY KEY (id);
+ALTER TABLE players ADD CONSTRAINT players_wallet_id_key UNIQUE (wallet_id);
+ALTER TABLE transactions ADD CONSTRAINT transactions_req_id_key UNIQUE (req_id);
+ALTER TABLE transactions ADD CONSTRAINT transactions_player_id_fkey FOREIGN KEY (player_id) REFERENCES players(id);
+ALTER TABLE transactions ADD CONSTRAINT transactions_wallet_id_fkey FOREIGN KEY (wallet_id) REFERENCES players(wallet_id);
+
+CREATE INDEX IF NOT EXISTS idx_transactions_player_id ON transactions(player_id);
+CREATE INDEX IF NOT EXISTS idx_transactions_req_id ON transactions(req_id);
+CREATE INDEX IF NOT EXISTS idx_transactions_wallet_round_type ON transactions(wallet_id, round_id, type);
+
+ALTER TABLE api_keys DROP COLUMN IF EXISTS operator_id;
+ALTER TABLE transactions DROP COLUMN IF EXISTS operator_id;
+ALTER TABLE players DROP COLUMN IF EXISTS operator_id;
+
+DROP TABLE IF EXISTS operators;
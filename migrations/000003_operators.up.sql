@@ -0,0 +1,45 @@
+CREATE TABLE IF NOT EXISTS operators (
+    id VARCHAR(64) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    currencies JSONB NOT NULL DEFAULT '[]',
+    min_bet DECIMAL(20,2) NOT NULL DEFAULT 0,
+    max_bet DECIMAL(20,2) NOT NULL DEFAULT 0,
+    spend_order JSONB NOT NULL DEFAULT '["cash"]',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Existing data belongs to the default operator
+INSERT INTO operators (id, name, currencies) VALUES ('default', 'Default operator', '["INR"]')
+ON CONFLICT (id) DO NOTHING;
+
+ALTER TABLE players ADD COLUMN operator_id VARCHAR(64) NOT NULL DEFAULT 'default' REFERENCES operators(id);
+ALTER TABLE transactions ADD COLUMN operator_id VARCHAR(64) NOT NULL DEFAULT 'default' REFERENCES operators(id);
+ALTER TABLE api_keys ADD COLUMN operator_id VARCHAR(64) NOT NULL DEFAULT 'default' REFERENCES operators(id);
+
+ALTER TABLE players ALTER COLUMN operator_id DROP DEFAULT;
+ALTER TABLE transactions ALTER COLUMN operator_id DROP DEFAULT;
+ALTER TABLE api_keys ALTER COLUMN operator_id DROP DEFAULT;
+
+-- Identifiers only have to be unique within an operator
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_player_id_fkey;
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_wallet_id_fkey;
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_req_id_key;
+ALTER TABLE players DROP CONSTRAINT IF EXISTS players_wallet_id_key;
+ALTER TABLE players DROP CONSTRAINT IF EXISTS players_pkey;
+
+ALTER TABLE players ADD PRIMARY KEY (operator_id, id);
+ALTER TABLE players ADD CONSTRAINT players_operator_wallet_key UNIQUE (operator_id, wallet_id);
+ALTER TABLE transactions ADD CONSTRAINT transactions_operator_req_key UNIQUE (operator_id, req_id);
+ALTER TABLE transactions ADD CONSTRAINT transactions_operator_player_fkey
+    FOREIGN KEY (operator_id, player_id) REFERENCES players(operator_id, id);
+ALTER TABLE transactions ADD CONSTRAINT transactions_operator_wallet_fkey
+    FOREIGN KEY (operator_id, wallet_id) REFERENCES players(operator_id, wallet_id);
+
+DROP INDEX IF EXISTS idx_transactions_player_id;
+DROP INDEX IF EXISTS idx_transactions_req_id;
+DROP INDEX IF EXISTS idx_transactions_wallet_round_type;
+CREATE INDEX IF NOT EXISTS idx_transactions_operator_player_id ON transactions(operator_id, player_id);
+CREATE INDEX IF NOT EXISTS idx_transactions_operator_wallet_round_type ON transactions(operator_id, wallet_id, round_id, type);
+CREATE INDEX IF NOT EXISTS idx_api_keys_operator_id ON api_keys(operator_id);
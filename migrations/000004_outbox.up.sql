@@ -0,0 +1,33 @@
+-- Events are written in the same transaction as the wallet change they describe
+-- and removed by the relay once the publisher acknowledged them
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    operator_id VARCHAR(64) NOT NULL REFERENCES operators(id),
+    -- Events with the same key are published in id order, wallet events use the wallet id
+    aggregate_key VARCHAR(255) NOT NULL,
+    event_type VARCHAR(64) NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_events_key ON outbox_events(operator_id, aggregate_key, id);
+CREATE INDEX IF NOT EXISTS idx_outbox_events_next_attempt_at ON outbox_events(next_attempt_at, id);
+
+-- Events that could not be published after the configured number of attempts
+CREATE TABLE IF NOT EXISTS outbox_dead_letters (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    outbox_event_id BIGINT NOT NULL,
+    operator_id VARCHAR(64) NOT NULL,
+    aggregate_key VARCHAR(255) NOT NULL,
+    event_type VARCHAR(64) NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INTEGER NOT NULL,
+    last_error TEXT,
+    event_created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_dead_letters_operator_id ON outbox_dead_letters(operator_id, created_at);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS balance_adjustments;
+DROP TABLE IF EXISTS reconciliation_discrepancies;
+DROP TABLE IF EXISTS reconciliation_runs;
+
+ALTER TABLE players DROP COLUMN IF EXISTS initial_balance;
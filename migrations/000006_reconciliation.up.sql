@@ -0,0 +1,62 @@
+ALTER TABLE players ADD COLUMN initial_balance DECIMAL(20,2) NOT NULL DEFAULT 0;
+
+-- Existing balances are taken as correct, the opening balance is derived from them
+UPDATE players p SET initial_balance = p.balance - COALESCE((
+    SELECT SUM(CASE WHEN t.type = 'result' THEN t.amount WHEN t.type = 'bet' THEN -t.amount ELSE 0 END)
+    FROM transactions t
+    WHERE t.operator_id = p.operator_id AND t.player_id = p.id AND t.deleted_at IS NULL
+), 0);
+
+-- A run without operator_id covered every operator
+CREATE TABLE IF NOT EXISTS reconciliation_runs (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    operator_id VARCHAR(64) REFERENCES operators(id),
+    triggered_by VARCHAR(16) NOT NULL,
+    wallets_checked INTEGER NOT NULL DEFAULT 0,
+    discrepancy_count INTEGER NOT NULL DEFAULT 0,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    finished_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_reconciliation_runs_started_at ON reconciliation_runs(started_at);
+
+CREATE TABLE IF NOT EXISTS reconciliation_discrepancies (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    run_id BIGINT NOT NULL REFERENCES reconciliation_runs(id) ON DELETE CASCADE,
+    operator_id VARCHAR(64) NOT NULL REFERENCES operators(id),
+    player_id VARCHAR(255) NOT NULL,
+    wallet_id VARCHAR(255) NOT NULL,
+    currency VARCHAR(10) NOT NULL,
+    initial_balance DECIMAL(20,2) NOT NULL,
+    expected_balance DECIMAL(20,2) NOT NULL,
+    actual_balance DECIMAL(20,2) NOT NULL,
+    drift DECIMAL(20,2) NOT NULL,
+    transaction_ids JSONB NOT NULL DEFAULT '[]',
+    status VARCHAR(16) NOT NULL DEFAULT 'open',
+    resolved_by VARCHAR(255),
+    resolved_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_reconciliation_discrepancies_run_id ON reconciliation_discrepancies(run_id);
+CREATE INDEX IF NOT EXISTS idx_reconciliation_discrepancies_operator_status ON reconciliation_discrepancies(operator_id, status, id);
+CREATE INDEX IF NOT EXISTS idx_reconciliation_discrepancies_wallet ON reconciliation_discrepancies(operator_id, player_id, run_id);
+
+-- Manual balance corrections, the ledger in transactions is left untouched
+CREATE TABLE IF NOT EXISTS balance_adjustments (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    operator_id VARCHAR(64) NOT NULL,
+    player_id VARCHAR(255) NOT NULL,
+    wallet_id VARCHAR(255) NOT NULL,
+    discrepancy_id BIGINT REFERENCES reconciliation_discrepancies(id),
+    previous_balance DECIMAL(20,2) NOT NULL,
+    new_balance DECIMAL(20,2) NOT NULL,
+    amount DECIMAL(20,2) NOT NULL,
+    currency VARCHAR(10) NOT NULL,
+    reason TEXT NOT NULL,
+    approved_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (operator_id, player_id) REFERENCES players(operator_id, id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_balance_adjustments_operator_player ON balance_adjustments(operator_id, player_id);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS statement_mismatches;
+DROP TABLE IF EXISTS statement_imports;
+
+DROP INDEX IF EXISTS idx_transactions_operator_provider_created_at;
+ALTER TABLE transactions DROP COLUMN IF EXISTS provider_id;
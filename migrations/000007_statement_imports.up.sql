@@ -0,0 +1,40 @@
+-- Transactions stored before this migration have no provider and are not matched against statements
+ALTER TABLE transactions ADD COLUMN provider_id VARCHAR(64) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_transactions_operator_provider_created_at ON transactions(operator_id, provider_id, created_at);
+
+CREATE TABLE IF NOT EXISTS statement_imports (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    operator_id VARCHAR(64) NOT NULL REFERENCES operators(id),
+    provider_id VARCHAR(64) NOT NULL,
+    statement_date DATE NOT NULL,
+    format VARCHAR(16) NOT NULL,
+    file_name VARCHAR(255) NOT NULL DEFAULT '',
+    row_count INTEGER NOT NULL DEFAULT 0,
+    matched_count INTEGER NOT NULL DEFAULT 0,
+    missing_on_our_side_count INTEGER NOT NULL DEFAULT 0,
+    missing_on_provider_side_count INTEGER NOT NULL DEFAULT 0,
+    amount_mismatch_count INTEGER NOT NULL DEFAULT 0,
+    imported_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_statement_imports_operator_provider ON statement_imports(operator_id, provider_id, statement_date);
+
+CREATE TABLE IF NOT EXISTS statement_mismatches (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    import_id BIGINT NOT NULL REFERENCES statement_imports(id) ON DELETE CASCADE,
+    kind VARCHAR(32) NOT NULL,
+    -- Line of the row in the statement, 0 for transactions missing from the statement
+    line INTEGER NOT NULL DEFAULT 0,
+    transaction_id BIGINT,
+    req_id VARCHAR(255) NOT NULL DEFAULT '',
+    round_id VARCHAR(255) NOT NULL DEFAULT '',
+    player_id VARCHAR(255) NOT NULL DEFAULT '',
+    type VARCHAR(50) NOT NULL DEFAULT '',
+    currency VARCHAR(10) NOT NULL DEFAULT '',
+    provider_amount DECIMAL(20,2),
+    our_amount DECIMAL(20,2)
+);
+
+CREATE INDEX IF NOT EXISTS idx_statement_mismatches_import_kind ON statement_mismatches(import_id, kind, id);
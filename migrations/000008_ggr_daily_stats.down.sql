@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS ggr_daily_players;
+DROP TABLE IF EXISTS ggr_daily_stats;
+
+DROP INDEX IF EXISTS idx_transactions_created_at;
@@ -0,0 +1,46 @@
+-- The rollup job recomputes recent days from created_at
+CREATE INDEX IF NOT EXISTS idx_transactions_created_at ON transactions(created_at);
+
+-- Days are counted in UTC. GGR is turnover minus wins, the round count is the
+-- number of bets, a wallet places one bet per round.
+CREATE TABLE IF NOT EXISTS ggr_daily_stats (
+    day DATE NOT NULL,
+    operator_id VARCHAR(64) NOT NULL REFERENCES operators(id),
+    provider_id VARCHAR(64) NOT NULL,
+    game_code VARCHAR(255) NOT NULL,
+    currency VARCHAR(10) NOT NULL,
+    turnover DECIMAL(20,2) NOT NULL DEFAULT 0,
+    wins DECIMAL(20,2) NOT NULL DEFAULT 0,
+    round_count BIGINT NOT NULL DEFAULT 0,
+    unique_players INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (operator_id, day, provider_id, game_code, currency)
+);
+
+-- Unique players do not add up over days or games, reports over wider groups
+-- count them from the players of each day
+CREATE TABLE IF NOT EXISTS ggr_daily_players (
+    day DATE NOT NULL,
+    operator_id VARCHAR(64) NOT NULL REFERENCES operators(id),
+    provider_id VARCHAR(64) NOT NULL,
+    game_code VARCHAR(255) NOT NULL,
+    currency VARCHAR(10) NOT NULL,
+    player_id VARCHAR(255) NOT NULL,
+    PRIMARY KEY (operator_id, day, provider_id, game_code, currency, player_id)
+);
+
+-- Backfill the existing transactions, later days are kept up to date by the rollup job
+INSERT INTO ggr_daily_players (day, operator_id, provider_id, game_code, currency, player_id)
+SELECT DISTINCT (created_at AT TIME ZONE 'UTC')::date, operator_id, provider_id, game_code, currency, player_id
+FROM transactions
+WHERE deleted_at IS NULL;
+
+INSERT INTO ggr_daily_stats (day, operator_id, provider_id, game_code, currency, turnover, wins, round_count, unique_players)
+SELECT (created_at AT TIME ZONE 'UTC')::date, operator_id, provider_id, game_code, currency,
+    COALESCE(SUM(amount) FILTER (WHERE type = 'bet'), 0),
+    COALESCE(SUM(amount) FILTER (WHERE type = 'result'), 0),
+    COUNT(*) FILTER (WHERE type = 'bet'),
+    COUNT(DISTINCT player_id)
+FROM transactions
+WHERE deleted_at IS NULL
+GROUP BY 1, 2, 3, 4, 5;
@@ -0,0 +1,2 @@
+CREATE INDEX IF NOT EXISTS idx_transactions_operator_player_id ON transactions(operator_id, player_id);
+DROP INDEX IF EXISTS idx_transactions_operator_player_created_at;
@@ -0,0 +1,4 @@
+-- Statements read the transactions of a player in time order, the new index
+-- also covers the lookups by player the old one served
+CREATE INDEX IF NOT EXISTS idx_transactions_operator_player_created_at ON transactions(operator_id, player_id, created_at, id);
+DROP INDEX IF EXISTS idx_transactions_operator_player_id;
@@ -0,0 +1,12 @@
+-- A checkpoint holds the balance after every transaction stored at or before
+-- checkpoint_at, replayed from players.initial_balance
+CREATE TABLE IF NOT EXISTS balance_checkpoints (
+    operator_id VARCHAR(64) NOT NULL,
+    player_id VARCHAR(255) NOT NULL,
+    checkpoint_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    balance DECIMAL(20,2) NOT NULL,
+    last_transaction_id BIGINT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (operator_id, player_id, checkpoint_at),
+    FOREIGN KEY (operator_id, player_id) REFERENCES players(operator_id, id)
+);
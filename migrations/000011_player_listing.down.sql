@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_players_operator_balance;
+DROP INDEX IF EXISTS idx_players_operator_created_at;
+ALTER TABLE players DROP CONSTRAINT IF EXISTS players_status_check;
+ALTER TABLE players DROP COLUMN IF EXISTS status;
@@ -0,0 +1,7 @@
+ALTER TABLE players ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'active';
+ALTER TABLE players ADD CONSTRAINT players_status_check CHECK (status IN ('active', 'suspended', 'closed'));
+
+-- The players listing pages through one operator's players in the order of
+-- the sort field, with the id breaking ties
+CREATE INDEX IF NOT EXISTS idx_players_operator_created_at ON players(operator_id, created_at, id);
+CREATE INDEX IF NOT EXISTS idx_players_operator_balance ON players(operator_id, balance, id);
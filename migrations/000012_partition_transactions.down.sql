@@ -0,0 +1,58 @@
+-- Archived months stay archived, their effect remains in players.initial_balance
+DROP TABLE IF EXISTS transaction_archive_totals;
+DROP TABLE IF EXISTS transaction_archives;
+
+DROP TRIGGER IF EXISTS transactions_register_request_id ON transactions;
+DROP FUNCTION IF EXISTS register_transaction_request_id();
+DROP TABLE IF EXISTS transaction_request_ids;
+
+DROP INDEX IF EXISTS idx_transactions_operator_req_id;
+DROP INDEX IF EXISTS idx_transactions_session_id;
+DROP INDEX IF EXISTS idx_transactions_operator_wallet_round_type;
+DROP INDEX IF EXISTS idx_transactions_operator_provider_created_at;
+DROP INDEX IF EXISTS idx_transactions_created_at;
+DROP INDEX IF EXISTS idx_transactions_operator_player_created_at;
+
+ALTER TABLE transactions RENAME TO transactions_partitioned;
+ALTER TABLE transactions_partitioned RENAME CONSTRAINT transactions_pkey TO transactions_partitioned_pkey;
+ALTER SEQUENCE transactions_id_seq RENAME TO transactions_partitioned_id_seq;
+
+CREATE TABLE transactions (
+    id int GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    req_id VARCHAR(255) NOT NULL,
+    player_id VARCHAR(255) NOT NULL,
+    wallet_id VARCHAR(255) NOT NULL,
+    round_id VARCHAR(255) NOT NULL,
+    session_id VARCHAR(255) NOT NULL,
+    game_code VARCHAR(255) NOT NULL,
+    type VARCHAR(50) NOT NULL,
+    amount DECIMAL(20,2),
+    currency VARCHAR(10) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    operator_id VARCHAR(64) NOT NULL REFERENCES operators(id),
+    provider_id VARCHAR(64) NOT NULL DEFAULT '',
+    CONSTRAINT transactions_operator_req_key UNIQUE (operator_id, req_id),
+    CONSTRAINT transactions_operator_player_fkey
+        FOREIGN KEY (operator_id, player_id) REFERENCES players(operator_id, id),
+    CONSTRAINT transactions_operator_wallet_fkey
+        FOREIGN KEY (operator_id, wallet_id) REFERENCES players(operator_id, wallet_id)
+);
+
+INSERT INTO transactions (id, operator_id, provider_id, req_id, player_id, wallet_id, round_id, session_id,
+    game_code, type, amount, currency, created_at, updated_at, deleted_at)
+SELECT id, operator_id, provider_id, req_id, player_id, wallet_id, round_id, session_id,
+    game_code, type, amount, currency, created_at, updated_at, deleted_at
+FROM transactions_partitioned;
+
+SELECT setval(pg_get_serial_sequence('transactions', 'id'), COALESCE((SELECT MAX(id) FROM transactions), 0) + 1, false);
+
+DROP TABLE transactions_partitioned;
+DROP FUNCTION IF EXISTS create_transactions_partition(DATE);
+
+CREATE INDEX IF NOT EXISTS idx_transactions_session_id ON transactions(session_id);
+CREATE INDEX IF NOT EXISTS idx_transactions_operator_wallet_round_type ON transactions(operator_id, wallet_id, round_id, type);
+CREATE INDEX IF NOT EXISTS idx_transactions_operator_provider_created_at ON transactions(operator_id, provider_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_transactions_created_at ON transactions(created_at);
+CREATE INDEX IF NOT EXISTS idx_transactions_operator_player_created_at ON transactions(operator_id, player_id, created_at, id);
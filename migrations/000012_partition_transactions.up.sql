@@ -0,0 +1,118 @@
+-- Transactions are range partitioned by month of created_at in UTC, so indexes
+-- stay per month and old months can be detached and archived as a whole
+ALTER TABLE transactions RENAME TO transactions_unpartitioned;
+-- Index names are unique per schema, the old table gives up the names of its
+-- primary key and request ID constraint until it is dropped
+ALTER TABLE transactions_unpartitioned RENAME CONSTRAINT transactions_pkey TO transactions_unpartitioned_pkey;
+ALTER TABLE transactions_unpartitioned RENAME CONSTRAINT transactions_operator_req_key TO transactions_unpartitioned_operator_req_key;
+
+CREATE TABLE transactions (
+    id BIGINT NOT NULL,
+    operator_id VARCHAR(64) NOT NULL REFERENCES operators(id),
+    provider_id VARCHAR(64) NOT NULL DEFAULT '',
+    req_id VARCHAR(255) NOT NULL,
+    player_id VARCHAR(255) NOT NULL,
+    wallet_id VARCHAR(255) NOT NULL,
+    round_id VARCHAR(255) NOT NULL,
+    session_id VARCHAR(255) NOT NULL,
+    game_code VARCHAR(255) NOT NULL,
+    type VARCHAR(50) NOT NULL,
+    amount DECIMAL(20,2),
+    currency VARCHAR(10) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT transactions_pkey PRIMARY KEY (id, created_at),
+    CONSTRAINT transactions_operator_player_fkey
+        FOREIGN KEY (operator_id, player_id) REFERENCES players(operator_id, id),
+    CONSTRAINT transactions_operator_wallet_fkey
+        FOREIGN KEY (operator_id, wallet_id) REFERENCES players(operator_id, wallet_id)
+) PARTITION BY RANGE (created_at);
+
+-- Rows of a month without a partition land here instead of failing, the
+-- partition job creates the months ahead so it normally stays empty
+CREATE TABLE transactions_default PARTITION OF transactions DEFAULT;
+
+-- Creates the partition of the month of month, named transactions_YYYY_MM
+CREATE OR REPLACE FUNCTION create_transactions_partition(month DATE) RETURNS VOID AS $$
+DECLARE
+    month_start TIMESTAMP := date_trunc('month', month);
+BEGIN
+    EXECUTE format('CREATE TABLE IF NOT EXISTS %I PARTITION OF transactions FOR VALUES FROM (%L) TO (%L)',
+        'transactions_' || to_char(month_start, 'YYYY_MM'),
+        month_start AT TIME ZONE 'UTC',
+        (month_start + INTERVAL '1 month') AT TIME ZONE 'UTC');
+END;
+$$ LANGUAGE plpgsql;
+
+DO $$
+DECLARE
+    month DATE := COALESCE(
+        (SELECT date_trunc('month', MIN(created_at) AT TIME ZONE 'UTC') FROM transactions_unpartitioned),
+        date_trunc('month', NOW() AT TIME ZONE 'UTC'));
+BEGIN
+    WHILE month <= date_trunc('month', NOW() AT TIME ZONE 'UTC') + INTERVAL '3 months' LOOP
+        PERFORM create_transactions_partition(month);
+        month := month + INTERVAL '1 month';
+    END LOOP;
+END;
+$$;
+
+-- Unique constraints of a partitioned table have to contain created_at, so
+-- request IDs are kept unique across months in a table of their own. Archived
+-- months keep their request IDs, a replayed request is still rejected.
+CREATE TABLE IF NOT EXISTS transaction_request_ids (
+    operator_id VARCHAR(64) NOT NULL,
+    req_id VARCHAR(255) NOT NULL,
+    CONSTRAINT transactions_operator_req_key PRIMARY KEY (operator_id, req_id)
+);
+
+CREATE OR REPLACE FUNCTION register_transaction_request_id() RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO transaction_request_ids (operator_id, req_id) VALUES (NEW.operator_id, NEW.req_id);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER transactions_register_request_id AFTER INSERT ON transactions
+    FOR EACH ROW EXECUTE FUNCTION register_transaction_request_id();
+
+INSERT INTO transactions (id, operator_id, provider_id, req_id, player_id, wallet_id, round_id, session_id,
+    game_code, type, amount, currency, created_at, updated_at, deleted_at)
+SELECT id, operator_id, provider_id, req_id, player_id, wallet_id, round_id, session_id,
+    game_code, type, amount, currency, created_at, updated_at, deleted_at
+FROM transactions_unpartitioned;
+
+DROP TABLE transactions_unpartitioned;
+
+CREATE SEQUENCE transactions_id_seq OWNED BY transactions.id;
+SELECT setval('transactions_id_seq', COALESCE((SELECT MAX(id) FROM transactions), 0) + 1, false);
+ALTER TABLE transactions ALTER COLUMN id SET DEFAULT nextval('transactions_id_seq');
+
+CREATE INDEX IF NOT EXISTS idx_transactions_operator_req_id ON transactions(operator_id, req_id);
+CREATE INDEX IF NOT EXISTS idx_transactions_session_id ON transactions(session_id);
+CREATE INDEX IF NOT EXISTS idx_transactions_operator_wallet_round_type ON transactions(operator_id, wallet_id, round_id, type);
+CREATE INDEX IF NOT EXISTS idx_transactions_operator_provider_created_at ON transactions(operator_id, provider_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_transactions_created_at ON transactions(created_at);
+CREATE INDEX IF NOT EXISTS idx_transactions_operator_player_created_at ON transactions(operator_id, player_id, created_at, id);
+
+-- A month moved out of the database into a compressed file of the archive directory
+CREATE TABLE IF NOT EXISTS transaction_archives (
+    month DATE PRIMARY KEY,
+    file_name VARCHAR(255) NOT NULL,
+    transaction_count BIGINT NOT NULL,
+    checksum VARCHAR(64) NOT NULL,
+    archived_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- The effect of an archived month on each wallet. Archiving adds it to
+-- players.initial_balance, which stays the balance the transactions left in
+-- the database are replayed on.
+CREATE TABLE IF NOT EXISTS transaction_archive_totals (
+    month DATE NOT NULL REFERENCES transaction_archives(month),
+    operator_id VARCHAR(64) NOT NULL,
+    player_id VARCHAR(255) NOT NULL,
+    amount DECIMAL(20,2) NOT NULL,
+    transaction_count BIGINT NOT NULL,
+    PRIMARY KEY (operator_id, player_id, month)
+);
@@ -0,0 +1,3 @@
+-- Every balance change increments the version, the optimistic concurrency
+-- mode updates a player only when the version it read is still current
+ALTER TABLE players ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 0;
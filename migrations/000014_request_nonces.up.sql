@@ -0,0 +1,10 @@
+-- Nonces of signed provider requests, shared by all replicas. A row is kept
+-- until its request can no longer pass the timestamp check.
+CREATE TABLE IF NOT EXISTS request_nonces (
+    provider_id VARCHAR(64) NOT NULL,
+    nonce VARCHAR(255) NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    CONSTRAINT request_nonces_pkey PRIMARY KEY (provider_id, nonce)
+);
+
+CREATE INDEX IF NOT EXISTS idx_request_nonces_expires_at ON request_nonces(expires_at);
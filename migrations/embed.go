@@ -0,0 +1,16 @@
+// Package migrations holds the schema migrations of both storage backends. They
+// are embedded into the binary, which applies them on startup or with its
+// migrate command.
+package migrations
+
+import "embed"
+
+// Postgres holds the migrations of the postgres storage backend
+//
+//go:embed *.sql
+var Postgres embed.FS
+
+// SQLite holds the migrations of the sqlite storage backend
+//
+//go:embed sqlite/*.sql
+var SQLite embed.FS
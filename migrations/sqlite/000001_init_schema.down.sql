@@ -0,0 +1,17 @@
+DROP TABLE IF EXISTS balance_checkpoints;
+DROP TABLE IF EXISTS ggr_daily_players;
+DROP TABLE IF EXISTS ggr_daily_stats;
+DROP TABLE IF EXISTS statement_mismatches;
+DROP TABLE IF EXISTS statement_imports;
+DROP TABLE IF EXISTS balance_adjustments;
+DROP TABLE IF EXISTS reconciliation_discrepancies;
+DROP TABLE IF EXISTS reconciliation_runs;
+DROP TABLE IF EXISTS webhook_delivery_attempts;
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhooks;
+DROP TABLE IF EXISTS outbox_dead_letters;
+DROP TABLE IF EXISTS outbox_events;
+DROP TABLE IF EXISTS api_keys;
+DROP TABLE IF EXISTS transactions;
+DROP TABLE IF EXISTS players;
+DROP TABLE IF EXISTS operators;
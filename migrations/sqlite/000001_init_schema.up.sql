@@ -0,0 +1,288 @@
+-- The schema the Postgres migrations build, in SQLite types. Identities are
+-- INTEGER PRIMARY KEY AUTOINCREMENT, JSONB columns hold JSON text, event
+-- payloads are BLOBs so they scan into json.RawMessage, and time columns are
+-- DATETIME so the driver reads them back as times. Decimals are stored with
+-- NUMERIC affinity, the repositories round them to two places.
+
+CREATE TABLE IF NOT EXISTS operators (
+    id VARCHAR(64) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    currencies TEXT NOT NULL DEFAULT '[]',
+    min_bet DECIMAL(20,2) NOT NULL DEFAULT 0,
+    max_bet DECIMAL(20,2) NOT NULL DEFAULT 0,
+    spend_order TEXT NOT NULL DEFAULT '["cash"]',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at DATETIME
+);
+
+INSERT INTO operators (id, name, currencies) VALUES ('default', 'Default operator', '["INR"]')
+ON CONFLICT (id) DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS players (
+    operator_id VARCHAR(64) NOT NULL REFERENCES operators(id),
+    id VARCHAR(255) NOT NULL,
+    wallet_id VARCHAR(255) NOT NULL,
+    balance DECIMAL(20,2) NOT NULL DEFAULT 0,
+    initial_balance DECIMAL(20,2) NOT NULL DEFAULT 0,
+    currency VARCHAR(10) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'active' CONSTRAINT players_status_check CHECK (status IN ('active', 'suspended', 'closed')),
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at DATETIME,
+    PRIMARY KEY (operator_id, id),
+    CONSTRAINT players_operator_wallet_key UNIQUE (operator_id, wallet_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_players_operator_created_at ON players(operator_id, created_at, id);
+CREATE INDEX IF NOT EXISTS idx_players_operator_balance ON players(operator_id, balance, id);
+
+CREATE TABLE IF NOT EXISTS transactions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    operator_id VARCHAR(64) NOT NULL REFERENCES operators(id),
+    provider_id VARCHAR(64) NOT NULL DEFAULT '',
+    req_id VARCHAR(255) NOT NULL,
+    player_id VARCHAR(255) NOT NULL,
+    wallet_id VARCHAR(255) NOT NULL,
+    round_id VARCHAR(255) NOT NULL,
+    session_id VARCHAR(255) NOT NULL,
+    game_code VARCHAR(255) NOT NULL,
+    type VARCHAR(50) NOT NULL,
+    amount DECIMAL(20,2),
+    currency VARCHAR(10) NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at DATETIME,
+    CONSTRAINT transactions_operator_req_key UNIQUE (operator_id, req_id),
+    CONSTRAINT transactions_operator_player_fkey
+        FOREIGN KEY (operator_id, player_id) REFERENCES players(operator_id, id),
+    CONSTRAINT transactions_operator_wallet_fkey
+        FOREIGN KEY (operator_id, wallet_id) REFERENCES players(operator_id, wallet_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_transactions_session_id ON transactions(session_id);
+CREATE INDEX IF NOT EXISTS idx_transactions_operator_wallet_round_type ON transactions(operator_id, wallet_id, round_id, type);
+CREATE INDEX IF NOT EXISTS idx_transactions_operator_provider_created_at ON transactions(operator_id, provider_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_transactions_created_at ON transactions(created_at);
+CREATE INDEX IF NOT EXISTS idx_transactions_operator_player_created_at ON transactions(operator_id, player_id, created_at, id);
+
+CREATE TABLE IF NOT EXISTS api_keys (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    operator_id VARCHAR(64) NOT NULL REFERENCES operators(id),
+    name VARCHAR(255) NOT NULL,
+    prefix VARCHAR(16) NOT NULL,
+    key_hash VARCHAR(64) UNIQUE NOT NULL,
+    role VARCHAR(50) NOT NULL,
+    revoked_at DATETIME,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at DATETIME
+);
+
+CREATE INDEX IF NOT EXISTS idx_api_keys_operator_id ON api_keys(operator_id);
+
+-- Events are written in the same transaction as the wallet change they describe
+-- and removed by the relay once the publisher acknowledged them
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    operator_id VARCHAR(64) NOT NULL REFERENCES operators(id),
+    aggregate_key VARCHAR(255) NOT NULL,
+    event_type VARCHAR(64) NOT NULL,
+    payload BLOB NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_events_key ON outbox_events(operator_id, aggregate_key, id);
+CREATE INDEX IF NOT EXISTS idx_outbox_events_next_attempt_at ON outbox_events(next_attempt_at, id);
+
+CREATE TABLE IF NOT EXISTS outbox_dead_letters (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    outbox_event_id BIGINT NOT NULL,
+    operator_id VARCHAR(64) NOT NULL,
+    aggregate_key VARCHAR(255) NOT NULL,
+    event_type VARCHAR(64) NOT NULL,
+    payload BLOB NOT NULL,
+    attempts INTEGER NOT NULL,
+    last_error TEXT,
+    event_created_at DATETIME NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_dead_letters_operator_id ON outbox_dead_letters(operator_id, created_at);
+
+CREATE TABLE IF NOT EXISTS webhooks (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    operator_id VARCHAR(64) NOT NULL REFERENCES operators(id),
+    url TEXT NOT NULL,
+    secret VARCHAR(128) NOT NULL,
+    event_types TEXT NOT NULL DEFAULT '[]',
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at DATETIME
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhooks_operator_id ON webhooks(operator_id) WHERE deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    webhook_id BIGINT NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+    operator_id VARCHAR(64) NOT NULL REFERENCES operators(id),
+    event_type VARCHAR(64) NOT NULL,
+    payload BLOB NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_status_code INTEGER,
+    last_error TEXT,
+    next_attempt_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    delivered_at DATETIME,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at, id) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook_id ON webhook_deliveries(webhook_id, id);
+
+CREATE TABLE IF NOT EXISTS webhook_delivery_attempts (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    delivery_id BIGINT NOT NULL REFERENCES webhook_deliveries(id) ON DELETE CASCADE,
+    attempt INTEGER NOT NULL,
+    status_code INTEGER NOT NULL DEFAULT 0,
+    error TEXT,
+    duration_ms BIGINT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_delivery_attempts_delivery_id ON webhook_delivery_attempts(delivery_id, attempt);
+
+-- A run without operator_id covered every operator
+CREATE TABLE IF NOT EXISTS reconciliation_runs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    operator_id VARCHAR(64) REFERENCES operators(id),
+    triggered_by VARCHAR(16) NOT NULL,
+    wallets_checked INTEGER NOT NULL DEFAULT 0,
+    discrepancy_count INTEGER NOT NULL DEFAULT 0,
+    started_at DATETIME NOT NULL,
+    finished_at DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_reconciliation_runs_started_at ON reconciliation_runs(started_at);
+
+CREATE TABLE IF NOT EXISTS reconciliation_discrepancies (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    run_id BIGINT NOT NULL REFERENCES reconciliation_runs(id) ON DELETE CASCADE,
+    operator_id VARCHAR(64) NOT NULL REFERENCES operators(id),
+    player_id VARCHAR(255) NOT NULL,
+    wallet_id VARCHAR(255) NOT NULL,
+    currency VARCHAR(10) NOT NULL,
+    initial_balance DECIMAL(20,2) NOT NULL,
+    expected_balance DECIMAL(20,2) NOT NULL,
+    actual_balance DECIMAL(20,2) NOT NULL,
+    drift DECIMAL(20,2) NOT NULL,
+    transaction_ids TEXT NOT NULL DEFAULT '[]',
+    status VARCHAR(16) NOT NULL DEFAULT 'open',
+    resolved_by VARCHAR(255),
+    resolved_at DATETIME,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_reconciliation_discrepancies_run_id ON reconciliation_discrepancies(run_id);
+CREATE INDEX IF NOT EXISTS idx_reconciliation_discrepancies_operator_status ON reconciliation_discrepancies(operator_id, status, id);
+CREATE INDEX IF NOT EXISTS idx_reconciliation_discrepancies_wallet ON reconciliation_discrepancies(operator_id, player_id, run_id);
+
+-- Manual balance corrections, the ledger in transactions is left untouched
+CREATE TABLE IF NOT EXISTS balance_adjustments (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    operator_id VARCHAR(64) NOT NULL,
+    player_id VARCHAR(255) NOT NULL,
+    wallet_id VARCHAR(255) NOT NULL,
+    discrepancy_id BIGINT REFERENCES reconciliation_discrepancies(id),
+    previous_balance DECIMAL(20,2) NOT NULL,
+    new_balance DECIMAL(20,2) NOT NULL,
+    amount DECIMAL(20,2) NOT NULL,
+    currency VARCHAR(10) NOT NULL,
+    reason TEXT NOT NULL,
+    approved_by VARCHAR(255) NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (operator_id, player_id) REFERENCES players(operator_id, id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_balance_adjustments_operator_player ON balance_adjustments(operator_id, player_id);
+
+CREATE TABLE IF NOT EXISTS statement_imports (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    operator_id VARCHAR(64) NOT NULL REFERENCES operators(id),
+    provider_id VARCHAR(64) NOT NULL,
+    statement_date DATE NOT NULL,
+    format VARCHAR(16) NOT NULL,
+    file_name VARCHAR(255) NOT NULL DEFAULT '',
+    row_count INTEGER NOT NULL DEFAULT 0,
+    matched_count INTEGER NOT NULL DEFAULT 0,
+    missing_on_our_side_count INTEGER NOT NULL DEFAULT 0,
+    missing_on_provider_side_count INTEGER NOT NULL DEFAULT 0,
+    amount_mismatch_count INTEGER NOT NULL DEFAULT 0,
+    imported_by VARCHAR(255) NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_statement_imports_operator_provider ON statement_imports(operator_id, provider_id, statement_date);
+
+CREATE TABLE IF NOT EXISTS statement_mismatches (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    import_id BIGINT NOT NULL REFERENCES statement_imports(id) ON DELETE CASCADE,
+    kind VARCHAR(32) NOT NULL,
+    -- Line of the row in the statement, 0 for transactions missing from the statement
+    line INTEGER NOT NULL DEFAULT 0,
+    transaction_id BIGINT,
+    req_id VARCHAR(255) NOT NULL DEFAULT '',
+    round_id VARCHAR(255) NOT NULL DEFAULT '',
+    player_id VARCHAR(255) NOT NULL DEFAULT '',
+    type VARCHAR(50) NOT NULL DEFAULT '',
+    currency VARCHAR(10) NOT NULL DEFAULT '',
+    provider_amount DECIMAL(20,2),
+    our_amount DECIMAL(20,2)
+);
+
+CREATE INDEX IF NOT EXISTS idx_statement_mismatches_import_kind ON statement_mismatches(import_id, kind, id);
+
+-- Days are counted in UTC. GGR is turnover minus wins, the round count is the
+-- number of bets, a wallet places one bet per round.
+CREATE TABLE IF NOT EXISTS ggr_daily_stats (
+    day DATE NOT NULL,
+    operator_id VARCHAR(64) NOT NULL REFERENCES operators(id),
+    provider_id VARCHAR(64) NOT NULL,
+    game_code VARCHAR(255) NOT NULL,
+    currency VARCHAR(10) NOT NULL,
+    turnover DECIMAL(20,2) NOT NULL DEFAULT 0,
+    wins DECIMAL(20,2) NOT NULL DEFAULT 0,
+    round_count BIGINT NOT NULL DEFAULT 0,
+    unique_players INTEGER NOT NULL DEFAULT 0,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (operator_id, day, provider_id, game_code, currency)
+);
+
+CREATE TABLE IF NOT EXISTS ggr_daily_players (
+    day DATE NOT NULL,
+    operator_id VARCHAR(64) NOT NULL REFERENCES operators(id),
+    provider_id VARCHAR(64) NOT NULL,
+    game_code VARCHAR(255) NOT NULL,
+    currency VARCHAR(10) NOT NULL,
+    player_id VARCHAR(255) NOT NULL,
+    PRIMARY KEY (operator_id, day, provider_id, game_code, currency, player_id)
+);
+
+-- A checkpoint holds the balance after every transaction stored at or before
+-- checkpoint_at, replayed from players.initial_balance
+CREATE TABLE IF NOT EXISTS balance_checkpoints (
+    operator_id VARCHAR(64) NOT NULL,
+    player_id VARCHAR(255) NOT NULL,
+    checkpoint_at DATETIME NOT NULL,
+    balance DECIMAL(20,2) NOT NULL,
+    last_transaction_id BIGINT,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (operator_id, player_id, checkpoint_at),
+    FOREIGN KEY (operator_id, player_id) REFERENCES players(operator_id, id)
+);
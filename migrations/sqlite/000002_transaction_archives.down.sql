@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS transaction_archive_totals;
+DROP TABLE IF EXISTS transaction_archives;
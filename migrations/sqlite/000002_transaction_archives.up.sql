@@ -0,0 +1,18 @@
+-- SQLite keeps the transactions in a single table and never archives them,
+-- the tables exist so the queries that read archives work on both backends
+CREATE TABLE IF NOT EXISTS transaction_archives (
+    month DATETIME PRIMARY KEY,
+    file_name VARCHAR(255) NOT NULL,
+    transaction_count BIGINT NOT NULL,
+    checksum VARCHAR(64) NOT NULL,
+    archived_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS transaction_archive_totals (
+    month DATETIME NOT NULL REFERENCES transaction_archives(month),
+    operator_id VARCHAR(64) NOT NULL,
+    player_id VARCHAR(255) NOT NULL,
+    amount DECIMAL(20,2) NOT NULL,
+    transaction_count BIGINT NOT NULL,
+    PRIMARY KEY (operator_id, player_id, month)
+);
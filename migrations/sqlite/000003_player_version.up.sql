@@ -0,0 +1 @@
+ALTER TABLE players ADD COLUMN version BIGINT NOT NULL DEFAULT 0;
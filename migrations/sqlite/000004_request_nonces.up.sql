@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS request_nonces (
+    provider_id VARCHAR(64) NOT NULL,
+    nonce VARCHAR(255) NOT NULL,
+    expires_at DATETIME NOT NULL,
+    CONSTRAINT request_nonces_pkey PRIMARY KEY (provider_id, nonce)
+);
+
+CREATE INDEX IF NOT EXISTS idx_request_nonces_expires_at ON request_nonces(expires_at);
@@ -3,8 +3,11 @@
 package mocks
 
 import (
-	mock "github.com/stretchr/testify/mock"
+	context "context"
+
 	gorm "gorm.io/gorm"
+
+	mock "github.com/stretchr/testify/mock"
 )
 
 // IGormRepository is an autogenerated mock type for the IGormRepository type
@@ -48,17 +51,37 @@ func (_m *IGormRepository) FinishTransaction(tx *gorm.DB, err error) error {
 	return r0
 }
 
-// GetDB provides a mock function with no fields
-func (_m *IGormRepository) GetDB() *gorm.DB {
-	ret := _m.Called()
+// GetDB provides a mock function with given fields: ctx
+func (_m *IGormRepository) GetDB(ctx context.Context) *gorm.DB {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetDB")
 	}
 
 	var r0 *gorm.DB
-	if rf, ok := ret.Get(0).(func() *gorm.DB); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) *gorm.DB); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gorm.DB)
+		}
+	}
+
+	return r0
+}
+
+// GetPlayerReadDB provides a mock function with given fields: ctx, operatorID, playerID
+func (_m *IGormRepository) GetPlayerReadDB(ctx context.Context, operatorID string, playerID string) *gorm.DB {
+	ret := _m.Called(ctx, operatorID, playerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPlayerReadDB")
+	}
+
+	var r0 *gorm.DB
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *gorm.DB); ok {
+		r0 = rf(ctx, operatorID, playerID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*gorm.DB)
@@ -68,14 +91,39 @@ func (_m *IGormRepository) GetDB() *gorm.DB {
 	return r0
 }
 
+// GetReadDB provides a mock function with given fields: ctx
+func (_m *IGormRepository) GetReadDB(ctx context.Context) *gorm.DB {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReadDB")
+	}
+
+	var r0 *gorm.DB
+	if rf, ok := ret.Get(0).(func(context.Context) *gorm.DB); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gorm.DB)
+		}
+	}
+
+	return r0
+}
+
+// RecordPlayerWrite provides a mock function with given fields: operatorID, playerID
+func (_m *IGormRepository) RecordPlayerWrite(operatorID string, playerID string) {
+	_m.Called(operatorID, playerID)
+}
+
 // RollbackTransaction provides a mock function with given fields: tx
 func (_m *IGormRepository) RollbackTransaction(tx *gorm.DB) {
 	_m.Called(tx)
 }
 
-// StartTransaction provides a mock function with no fields
-func (_m *IGormRepository) StartTransaction() (*gorm.DB, error) {
-	ret := _m.Called()
+// StartTransaction provides a mock function with given fields: ctx
+func (_m *IGormRepository) StartTransaction(ctx context.Context) (*gorm.DB, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for StartTransaction")
@@ -83,19 +131,19 @@ func (_m *IGormRepository) StartTransaction() (*gorm.DB, error) {
 
 	var r0 *gorm.DB
 	var r1 error
-	if rf, ok := ret.Get(0).(func() (*gorm.DB, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context) (*gorm.DB, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func() *gorm.DB); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) *gorm.DB); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*gorm.DB)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -103,9 +151,9 @@ func (_m *IGormRepository) StartTransaction() (*gorm.DB, error) {
 	return r0, r1
 }
 
-// StartTransactionWithIsolation provides a mock function with given fields: isolationLevel
-func (_m *IGormRepository) StartTransactionWithIsolation(isolationLevel string) (*gorm.DB, error) {
-	ret := _m.Called(isolationLevel)
+// StartTransactionWithIsolation provides a mock function with given fields: ctx, isolationLevel
+func (_m *IGormRepository) StartTransactionWithIsolation(ctx context.Context, isolationLevel string) (*gorm.DB, error) {
+	ret := _m.Called(ctx, isolationLevel)
 
 	if len(ret) == 0 {
 		panic("no return value specified for StartTransactionWithIsolation")
@@ -113,19 +161,19 @@ func (_m *IGormRepository) StartTransactionWithIsolation(isolationLevel string)
 
 	var r0 *gorm.DB
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (*gorm.DB, error)); ok {
-		return rf(isolationLevel)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*gorm.DB, error)); ok {
+		return rf(ctx, isolationLevel)
 	}
-	if rf, ok := ret.Get(0).(func(string) *gorm.DB); ok {
-		r0 = rf(isolationLevel)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *gorm.DB); ok {
+		r0 = rf(ctx, isolationLevel)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*gorm.DB)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(isolationLevel)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, isolationLevel)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	entities "github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
 	gorm "gorm.io/gorm"
 
@@ -14,17 +16,45 @@ type IPlayerRepository struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: player, outTx
-func (_m *IPlayerRepository) Create(player *entities.Player, outTx *gorm.DB) error {
-	ret := _m.Called(player, outTx)
+// Count provides a mock function with given fields: ctx, filter, outTx
+func (_m *IPlayerRepository) Count(ctx context.Context, filter *entities.PlayerListFilter, outTx *gorm.DB) (int64, error) {
+	ret := _m.Called(ctx, filter, outTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Count")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.PlayerListFilter, *gorm.DB) (int64, error)); ok {
+		return rf(ctx, filter, outTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.PlayerListFilter, *gorm.DB) int64); ok {
+		r0 = rf(ctx, filter, outTx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *entities.PlayerListFilter, *gorm.DB) error); ok {
+		r1 = rf(ctx, filter, outTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, player, outTx
+func (_m *IPlayerRepository) Create(ctx context.Context, player *entities.Player, outTx *gorm.DB) error {
+	ret := _m.Called(ctx, player, outTx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*entities.Player, *gorm.DB) error); ok {
-		r0 = rf(player, outTx)
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.Player, *gorm.DB) error); ok {
+		r0 = rf(ctx, player, outTx)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -32,29 +62,29 @@ func (_m *IPlayerRepository) Create(player *entities.Player, outTx *gorm.DB) err
 	return r0
 }
 
-// GetAll provides a mock function with given fields: outTx
-func (_m *IPlayerRepository) GetAll(outTx *gorm.DB) ([]*entities.Player, error) {
-	ret := _m.Called(outTx)
+// GetByID provides a mock function with given fields: ctx, operatorID, id, outTx
+func (_m *IPlayerRepository) GetByID(ctx context.Context, operatorID string, id string, outTx *gorm.DB) (*entities.Player, error) {
+	ret := _m.Called(ctx, operatorID, id, outTx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAll")
+		panic("no return value specified for GetByID")
 	}
 
-	var r0 []*entities.Player
+	var r0 *entities.Player
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*gorm.DB) ([]*entities.Player, error)); ok {
-		return rf(outTx)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *gorm.DB) (*entities.Player, error)); ok {
+		return rf(ctx, operatorID, id, outTx)
 	}
-	if rf, ok := ret.Get(0).(func(*gorm.DB) []*entities.Player); ok {
-		r0 = rf(outTx)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *gorm.DB) *entities.Player); ok {
+		r0 = rf(ctx, operatorID, id, outTx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entities.Player)
+			r0 = ret.Get(0).(*entities.Player)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*gorm.DB) error); ok {
-		r1 = rf(outTx)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *gorm.DB) error); ok {
+		r1 = rf(ctx, operatorID, id, outTx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -62,29 +92,29 @@ func (_m *IPlayerRepository) GetAll(outTx *gorm.DB) ([]*entities.Player, error)
 	return r0, r1
 }
 
-// GetByID provides a mock function with given fields: id, outTx
-func (_m *IPlayerRepository) GetByID(id string, outTx *gorm.DB) (*entities.Player, error) {
-	ret := _m.Called(id, outTx)
+// GetByIDWithLock provides a mock function with given fields: ctx, operatorID, id, outTx
+func (_m *IPlayerRepository) GetByIDWithLock(ctx context.Context, operatorID string, id string, outTx *gorm.DB) (*entities.Player, error) {
+	ret := _m.Called(ctx, operatorID, id, outTx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetByID")
+		panic("no return value specified for GetByIDWithLock")
 	}
 
 	var r0 *entities.Player
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, *gorm.DB) (*entities.Player, error)); ok {
-		return rf(id, outTx)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *gorm.DB) (*entities.Player, error)); ok {
+		return rf(ctx, operatorID, id, outTx)
 	}
-	if rf, ok := ret.Get(0).(func(string, *gorm.DB) *entities.Player); ok {
-		r0 = rf(id, outTx)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *gorm.DB) *entities.Player); ok {
+		r0 = rf(ctx, operatorID, id, outTx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entities.Player)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, *gorm.DB) error); ok {
-		r1 = rf(id, outTx)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *gorm.DB) error); ok {
+		r1 = rf(ctx, operatorID, id, outTx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -92,29 +122,29 @@ func (_m *IPlayerRepository) GetByID(id string, outTx *gorm.DB) (*entities.Playe
 	return r0, r1
 }
 
-// GetByIDWithLock provides a mock function with given fields: id, outTx
-func (_m *IPlayerRepository) GetByIDWithLock(id string, outTx *gorm.DB) (*entities.Player, error) {
-	ret := _m.Called(id, outTx)
+// List provides a mock function with given fields: ctx, filter, order, after, limit, outTx
+func (_m *IPlayerRepository) List(ctx context.Context, filter *entities.PlayerListFilter, order entities.PlayerOrder, after *entities.PlayerCursor, limit int, outTx *gorm.DB) ([]*entities.Player, error) {
+	ret := _m.Called(ctx, filter, order, after, limit, outTx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetByIDWithLock")
+		panic("no return value specified for List")
 	}
 
-	var r0 *entities.Player
+	var r0 []*entities.Player
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, *gorm.DB) (*entities.Player, error)); ok {
-		return rf(id, outTx)
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.PlayerListFilter, entities.PlayerOrder, *entities.PlayerCursor, int, *gorm.DB) ([]*entities.Player, error)); ok {
+		return rf(ctx, filter, order, after, limit, outTx)
 	}
-	if rf, ok := ret.Get(0).(func(string, *gorm.DB) *entities.Player); ok {
-		r0 = rf(id, outTx)
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.PlayerListFilter, entities.PlayerOrder, *entities.PlayerCursor, int, *gorm.DB) []*entities.Player); ok {
+		r0 = rf(ctx, filter, order, after, limit, outTx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*entities.Player)
+			r0 = ret.Get(0).([]*entities.Player)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, *gorm.DB) error); ok {
-		r1 = rf(id, outTx)
+	if rf, ok := ret.Get(1).(func(context.Context, *entities.PlayerListFilter, entities.PlayerOrder, *entities.PlayerCursor, int, *gorm.DB) error); ok {
+		r1 = rf(ctx, filter, order, after, limit, outTx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -122,17 +152,35 @@ func (_m *IPlayerRepository) GetByIDWithLock(id string, outTx *gorm.DB) (*entiti
 	return r0, r1
 }
 
-// UpdateBalance provides a mock function with given fields: id, amount, outTx
-func (_m *IPlayerRepository) UpdateBalance(id string, amount float64, outTx *gorm.DB) error {
-	ret := _m.Called(id, amount, outTx)
+// UpdateBalance provides a mock function with given fields: ctx, operatorID, id, amount, outTx
+func (_m *IPlayerRepository) UpdateBalance(ctx context.Context, operatorID string, id string, amount float64, outTx *gorm.DB) error {
+	ret := _m.Called(ctx, operatorID, id, amount, outTx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateBalance")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, float64, *gorm.DB) error); ok {
-		r0 = rf(id, amount, outTx)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, float64, *gorm.DB) error); ok {
+		r0 = rf(ctx, operatorID, id, amount, outTx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateBalanceIfVersion provides a mock function with given fields: ctx, operatorID, id, version, amount, outTx
+func (_m *IPlayerRepository) UpdateBalanceIfVersion(ctx context.Context, operatorID string, id string, version int64, amount float64, outTx *gorm.DB) error {
+	ret := _m.Called(ctx, operatorID, id, version, amount, outTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateBalanceIfVersion")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64, float64, *gorm.DB) error); ok {
+		r0 = rf(ctx, operatorID, id, version, amount, outTx)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -3,10 +3,14 @@
 package mocks
 
 import (
+	context "context"
+
 	entities "github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
 	gorm "gorm.io/gorm"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // ITransactionRepository is an autogenerated mock type for the ITransactionRepository type
@@ -14,17 +18,17 @@ type ITransactionRepository struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: transaction, outTx
-func (_m *ITransactionRepository) Create(transaction *entities.Transaction, outTx *gorm.DB) error {
-	ret := _m.Called(transaction, outTx)
+// Create provides a mock function with given fields: ctx, transaction, outTx
+func (_m *ITransactionRepository) Create(ctx context.Context, transaction *entities.Transaction, outTx *gorm.DB) error {
+	ret := _m.Called(ctx, transaction, outTx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*entities.Transaction, *gorm.DB) error); ok {
-		r0 = rf(transaction, outTx)
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.Transaction, *gorm.DB) error); ok {
+		r0 = rf(ctx, transaction, outTx)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -32,9 +36,9 @@ func (_m *ITransactionRepository) Create(transaction *entities.Transaction, outT
 	return r0
 }
 
-// GetByPlayerID provides a mock function with given fields: playerID, outTx
-func (_m *ITransactionRepository) GetByPlayerID(playerID string, outTx *gorm.DB) ([]*entities.Transaction, error) {
-	ret := _m.Called(playerID, outTx)
+// GetByPlayerID provides a mock function with given fields: ctx, operatorID, playerID, outTx
+func (_m *ITransactionRepository) GetByPlayerID(ctx context.Context, operatorID string, playerID string, outTx *gorm.DB) ([]*entities.Transaction, error) {
+	ret := _m.Called(ctx, operatorID, playerID, outTx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetByPlayerID")
@@ -42,19 +46,19 @@ func (_m *ITransactionRepository) GetByPlayerID(playerID string, outTx *gorm.DB)
 
 	var r0 []*entities.Transaction
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, *gorm.DB) ([]*entities.Transaction, error)); ok {
-		return rf(playerID, outTx)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *gorm.DB) ([]*entities.Transaction, error)); ok {
+		return rf(ctx, operatorID, playerID, outTx)
 	}
-	if rf, ok := ret.Get(0).(func(string, *gorm.DB) []*entities.Transaction); ok {
-		r0 = rf(playerID, outTx)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *gorm.DB) []*entities.Transaction); ok {
+		r0 = rf(ctx, operatorID, playerID, outTx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*entities.Transaction)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, *gorm.DB) error); ok {
-		r1 = rf(playerID, outTx)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *gorm.DB) error); ok {
+		r1 = rf(ctx, operatorID, playerID, outTx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -62,9 +66,39 @@ func (_m *ITransactionRepository) GetByPlayerID(playerID string, outTx *gorm.DB)
 	return r0, r1
 }
 
-// GetByReqID provides a mock function with given fields: reqID, outTx
-func (_m *ITransactionRepository) GetByReqID(reqID string, outTx *gorm.DB) (*entities.Transaction, error) {
-	ret := _m.Called(reqID, outTx)
+// GetByProviderAndPeriod provides a mock function with given fields: ctx, operatorID, providerID, from, to, outTx
+func (_m *ITransactionRepository) GetByProviderAndPeriod(ctx context.Context, operatorID string, providerID string, from time.Time, to time.Time, outTx *gorm.DB) ([]*entities.Transaction, error) {
+	ret := _m.Called(ctx, operatorID, providerID, from, to, outTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByProviderAndPeriod")
+	}
+
+	var r0 []*entities.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time, time.Time, *gorm.DB) ([]*entities.Transaction, error)); ok {
+		return rf(ctx, operatorID, providerID, from, to, outTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time, time.Time, *gorm.DB) []*entities.Transaction); ok {
+		r0 = rf(ctx, operatorID, providerID, from, to, outTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time, time.Time, *gorm.DB) error); ok {
+		r1 = rf(ctx, operatorID, providerID, from, to, outTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByReqID provides a mock function with given fields: ctx, operatorID, reqID, outTx
+func (_m *ITransactionRepository) GetByReqID(ctx context.Context, operatorID string, reqID string, outTx *gorm.DB) (*entities.Transaction, error) {
+	ret := _m.Called(ctx, operatorID, reqID, outTx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetByReqID")
@@ -72,19 +106,19 @@ func (_m *ITransactionRepository) GetByReqID(reqID string, outTx *gorm.DB) (*ent
 
 	var r0 *entities.Transaction
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, *gorm.DB) (*entities.Transaction, error)); ok {
-		return rf(reqID, outTx)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *gorm.DB) (*entities.Transaction, error)); ok {
+		return rf(ctx, operatorID, reqID, outTx)
 	}
-	if rf, ok := ret.Get(0).(func(string, *gorm.DB) *entities.Transaction); ok {
-		r0 = rf(reqID, outTx)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *gorm.DB) *entities.Transaction); ok {
+		r0 = rf(ctx, operatorID, reqID, outTx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entities.Transaction)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, *gorm.DB) error); ok {
-		r1 = rf(reqID, outTx)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *gorm.DB) error); ok {
+		r1 = rf(ctx, operatorID, reqID, outTx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -92,29 +126,29 @@ func (_m *ITransactionRepository) GetByReqID(reqID string, outTx *gorm.DB) (*ent
 	return r0, r1
 }
 
-// GetByReqIDAndRoundIDWithLock provides a mock function with given fields: reqID, roundID, outTx
-func (_m *ITransactionRepository) GetByReqIDAndRoundIDWithLock(reqID string, roundID string, outTx *gorm.DB) (*entities.Transaction, error) {
-	ret := _m.Called(reqID, roundID, outTx)
+// GetByReqIDWithLock provides a mock function with given fields: ctx, operatorID, reqID, outTx
+func (_m *ITransactionRepository) GetByReqIDWithLock(ctx context.Context, operatorID string, reqID string, outTx *gorm.DB) (*entities.Transaction, error) {
+	ret := _m.Called(ctx, operatorID, reqID, outTx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetByReqIDAndRoundIDWithLock")
+		panic("no return value specified for GetByReqIDWithLock")
 	}
 
 	var r0 *entities.Transaction
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string, *gorm.DB) (*entities.Transaction, error)); ok {
-		return rf(reqID, roundID, outTx)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *gorm.DB) (*entities.Transaction, error)); ok {
+		return rf(ctx, operatorID, reqID, outTx)
 	}
-	if rf, ok := ret.Get(0).(func(string, string, *gorm.DB) *entities.Transaction); ok {
-		r0 = rf(reqID, roundID, outTx)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *gorm.DB) *entities.Transaction); ok {
+		r0 = rf(ctx, operatorID, reqID, outTx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entities.Transaction)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string, *gorm.DB) error); ok {
-		r1 = rf(reqID, roundID, outTx)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *gorm.DB) error); ok {
+		r1 = rf(ctx, operatorID, reqID, outTx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -122,29 +156,59 @@ func (_m *ITransactionRepository) GetByReqIDAndRoundIDWithLock(reqID string, rou
 	return r0, r1
 }
 
-// GetByReqIDWithLock provides a mock function with given fields: reqID, outTx
-func (_m *ITransactionRepository) GetByReqIDWithLock(reqID string, outTx *gorm.DB) (*entities.Transaction, error) {
-	ret := _m.Called(reqID, outTx)
+// GetByReqIDs provides a mock function with given fields: ctx, operatorID, reqIDs, outTx
+func (_m *ITransactionRepository) GetByReqIDs(ctx context.Context, operatorID string, reqIDs []string, outTx *gorm.DB) ([]*entities.Transaction, error) {
+	ret := _m.Called(ctx, operatorID, reqIDs, outTx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetByReqIDWithLock")
+		panic("no return value specified for GetByReqIDs")
+	}
+
+	var r0 []*entities.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, *gorm.DB) ([]*entities.Transaction, error)); ok {
+		return rf(ctx, operatorID, reqIDs, outTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, *gorm.DB) []*entities.Transaction); ok {
+		r0 = rf(ctx, operatorID, reqIDs, outTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string, *gorm.DB) error); ok {
+		r1 = rf(ctx, operatorID, reqIDs, outTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByRoundID provides a mock function with given fields: ctx, operatorID, roundID, outTx
+func (_m *ITransactionRepository) GetByRoundID(ctx context.Context, operatorID string, roundID string, outTx *gorm.DB) (*entities.Transaction, error) {
+	ret := _m.Called(ctx, operatorID, roundID, outTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByRoundID")
 	}
 
 	var r0 *entities.Transaction
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, *gorm.DB) (*entities.Transaction, error)); ok {
-		return rf(reqID, outTx)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *gorm.DB) (*entities.Transaction, error)); ok {
+		return rf(ctx, operatorID, roundID, outTx)
 	}
-	if rf, ok := ret.Get(0).(func(string, *gorm.DB) *entities.Transaction); ok {
-		r0 = rf(reqID, outTx)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *gorm.DB) *entities.Transaction); ok {
+		r0 = rf(ctx, operatorID, roundID, outTx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entities.Transaction)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, *gorm.DB) error); ok {
-		r1 = rf(reqID, outTx)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *gorm.DB) error); ok {
+		r1 = rf(ctx, operatorID, roundID, outTx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -152,29 +216,29 @@ func (_m *ITransactionRepository) GetByReqIDWithLock(reqID string, outTx *gorm.D
 	return r0, r1
 }
 
-// GetByRoundID provides a mock function with given fields: roundID, outTx
-func (_m *ITransactionRepository) GetByRoundID(roundID string, outTx *gorm.DB) (*entities.Transaction, error) {
-	ret := _m.Called(roundID, outTx)
+// GetByRoundIDAndPlayerIDAndWalletID provides a mock function with given fields: ctx, operatorID, roundID, walletID, transactionType, outTx
+func (_m *ITransactionRepository) GetByRoundIDAndPlayerIDAndWalletID(ctx context.Context, operatorID string, roundID string, walletID string, transactionType entities.TransactionType, outTx *gorm.DB) (*entities.Transaction, error) {
+	ret := _m.Called(ctx, operatorID, roundID, walletID, transactionType, outTx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetByRoundID")
+		panic("no return value specified for GetByRoundIDAndPlayerIDAndWalletID")
 	}
 
 	var r0 *entities.Transaction
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, *gorm.DB) (*entities.Transaction, error)); ok {
-		return rf(roundID, outTx)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, entities.TransactionType, *gorm.DB) (*entities.Transaction, error)); ok {
+		return rf(ctx, operatorID, roundID, walletID, transactionType, outTx)
 	}
-	if rf, ok := ret.Get(0).(func(string, *gorm.DB) *entities.Transaction); ok {
-		r0 = rf(roundID, outTx)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, entities.TransactionType, *gorm.DB) *entities.Transaction); ok {
+		r0 = rf(ctx, operatorID, roundID, walletID, transactionType, outTx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entities.Transaction)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, *gorm.DB) error); ok {
-		r1 = rf(roundID, outTx)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, entities.TransactionType, *gorm.DB) error); ok {
+		r1 = rf(ctx, operatorID, roundID, walletID, transactionType, outTx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -182,29 +246,59 @@ func (_m *ITransactionRepository) GetByRoundID(roundID string, outTx *gorm.DB) (
 	return r0, r1
 }
 
-// GetByRoundIDWithLock provides a mock function with given fields: roundID, transactionType, outTx
-func (_m *ITransactionRepository) GetByRoundIDWithLock(roundID string, transactionType entities.TransactionType, outTx *gorm.DB) (*entities.Transaction, error) {
-	ret := _m.Called(roundID, transactionType, outTx)
+// GetByRoundIDAndPlayerIDAndWalletIDWithLock provides a mock function with given fields: ctx, operatorID, roundID, walletID, transactionType, outTx
+func (_m *ITransactionRepository) GetByRoundIDAndPlayerIDAndWalletIDWithLock(ctx context.Context, operatorID string, roundID string, walletID string, transactionType entities.TransactionType, outTx *gorm.DB) (*entities.Transaction, error) {
+	ret := _m.Called(ctx, operatorID, roundID, walletID, transactionType, outTx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetByRoundIDWithLock")
+		panic("no return value specified for GetByRoundIDAndPlayerIDAndWalletIDWithLock")
 	}
 
 	var r0 *entities.Transaction
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, entities.TransactionType, *gorm.DB) (*entities.Transaction, error)); ok {
-		return rf(roundID, transactionType, outTx)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, entities.TransactionType, *gorm.DB) (*entities.Transaction, error)); ok {
+		return rf(ctx, operatorID, roundID, walletID, transactionType, outTx)
 	}
-	if rf, ok := ret.Get(0).(func(string, entities.TransactionType, *gorm.DB) *entities.Transaction); ok {
-		r0 = rf(roundID, transactionType, outTx)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, entities.TransactionType, *gorm.DB) *entities.Transaction); ok {
+		r0 = rf(ctx, operatorID, roundID, walletID, transactionType, outTx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entities.Transaction)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, entities.TransactionType, *gorm.DB) error); ok {
-		r1 = rf(roundID, transactionType, outTx)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, entities.TransactionType, *gorm.DB) error); ok {
+		r1 = rf(ctx, operatorID, roundID, walletID, transactionType, outTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByRoundIDs provides a mock function with given fields: ctx, operatorID, roundIDs, outTx
+func (_m *ITransactionRepository) GetByRoundIDs(ctx context.Context, operatorID string, roundIDs []string, outTx *gorm.DB) ([]*entities.Transaction, error) {
+	ret := _m.Called(ctx, operatorID, roundIDs, outTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByRoundIDs")
+	}
+
+	var r0 []*entities.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, *gorm.DB) ([]*entities.Transaction, error)); ok {
+		return rf(ctx, operatorID, roundIDs, outTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, *gorm.DB) []*entities.Transaction); ok {
+		r0 = rf(ctx, operatorID, roundIDs, outTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string, *gorm.DB) error); ok {
+		r1 = rf(ctx, operatorID, roundIDs, outTx)
 	} else {
 		r1 = ret.Error(1)
 	}
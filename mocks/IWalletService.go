@@ -3,8 +3,12 @@
 package mocks
 
 import (
+	context "context"
+
 	entities "github.com/BarisKilicGsu/casino-wallet-service/internal/entities"
 	mock "github.com/stretchr/testify/mock"
+
+	service "github.com/BarisKilicGsu/casino-wallet-service/internal/service"
 )
 
 // IWalletService is an autogenerated mock type for the IWalletService type
@@ -12,29 +16,29 @@ type IWalletService struct {
 	mock.Mock
 }
 
-// GetAllPlayers provides a mock function with no fields
-func (_m *IWalletService) GetAllPlayers() ([]*entities.Player, error) {
-	ret := _m.Called()
+// GetPlayerBalance provides a mock function with given fields: ctx, operatorID, playerID
+func (_m *IWalletService) GetPlayerBalance(ctx context.Context, operatorID string, playerID string) (*entities.Player, error) {
+	ret := _m.Called(ctx, operatorID, playerID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAllPlayers")
+		panic("no return value specified for GetPlayerBalance")
 	}
 
-	var r0 []*entities.Player
+	var r0 *entities.Player
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]*entities.Player, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*entities.Player, error)); ok {
+		return rf(ctx, operatorID, playerID)
 	}
-	if rf, ok := ret.Get(0).(func() []*entities.Player); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *entities.Player); ok {
+		r0 = rf(ctx, operatorID, playerID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entities.Player)
+			r0 = ret.Get(0).(*entities.Player)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, operatorID, playerID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -42,29 +46,29 @@ func (_m *IWalletService) GetAllPlayers() ([]*entities.Player, error) {
 	return r0, r1
 }
 
-// GetPlayerBalance provides a mock function with given fields: playerID
-func (_m *IWalletService) GetPlayerBalance(playerID string) (*entities.Player, error) {
-	ret := _m.Called(playerID)
+// ListPlayers provides a mock function with given fields: ctx, request
+func (_m *IWalletService) ListPlayers(ctx context.Context, request *service.ListPlayersRequest) (*entities.PlayerPage, error) {
+	ret := _m.Called(ctx, request)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPlayerBalance")
+		panic("no return value specified for ListPlayers")
 	}
 
-	var r0 *entities.Player
+	var r0 *entities.PlayerPage
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (*entities.Player, error)); ok {
-		return rf(playerID)
+	if rf, ok := ret.Get(0).(func(context.Context, *service.ListPlayersRequest) (*entities.PlayerPage, error)); ok {
+		return rf(ctx, request)
 	}
-	if rf, ok := ret.Get(0).(func(string) *entities.Player); ok {
-		r0 = rf(playerID)
+	if rf, ok := ret.Get(0).(func(context.Context, *service.ListPlayersRequest) *entities.PlayerPage); ok {
+		r0 = rf(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*entities.Player)
+			r0 = ret.Get(0).(*entities.PlayerPage)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(playerID)
+	if rf, ok := ret.Get(1).(func(context.Context, *service.ListPlayersRequest) error); ok {
+		r1 = rf(ctx, request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -72,17 +76,17 @@ func (_m *IWalletService) GetPlayerBalance(playerID string) (*entities.Player, e
 	return r0, r1
 }
 
-// ProcessTransaction provides a mock function with given fields: transaction
-func (_m *IWalletService) ProcessTransaction(transaction *entities.Transaction) error {
-	ret := _m.Called(transaction)
+// ProcessTransaction provides a mock function with given fields: ctx, transaction
+func (_m *IWalletService) ProcessTransaction(ctx context.Context, transaction *entities.Transaction) error {
+	ret := _m.Called(ctx, transaction)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ProcessTransaction")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*entities.Transaction) error); ok {
-		r0 = rf(transaction)
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.Transaction) error); ok {
+		r0 = rf(ctx, transaction)
 	} else {
 		r0 = ret.Error(0)
 	}
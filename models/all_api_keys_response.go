@@ -0,0 +1,112 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// AllAPIKeysResponse all API keys response
+//
+// swagger:model AllAPIKeysResponse
+type AllAPIKeysResponse struct {
+
+	// api keys
+	APIKeys []*APIKeyResponse `json:"api_keys"`
+}
+
+// Validate validates this all API keys response
+func (m *AllAPIKeysResponse) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateAPIKeys(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *AllAPIKeysResponse) validateAPIKeys(formats strfmt.Registry) error {
+	if swag.IsZero(m.APIKeys) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.APIKeys); i++ {
+		if swag.IsZero(m.APIKeys[i]) { // not required
+			continue
+		}
+
+		if m.APIKeys[i] != nil {
+			if err := m.APIKeys[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("api_keys" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// ContextValidate validate this all API keys response based on the context it is used
+func (m *AllAPIKeysResponse) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateAPIKeys(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *AllAPIKeysResponse) contextValidateAPIKeys(ctx context.Context, formats strfmt.Registry) error {
+
+	for i := 0; i < len(m.APIKeys); i++ {
+
+		if m.APIKeys[i] != nil {
+			if err := m.APIKeys[i].ContextValidate(ctx, formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("api_keys" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *AllAPIKeysResponse) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *AllAPIKeysResponse) UnmarshalBinary(b []byte) error {
+	var res AllAPIKeysResponse
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -19,8 +19,14 @@ import (
 // swagger:model AllPlayersResponse
 type AllPlayersResponse struct {
 
+	// Cursor of the next page, empty on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
+
 	// players
 	Players []*PlayerResponse `json:"players"`
+
+	// Number of players matching the filters, only returned with include_total=true
+	TotalCount *int64 `json:"total_count,omitempty"`
 }
 
 // Validate validates this all players response
@@ -0,0 +1,112 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// AllReconciliationDiscrepanciesResponse all reconciliation discrepancies response
+//
+// swagger:model AllReconciliationDiscrepanciesResponse
+type AllReconciliationDiscrepanciesResponse struct {
+
+	// discrepancies
+	Discrepancies []*ReconciliationDiscrepancyResponse `json:"discrepancies"`
+}
+
+// Validate validates this all reconciliation discrepancies response
+func (m *AllReconciliationDiscrepanciesResponse) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateDiscrepancies(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *AllReconciliationDiscrepanciesResponse) validateDiscrepancies(formats strfmt.Registry) error {
+	if swag.IsZero(m.Discrepancies) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.Discrepancies); i++ {
+		if swag.IsZero(m.Discrepancies[i]) { // not required
+			continue
+		}
+
+		if m.Discrepancies[i] != nil {
+			if err := m.Discrepancies[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("discrepancies" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// ContextValidate validate this all reconciliation discrepancies response based on the context it is used
+func (m *AllReconciliationDiscrepanciesResponse) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateDiscrepancies(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *AllReconciliationDiscrepanciesResponse) contextValidateDiscrepancies(ctx context.Context, formats strfmt.Registry) error {
+
+	for i := 0; i < len(m.Discrepancies); i++ {
+
+		if m.Discrepancies[i] != nil {
+			if err := m.Discrepancies[i].ContextValidate(ctx, formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("discrepancies" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *AllReconciliationDiscrepanciesResponse) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *AllReconciliationDiscrepanciesResponse) UnmarshalBinary(b []byte) error {
+	var res AllReconciliationDiscrepanciesResponse
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
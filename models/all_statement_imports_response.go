@@ -0,0 +1,112 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// AllStatementImportsResponse all statement imports response
+//
+// swagger:model AllStatementImportsResponse
+type AllStatementImportsResponse struct {
+
+	// imports
+	Imports []*StatementImportResponse `json:"imports"`
+}
+
+// Validate validates this all statement imports response
+func (m *AllStatementImportsResponse) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateImports(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *AllStatementImportsResponse) validateImports(formats strfmt.Registry) error {
+	if swag.IsZero(m.Imports) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.Imports); i++ {
+		if swag.IsZero(m.Imports[i]) { // not required
+			continue
+		}
+
+		if m.Imports[i] != nil {
+			if err := m.Imports[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("imports" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// ContextValidate validate this all statement imports response based on the context it is used
+func (m *AllStatementImportsResponse) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateImports(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *AllStatementImportsResponse) contextValidateImports(ctx context.Context, formats strfmt.Registry) error {
+
+	for i := 0; i < len(m.Imports); i++ {
+
+		if m.Imports[i] != nil {
+			if err := m.Imports[i].ContextValidate(ctx, formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("imports" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *AllStatementImportsResponse) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *AllStatementImportsResponse) UnmarshalBinary(b []byte) error {
+	var res AllStatementImportsResponse
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
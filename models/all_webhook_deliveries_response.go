@@ -0,0 +1,112 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// AllWebhookDeliveriesResponse all webhook deliveries response
+//
+// swagger:model AllWebhookDeliveriesResponse
+type AllWebhookDeliveriesResponse struct {
+
+	// deliveries
+	Deliveries []*WebhookDeliveryResponse `json:"deliveries"`
+}
+
+// Validate validates this all webhook deliveries response
+func (m *AllWebhookDeliveriesResponse) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateDeliveries(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *AllWebhookDeliveriesResponse) validateDeliveries(formats strfmt.Registry) error {
+	if swag.IsZero(m.Deliveries) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.Deliveries); i++ {
+		if swag.IsZero(m.Deliveries[i]) { // not required
+			continue
+		}
+
+		if m.Deliveries[i] != nil {
+			if err := m.Deliveries[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("deliveries" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// ContextValidate validate this all webhook deliveries response based on the context it is used
+func (m *AllWebhookDeliveriesResponse) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateDeliveries(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *AllWebhookDeliveriesResponse) contextValidateDeliveries(ctx context.Context, formats strfmt.Registry) error {
+
+	for i := 0; i < len(m.Deliveries); i++ {
+
+		if m.Deliveries[i] != nil {
+			if err := m.Deliveries[i].ContextValidate(ctx, formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("deliveries" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *AllWebhookDeliveriesResponse) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *AllWebhookDeliveriesResponse) UnmarshalBinary(b []byte) error {
+	var res AllWebhookDeliveriesResponse
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
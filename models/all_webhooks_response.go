@@ -0,0 +1,112 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// AllWebhooksResponse all webhooks response
+//
+// swagger:model AllWebhooksResponse
+type AllWebhooksResponse struct {
+
+	// webhooks
+	Webhooks []*WebhookResponse `json:"webhooks"`
+}
+
+// Validate validates this all webhooks response
+func (m *AllWebhooksResponse) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateWebhooks(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *AllWebhooksResponse) validateWebhooks(formats strfmt.Registry) error {
+	if swag.IsZero(m.Webhooks) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.Webhooks); i++ {
+		if swag.IsZero(m.Webhooks[i]) { // not required
+			continue
+		}
+
+		if m.Webhooks[i] != nil {
+			if err := m.Webhooks[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("webhooks" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// ContextValidate validate this all webhooks response based on the context it is used
+func (m *AllWebhooksResponse) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateWebhooks(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *AllWebhooksResponse) contextValidateWebhooks(ctx context.Context, formats strfmt.Registry) error {
+
+	for i := 0; i < len(m.Webhooks); i++ {
+
+		if m.Webhooks[i] != nil {
+			if err := m.Webhooks[i].ContextValidate(ctx, formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("webhooks" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *AllWebhooksResponse) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *AllWebhooksResponse) UnmarshalBinary(b []byte) error {
+	var res AllWebhooksResponse
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
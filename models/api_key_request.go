@@ -0,0 +1,135 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// APIKeyRequest API key request
+//
+// swagger:model APIKeyRequest
+type APIKeyRequest struct {
+
+	// Human readable owner of the key
+	// Required: true
+	// Min Length: 1
+	Name *string `json:"name"`
+
+	// role
+	// Required: true
+	// Enum: [provider support finance admin]
+	Role *string `json:"role"`
+}
+
+// Validate validates this API key request
+func (m *APIKeyRequest) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateName(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateRole(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *APIKeyRequest) validateName(formats strfmt.Registry) error {
+
+	if err := validate.Required("name", "body", m.Name); err != nil {
+		return err
+	}
+
+	if err := validate.MinLength("name", "body", *m.Name, 1); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var apiKeyRequestTypeRolePropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["provider","support","finance","admin"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		apiKeyRequestTypeRolePropEnum = append(apiKeyRequestTypeRolePropEnum, v)
+	}
+}
+
+const (
+
+	// APIKeyRequestRoleProvider captures enum value "provider"
+	APIKeyRequestRoleProvider string = "provider"
+
+	// APIKeyRequestRoleSupport captures enum value "support"
+	APIKeyRequestRoleSupport string = "support"
+
+	// APIKeyRequestRoleFinance captures enum value "finance"
+	APIKeyRequestRoleFinance string = "finance"
+
+	// APIKeyRequestRoleAdmin captures enum value "admin"
+	APIKeyRequestRoleAdmin string = "admin"
+)
+
+// prop value enum
+func (m *APIKeyRequest) validateRoleEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, apiKeyRequestTypeRolePropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *APIKeyRequest) validateRole(formats strfmt.Registry) error {
+
+	if err := validate.Required("role", "body", m.Role); err != nil {
+		return err
+	}
+
+	// value enum
+	if err := m.validateRoleEnum("role", "body", *m.Role); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validates this API key request based on context it is used
+func (m *APIKeyRequest) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *APIKeyRequest) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *APIKeyRequest) UnmarshalBinary(b []byte) error {
+	var res APIKeyRequest
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
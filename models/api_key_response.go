@@ -0,0 +1,68 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// APIKeyResponse API key response
+//
+// swagger:model APIKeyResponse
+type APIKeyResponse struct {
+
+	// RFC3339 creation time
+	CreatedAt string `json:"created_at,omitempty"`
+
+	// id
+	ID uint64 `json:"id,omitempty"`
+
+	// name
+	Name string `json:"name,omitempty"`
+
+	// operator id
+	OperatorID string `json:"operator_id,omitempty"`
+
+	// First characters of the key, used to identify it
+	Prefix string `json:"prefix,omitempty"`
+
+	// RFC3339 revocation time, empty while the key is active
+	RevokedAt string `json:"revoked_at,omitempty"`
+
+	// role
+	Role string `json:"role,omitempty"`
+}
+
+// Validate validates this API key response
+func (m *APIKeyResponse) Validate(formats strfmt.Registry) error {
+	return nil
+}
+
+// ContextValidate validates this API key response based on context it is used
+func (m *APIKeyResponse) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *APIKeyResponse) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *APIKeyResponse) UnmarshalBinary(b []byte) error {
+	var res APIKeyResponse
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -0,0 +1,80 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// BalanceAdjustmentResponse balance adjustment response
+//
+// swagger:model BalanceAdjustmentResponse
+type BalanceAdjustmentResponse struct {
+
+	// amount
+	Amount float64 `json:"amount"`
+
+	// approved by
+	ApprovedBy string `json:"approved_by,omitempty"`
+
+	// RFC3339 creation time
+	CreatedAt string `json:"created_at,omitempty"`
+
+	// currency
+	Currency string `json:"currency,omitempty"`
+
+	// discrepancy id
+	DiscrepancyID uint64 `json:"discrepancy_id,omitempty"`
+
+	// id
+	ID uint64 `json:"id,omitempty"`
+
+	// new balance
+	NewBalance float64 `json:"new_balance"`
+
+	// player id
+	PlayerID string `json:"player_id,omitempty"`
+
+	// previous balance
+	PreviousBalance float64 `json:"previous_balance"`
+
+	// reason
+	Reason string `json:"reason,omitempty"`
+
+	// wallet id
+	WalletID string `json:"wallet_id,omitempty"`
+}
+
+// Validate validates this balance adjustment response
+func (m *BalanceAdjustmentResponse) Validate(formats strfmt.Registry) error {
+	return nil
+}
+
+// ContextValidate validates this balance adjustment response based on context it is used
+func (m *BalanceAdjustmentResponse) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *BalanceAdjustmentResponse) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *BalanceAdjustmentResponse) UnmarshalBinary(b []byte) error {
+	var res BalanceAdjustmentResponse
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
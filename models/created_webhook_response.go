@@ -0,0 +1,103 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// CreatedWebhookResponse created webhook response
+//
+// swagger:model CreatedWebhookResponse
+type CreatedWebhookResponse struct {
+
+	// Secret used to sign deliveries, it is only returned once
+	Secret string `json:"secret,omitempty"`
+
+	// webhook
+	Webhook *WebhookResponse `json:"webhook,omitempty"`
+}
+
+// Validate validates this created webhook response
+func (m *CreatedWebhookResponse) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateWebhook(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *CreatedWebhookResponse) validateWebhook(formats strfmt.Registry) error {
+	if swag.IsZero(m.Webhook) { // not required
+		return nil
+	}
+
+	if m.Webhook != nil {
+		if err := m.Webhook.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("webhook")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ContextValidate validate this created webhook response based on the context it is used
+func (m *CreatedWebhookResponse) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateWebhook(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *CreatedWebhookResponse) contextValidateWebhook(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.Webhook != nil {
+		if err := m.Webhook.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("webhook")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *CreatedWebhookResponse) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *CreatedWebhookResponse) UnmarshalBinary(b []byte) error {
+	var res CreatedWebhookResponse
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -0,0 +1,121 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// GGRReportResponse g g r report response
+//
+// swagger:model GGRReportResponse
+type GGRReportResponse struct {
+
+	// from
+	From string `json:"from,omitempty"`
+
+	// group by
+	GroupBy []string `json:"group_by"`
+
+	// rows
+	Rows []*GGRReportRowResponse `json:"rows"`
+
+	// to
+	To string `json:"to,omitempty"`
+}
+
+// Validate validates this g g r report response
+func (m *GGRReportResponse) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateRows(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *GGRReportResponse) validateRows(formats strfmt.Registry) error {
+	if swag.IsZero(m.Rows) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.Rows); i++ {
+		if swag.IsZero(m.Rows[i]) { // not required
+			continue
+		}
+
+		if m.Rows[i] != nil {
+			if err := m.Rows[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("rows" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// ContextValidate validate this g g r report response based on the context it is used
+func (m *GGRReportResponse) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateRows(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *GGRReportResponse) contextValidateRows(ctx context.Context, formats strfmt.Registry) error {
+
+	for i := 0; i < len(m.Rows); i++ {
+
+		if m.Rows[i] != nil {
+			if err := m.Rows[i].ContextValidate(ctx, formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("rows" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *GGRReportResponse) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *GGRReportResponse) UnmarshalBinary(b []byte) error {
+	var res GGRReportResponse
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
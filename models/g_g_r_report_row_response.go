@@ -0,0 +1,74 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// GGRReportRowResponse One group of the report, dimensions that are not grouped by are empty
+//
+// swagger:model GGRReportRowResponse
+type GGRReportRowResponse struct {
+
+	// currency
+	Currency string `json:"currency,omitempty"`
+
+	// YYYY-MM-DD in UTC
+	Day string `json:"day,omitempty"`
+
+	// game code
+	GameCode string `json:"game_code,omitempty"`
+
+	// Turnover minus wins
+	Ggr float64 `json:"ggr"`
+
+	// provider id
+	ProviderID string `json:"provider_id,omitempty"`
+
+	// round count
+	RoundCount int64 `json:"round_count"`
+
+	// Sum of the bets
+	Turnover float64 `json:"turnover"`
+
+	// unique players
+	UniquePlayers int64 `json:"unique_players"`
+
+	// Sum of the results
+	Wins float64 `json:"wins"`
+}
+
+// Validate validates this g g r report row response
+func (m *GGRReportRowResponse) Validate(formats strfmt.Registry) error {
+	return nil
+}
+
+// ContextValidate validates this g g r report row response based on context it is used
+func (m *GGRReportRowResponse) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *GGRReportRowResponse) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *GGRReportRowResponse) UnmarshalBinary(b []byte) error {
+	var res GGRReportRowResponse
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
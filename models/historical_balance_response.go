@@ -0,0 +1,118 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// HistoricalBalanceResponse historical balance response
+//
+// swagger:model HistoricalBalanceResponse
+type HistoricalBalanceResponse struct {
+
+	// RFC3339 time the balance was reconstructed for
+	At string `json:"at,omitempty"`
+
+	// Balance after every transaction stored at or before at
+	Balance float64 `json:"balance"`
+
+	// RFC3339 time of the checkpoint the reconstruction started from, empty when it started from the opening balance
+	CheckpointAt string `json:"checkpoint_at,omitempty"`
+
+	// currency
+	Currency string `json:"currency,omitempty"`
+
+	// last transaction
+	LastTransaction *TransactionResponse `json:"last_transaction,omitempty"`
+
+	// player id
+	PlayerID string `json:"player_id,omitempty"`
+
+	// wallet id
+	WalletID string `json:"wallet_id,omitempty"`
+}
+
+// Validate validates this historical balance response
+func (m *HistoricalBalanceResponse) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateLastTransaction(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *HistoricalBalanceResponse) validateLastTransaction(formats strfmt.Registry) error {
+	if swag.IsZero(m.LastTransaction) { // not required
+		return nil
+	}
+
+	if m.LastTransaction != nil {
+		if err := m.LastTransaction.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("last_transaction")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ContextValidate validate this historical balance response based on the context it is used
+func (m *HistoricalBalanceResponse) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateLastTransaction(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *HistoricalBalanceResponse) contextValidateLastTransaction(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.LastTransaction != nil {
+		if err := m.LastTransaction.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("last_transaction")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *HistoricalBalanceResponse) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *HistoricalBalanceResponse) UnmarshalBinary(b []byte) error {
+	var res HistoricalBalanceResponse
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
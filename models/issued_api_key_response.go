@@ -0,0 +1,103 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// IssuedAPIKeyResponse issued API key response
+//
+// swagger:model IssuedAPIKeyResponse
+type IssuedAPIKeyResponse struct {
+
+	// api key
+	APIKey *APIKeyResponse `json:"api_key,omitempty"`
+
+	// Plain API key, it is only returned once
+	Key string `json:"key,omitempty"`
+}
+
+// Validate validates this issued API key response
+func (m *IssuedAPIKeyResponse) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateAPIKey(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *IssuedAPIKeyResponse) validateAPIKey(formats strfmt.Registry) error {
+	if swag.IsZero(m.APIKey) { // not required
+		return nil
+	}
+
+	if m.APIKey != nil {
+		if err := m.APIKey.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("api_key")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ContextValidate validate this issued API key response based on the context it is used
+func (m *IssuedAPIKeyResponse) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateAPIKey(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *IssuedAPIKeyResponse) contextValidateAPIKey(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.APIKey != nil {
+		if err := m.APIKey.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("api_key")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *IssuedAPIKeyResponse) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *IssuedAPIKeyResponse) UnmarshalBinary(b []byte) error {
+	var res IssuedAPIKeyResponse
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
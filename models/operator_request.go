@@ -0,0 +1,194 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// OperatorRequest operator request
+//
+// swagger:model OperatorRequest
+type OperatorRequest struct {
+
+	// currencies
+	// Required: true
+	// Min Items: 1
+	Currencies []string `json:"currencies"`
+
+	// Maximum bet amount, 0 disables the limit
+	// Minimum: 0
+	MaxBet *float64 `json:"max_bet,omitempty"`
+
+	// Minimum bet amount, 0 disables the limit
+	// Minimum: 0
+	MinBet *float64 `json:"min_bet,omitempty"`
+
+	// name
+	// Required: true
+	// Min Length: 1
+	Name *string `json:"name"`
+
+	// Order in which balance buckets are spent on bets
+	// Required: true
+	// Min Items: 1
+	SpendOrder []string `json:"spend_order"`
+}
+
+// Validate validates this operator request
+func (m *OperatorRequest) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateCurrencies(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateMaxBet(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateMinBet(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateName(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateSpendOrder(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *OperatorRequest) validateCurrencies(formats strfmt.Registry) error {
+
+	if err := validate.Required("currencies", "body", m.Currencies); err != nil {
+		return err
+	}
+
+	iCurrenciesSize := int64(len(m.Currencies))
+
+	if err := validate.MinItems("currencies", "body", iCurrenciesSize, 1); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *OperatorRequest) validateMaxBet(formats strfmt.Registry) error {
+	if swag.IsZero(m.MaxBet) { // not required
+		return nil
+	}
+
+	if err := validate.Minimum("max_bet", "body", *m.MaxBet, 0, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *OperatorRequest) validateMinBet(formats strfmt.Registry) error {
+	if swag.IsZero(m.MinBet) { // not required
+		return nil
+	}
+
+	if err := validate.Minimum("min_bet", "body", *m.MinBet, 0, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *OperatorRequest) validateName(formats strfmt.Registry) error {
+
+	if err := validate.Required("name", "body", m.Name); err != nil {
+		return err
+	}
+
+	if err := validate.MinLength("name", "body", *m.Name, 1); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var operatorRequestSpendOrderItemsEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["cash"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		operatorRequestSpendOrderItemsEnum = append(operatorRequestSpendOrderItemsEnum, v)
+	}
+}
+
+func (m *OperatorRequest) validateSpendOrderItemsEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, operatorRequestSpendOrderItemsEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *OperatorRequest) validateSpendOrder(formats strfmt.Registry) error {
+
+	if err := validate.Required("spend_order", "body", m.SpendOrder); err != nil {
+		return err
+	}
+
+	iSpendOrderSize := int64(len(m.SpendOrder))
+
+	if err := validate.MinItems("spend_order", "body", iSpendOrderSize, 1); err != nil {
+		return err
+	}
+
+	for i := 0; i < len(m.SpendOrder); i++ {
+
+		// value enum
+		if err := m.validateSpendOrderItemsEnum("spend_order"+"."+strconv.Itoa(i), "body", m.SpendOrder[i]); err != nil {
+			return err
+		}
+
+	}
+
+	return nil
+}
+
+// ContextValidate validates this operator request based on context it is used
+func (m *OperatorRequest) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *OperatorRequest) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *OperatorRequest) UnmarshalBinary(b []byte) error {
+	var res OperatorRequest
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -0,0 +1,65 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// OperatorResponse operator response
+//
+// swagger:model OperatorResponse
+type OperatorResponse struct {
+
+	// currencies
+	Currencies []string `json:"currencies"`
+
+	// id
+	ID string `json:"id,omitempty"`
+
+	// max bet
+	MaxBet float64 `json:"max_bet,omitempty"`
+
+	// min bet
+	MinBet float64 `json:"min_bet,omitempty"`
+
+	// name
+	Name string `json:"name,omitempty"`
+
+	// spend order
+	SpendOrder []string `json:"spend_order"`
+}
+
+// Validate validates this operator response
+func (m *OperatorResponse) Validate(formats strfmt.Registry) error {
+	return nil
+}
+
+// ContextValidate validates this operator response based on context it is used
+func (m *OperatorResponse) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *OperatorResponse) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *OperatorResponse) UnmarshalBinary(b []byte) error {
+	var res OperatorResponse
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -7,9 +7,12 @@ package models
 
 import (
 	"context"
+	"encoding/json"
 
+	"github.com/go-openapi/errors"
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
 )
 
 // PlayerResponse player response
@@ -17,21 +20,82 @@ import (
 // swagger:model PlayerResponse
 type PlayerResponse struct {
 
-	// Bakiye
+	// Balance amount
 	Balance float64 `json:"balance,omitempty"`
 
-	// Para birimi
+	// RFC3339 creation time
+	CreatedAt string `json:"created_at,omitempty"`
+
+	// Currency type
 	Currency string `json:"currency,omitempty"`
 
-	// Oyuncu ID'si
+	// Player ID
 	ID string `json:"id,omitempty"`
 
-	// Cüzdan ID'si
+	// Account status
+	// Enum: [active suspended closed]
+	Status string `json:"status,omitempty"`
+
+	// Wallet ID
 	WalletID string `json:"wallet_id,omitempty"`
 }
 
 // Validate validates this player response
 func (m *PlayerResponse) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateStatus(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+var playerResponseTypeStatusPropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["active","suspended","closed"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		playerResponseTypeStatusPropEnum = append(playerResponseTypeStatusPropEnum, v)
+	}
+}
+
+const (
+
+	// PlayerResponseStatusActive captures enum value "active"
+	PlayerResponseStatusActive string = "active"
+
+	// PlayerResponseStatusSuspended captures enum value "suspended"
+	PlayerResponseStatusSuspended string = "suspended"
+
+	// PlayerResponseStatusClosed captures enum value "closed"
+	PlayerResponseStatusClosed string = "closed"
+)
+
+// prop value enum
+func (m *PlayerResponse) validateStatusEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, playerResponseTypeStatusPropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *PlayerResponse) validateStatus(formats strfmt.Registry) error {
+	if swag.IsZero(m.Status) { // not required
+		return nil
+	}
+
+	// value enum
+	if err := m.validateStatusEnum("status", "body", m.Status); err != nil {
+		return err
+	}
+
 	return nil
 }
 
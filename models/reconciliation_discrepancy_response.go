@@ -0,0 +1,147 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// ReconciliationDiscrepancyResponse reconciliation discrepancy response
+//
+// swagger:model ReconciliationDiscrepancyResponse
+type ReconciliationDiscrepancyResponse struct {
+
+	// actual balance
+	ActualBalance float64 `json:"actual_balance"`
+
+	// RFC3339 creation time
+	CreatedAt string `json:"created_at,omitempty"`
+
+	// currency
+	Currency string `json:"currency,omitempty"`
+
+	// Actual minus expected balance
+	Drift float64 `json:"drift"`
+
+	// Initial balance plus results minus bets
+	ExpectedBalance float64 `json:"expected_balance"`
+
+	// id
+	ID uint64 `json:"id,omitempty"`
+
+	// initial balance
+	InitialBalance float64 `json:"initial_balance"`
+
+	// player id
+	PlayerID string `json:"player_id,omitempty"`
+
+	// RFC3339 time the discrepancy was corrected or found resolved
+	ResolvedAt string `json:"resolved_at,omitempty"`
+
+	// resolved by
+	ResolvedBy string `json:"resolved_by,omitempty"`
+
+	// run id
+	RunID uint64 `json:"run_id,omitempty"`
+
+	// status
+	// Enum: [open corrected resolved]
+	Status string `json:"status,omitempty"`
+
+	// Transactions that look wrong or were stored after the wallet was last found consistent
+	TransactionIds []uint64 `json:"transaction_ids"`
+
+	// wallet id
+	WalletID string `json:"wallet_id,omitempty"`
+}
+
+// Validate validates this reconciliation discrepancy response
+func (m *ReconciliationDiscrepancyResponse) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateStatus(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+var reconciliationDiscrepancyResponseTypeStatusPropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["open","corrected","resolved"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		reconciliationDiscrepancyResponseTypeStatusPropEnum = append(reconciliationDiscrepancyResponseTypeStatusPropEnum, v)
+	}
+}
+
+const (
+
+	// ReconciliationDiscrepancyResponseStatusOpen captures enum value "open"
+	ReconciliationDiscrepancyResponseStatusOpen string = "open"
+
+	// ReconciliationDiscrepancyResponseStatusCorrected captures enum value "corrected"
+	ReconciliationDiscrepancyResponseStatusCorrected string = "corrected"
+
+	// ReconciliationDiscrepancyResponseStatusResolved captures enum value "resolved"
+	ReconciliationDiscrepancyResponseStatusResolved string = "resolved"
+)
+
+// prop value enum
+func (m *ReconciliationDiscrepancyResponse) validateStatusEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, reconciliationDiscrepancyResponseTypeStatusPropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *ReconciliationDiscrepancyResponse) validateStatus(formats strfmt.Registry) error {
+	if swag.IsZero(m.Status) { // not required
+		return nil
+	}
+
+	// value enum
+	if err := m.validateStatusEnum("status", "body", m.Status); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validates this reconciliation discrepancy response based on context it is used
+func (m *ReconciliationDiscrepancyResponse) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *ReconciliationDiscrepancyResponse) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *ReconciliationDiscrepancyResponse) UnmarshalBinary(b []byte) error {
+	var res ReconciliationDiscrepancyResponse
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
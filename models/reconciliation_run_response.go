@@ -0,0 +1,182 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// ReconciliationRunResponse reconciliation run response
+//
+// swagger:model ReconciliationRunResponse
+type ReconciliationRunResponse struct {
+
+	// Discrepancies of the caller's operator
+	Discrepancies []*ReconciliationDiscrepancyResponse `json:"discrepancies"`
+
+	// discrepancy count
+	DiscrepancyCount int64 `json:"discrepancy_count"`
+
+	// RFC3339 end time
+	FinishedAt string `json:"finished_at,omitempty"`
+
+	// id
+	ID uint64 `json:"id,omitempty"`
+
+	// RFC3339 start time
+	StartedAt string `json:"started_at,omitempty"`
+
+	// triggered by
+	// Enum: [api command schedule]
+	TriggeredBy string `json:"triggered_by,omitempty"`
+
+	// wallets checked
+	WalletsChecked int64 `json:"wallets_checked"`
+}
+
+// Validate validates this reconciliation run response
+func (m *ReconciliationRunResponse) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateDiscrepancies(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateTriggeredBy(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *ReconciliationRunResponse) validateDiscrepancies(formats strfmt.Registry) error {
+	if swag.IsZero(m.Discrepancies) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.Discrepancies); i++ {
+		if swag.IsZero(m.Discrepancies[i]) { // not required
+			continue
+		}
+
+		if m.Discrepancies[i] != nil {
+			if err := m.Discrepancies[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("discrepancies" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+var reconciliationRunResponseTypeTriggeredByPropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["api","command","schedule"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		reconciliationRunResponseTypeTriggeredByPropEnum = append(reconciliationRunResponseTypeTriggeredByPropEnum, v)
+	}
+}
+
+const (
+
+	// ReconciliationRunResponseTriggeredByAPI captures enum value "api"
+	ReconciliationRunResponseTriggeredByAPI string = "api"
+
+	// ReconciliationRunResponseTriggeredByCommand captures enum value "command"
+	ReconciliationRunResponseTriggeredByCommand string = "command"
+
+	// ReconciliationRunResponseTriggeredBySchedule captures enum value "schedule"
+	ReconciliationRunResponseTriggeredBySchedule string = "schedule"
+)
+
+// prop value enum
+func (m *ReconciliationRunResponse) validateTriggeredByEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, reconciliationRunResponseTypeTriggeredByPropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *ReconciliationRunResponse) validateTriggeredBy(formats strfmt.Registry) error {
+	if swag.IsZero(m.TriggeredBy) { // not required
+		return nil
+	}
+
+	// value enum
+	if err := m.validateTriggeredByEnum("triggered_by", "body", m.TriggeredBy); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validate this reconciliation run response based on the context it is used
+func (m *ReconciliationRunResponse) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateDiscrepancies(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *ReconciliationRunResponse) contextValidateDiscrepancies(ctx context.Context, formats strfmt.Registry) error {
+
+	for i := 0; i < len(m.Discrepancies); i++ {
+
+		if m.Discrepancies[i] != nil {
+			if err := m.Discrepancies[i].ContextValidate(ctx, formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("discrepancies" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *ReconciliationRunResponse) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *ReconciliationRunResponse) UnmarshalBinary(b []byte) error {
+	var res ReconciliationRunResponse
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
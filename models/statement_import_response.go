@@ -0,0 +1,197 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// StatementImportResponse statement import response
+//
+// swagger:model StatementImportResponse
+type StatementImportResponse struct {
+
+	// amount mismatch count
+	AmountMismatchCount int64 `json:"amount_mismatch_count"`
+
+	// RFC3339 import time
+	CreatedAt string `json:"created_at,omitempty"`
+
+	// file name
+	FileName string `json:"file_name,omitempty"`
+
+	// format
+	// Enum: [csv json]
+	Format string `json:"format,omitempty"`
+
+	// id
+	ID uint64 `json:"id,omitempty"`
+
+	// imported by
+	ImportedBy string `json:"imported_by,omitempty"`
+
+	// matched count
+	MatchedCount int64 `json:"matched_count"`
+
+	// Mismatches of the import, only returned for a single import
+	Mismatches []*StatementMismatchResponse `json:"mismatches"`
+
+	// missing on our side count
+	MissingOnOurSideCount int64 `json:"missing_on_our_side_count"`
+
+	// missing on provider side count
+	MissingOnProviderSideCount int64 `json:"missing_on_provider_side_count"`
+
+	// provider id
+	ProviderID string `json:"provider_id,omitempty"`
+
+	// row count
+	RowCount int64 `json:"row_count"`
+
+	// Day covered by the statement, YYYY-MM-DD
+	StatementDate string `json:"statement_date,omitempty"`
+}
+
+// Validate validates this statement import response
+func (m *StatementImportResponse) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateFormat(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateMismatches(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+var statementImportResponseTypeFormatPropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["csv","json"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		statementImportResponseTypeFormatPropEnum = append(statementImportResponseTypeFormatPropEnum, v)
+	}
+}
+
+const (
+
+	// StatementImportResponseFormatCsv captures enum value "csv"
+	StatementImportResponseFormatCsv string = "csv"
+
+	// StatementImportResponseFormatJSON captures enum value "json"
+	StatementImportResponseFormatJSON string = "json"
+)
+
+// prop value enum
+func (m *StatementImportResponse) validateFormatEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, statementImportResponseTypeFormatPropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *StatementImportResponse) validateFormat(formats strfmt.Registry) error {
+	if swag.IsZero(m.Format) { // not required
+		return nil
+	}
+
+	// value enum
+	if err := m.validateFormatEnum("format", "body", m.Format); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *StatementImportResponse) validateMismatches(formats strfmt.Registry) error {
+	if swag.IsZero(m.Mismatches) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.Mismatches); i++ {
+		if swag.IsZero(m.Mismatches[i]) { // not required
+			continue
+		}
+
+		if m.Mismatches[i] != nil {
+			if err := m.Mismatches[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("mismatches" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// ContextValidate validate this statement import response based on the context it is used
+func (m *StatementImportResponse) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateMismatches(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *StatementImportResponse) contextValidateMismatches(ctx context.Context, formats strfmt.Registry) error {
+
+	for i := 0; i < len(m.Mismatches); i++ {
+
+		if m.Mismatches[i] != nil {
+			if err := m.Mismatches[i].ContextValidate(ctx, formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("mismatches" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *StatementImportResponse) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *StatementImportResponse) UnmarshalBinary(b []byte) error {
+	var res StatementImportResponse
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -0,0 +1,138 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// StatementMismatchResponse statement mismatch response
+//
+// swagger:model StatementMismatchResponse
+type StatementMismatchResponse struct {
+
+	// currency
+	Currency string `json:"currency,omitempty"`
+
+	// id
+	ID uint64 `json:"id,omitempty"`
+
+	// kind
+	// Enum: [missing_on_our_side missing_on_provider_side amount_mismatch]
+	Kind string `json:"kind,omitempty"`
+
+	// Line or record number in the statement, 0 for transactions missing from the statement
+	Line int64 `json:"line,omitempty"`
+
+	// Amount of our transaction, empty when it is missing on our side
+	OurAmount *float64 `json:"our_amount,omitempty"`
+
+	// player id
+	PlayerID string `json:"player_id,omitempty"`
+
+	// Amount in the statement, empty when the row is missing from it
+	ProviderAmount *float64 `json:"provider_amount,omitempty"`
+
+	// req id
+	ReqID string `json:"req_id,omitempty"`
+
+	// round id
+	RoundID string `json:"round_id,omitempty"`
+
+	// Our transaction, empty when it is missing on our side
+	TransactionID uint64 `json:"transaction_id,omitempty"`
+
+	// type
+	Type string `json:"type,omitempty"`
+}
+
+// Validate validates this statement mismatch response
+func (m *StatementMismatchResponse) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateKind(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+var statementMismatchResponseTypeKindPropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["missing_on_our_side","missing_on_provider_side","amount_mismatch"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		statementMismatchResponseTypeKindPropEnum = append(statementMismatchResponseTypeKindPropEnum, v)
+	}
+}
+
+const (
+
+	// StatementMismatchResponseKindMissingOnOurSide captures enum value "missing_on_our_side"
+	StatementMismatchResponseKindMissingOnOurSide string = "missing_on_our_side"
+
+	// StatementMismatchResponseKindMissingOnProviderSide captures enum value "missing_on_provider_side"
+	StatementMismatchResponseKindMissingOnProviderSide string = "missing_on_provider_side"
+
+	// StatementMismatchResponseKindAmountMismatch captures enum value "amount_mismatch"
+	StatementMismatchResponseKindAmountMismatch string = "amount_mismatch"
+)
+
+// prop value enum
+func (m *StatementMismatchResponse) validateKindEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, statementMismatchResponseTypeKindPropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *StatementMismatchResponse) validateKind(formats strfmt.Registry) error {
+	if swag.IsZero(m.Kind) { // not required
+		return nil
+	}
+
+	// value enum
+	if err := m.validateKindEnum("kind", "body", m.Kind); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validates this statement mismatch response based on context it is used
+func (m *StatementMismatchResponse) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *StatementMismatchResponse) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *StatementMismatchResponse) UnmarshalBinary(b []byte) error {
+	var res StatementMismatchResponse
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
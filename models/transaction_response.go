@@ -0,0 +1,132 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// TransactionResponse transaction response
+//
+// swagger:model TransactionResponse
+type TransactionResponse struct {
+
+	// amount
+	Amount float64 `json:"amount"`
+
+	// RFC3339 time the transaction was stored
+	CreatedAt string `json:"created_at,omitempty"`
+
+	// currency
+	Currency string `json:"currency,omitempty"`
+
+	// game code
+	GameCode string `json:"game_code,omitempty"`
+
+	// id
+	ID uint64 `json:"id,omitempty"`
+
+	// provider id
+	ProviderID string `json:"provider_id,omitempty"`
+
+	// req id
+	ReqID string `json:"req_id,omitempty"`
+
+	// round id
+	RoundID string `json:"round_id,omitempty"`
+
+	// session id
+	SessionID string `json:"session_id,omitempty"`
+
+	// type
+	// Enum: [bet result]
+	Type string `json:"type,omitempty"`
+}
+
+// Validate validates this transaction response
+func (m *TransactionResponse) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateType(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+var transactionResponseTypeTypePropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["bet","result"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		transactionResponseTypeTypePropEnum = append(transactionResponseTypeTypePropEnum, v)
+	}
+}
+
+const (
+
+	// TransactionResponseTypeBet captures enum value "bet"
+	TransactionResponseTypeBet string = "bet"
+
+	// TransactionResponseTypeResult captures enum value "result"
+	TransactionResponseTypeResult string = "result"
+)
+
+// prop value enum
+func (m *TransactionResponse) validateTypeEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, transactionResponseTypeTypePropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *TransactionResponse) validateType(formats strfmt.Registry) error {
+	if swag.IsZero(m.Type) { // not required
+		return nil
+	}
+
+	// value enum
+	if err := m.validateTypeEnum("type", "body", m.Type); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validates this transaction response based on context it is used
+func (m *TransactionResponse) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *TransactionResponse) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *TransactionResponse) UnmarshalBinary(b []byte) error {
+	var res TransactionResponse
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
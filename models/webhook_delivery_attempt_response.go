@@ -0,0 +1,62 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// WebhookDeliveryAttemptResponse webhook delivery attempt response
+//
+// swagger:model WebhookDeliveryAttemptResponse
+type WebhookDeliveryAttemptResponse struct {
+
+	// attempt
+	Attempt int64 `json:"attempt,omitempty"`
+
+	// RFC3339 time of the attempt
+	CreatedAt string `json:"created_at,omitempty"`
+
+	// duration ms
+	DurationMs int64 `json:"duration_ms,omitempty"`
+
+	// error
+	Error string `json:"error,omitempty"`
+
+	// HTTP status of the response, 0 when no response was received
+	StatusCode int64 `json:"status_code"`
+}
+
+// Validate validates this webhook delivery attempt response
+func (m *WebhookDeliveryAttemptResponse) Validate(formats strfmt.Registry) error {
+	return nil
+}
+
+// ContextValidate validates this webhook delivery attempt response based on context it is used
+func (m *WebhookDeliveryAttemptResponse) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *WebhookDeliveryAttemptResponse) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *WebhookDeliveryAttemptResponse) UnmarshalBinary(b []byte) error {
+	var res WebhookDeliveryAttemptResponse
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -0,0 +1,197 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// WebhookDeliveryResponse webhook delivery response
+//
+// swagger:model WebhookDeliveryResponse
+type WebhookDeliveryResponse struct {
+
+	// Every attempt of the delivery, only returned for a single delivery
+	AttemptLog []*WebhookDeliveryAttemptResponse `json:"attempt_log"`
+
+	// attempts
+	Attempts int64 `json:"attempts"`
+
+	// RFC3339 creation time
+	CreatedAt string `json:"created_at,omitempty"`
+
+	// RFC3339 delivery time, empty until the endpoint accepted the event
+	DeliveredAt string `json:"delivered_at,omitempty"`
+
+	// event type
+	EventType string `json:"event_type,omitempty"`
+
+	// id
+	ID uint64 `json:"id,omitempty"`
+
+	// last error
+	LastError string `json:"last_error,omitempty"`
+
+	// last status code
+	LastStatusCode int64 `json:"last_status_code,omitempty"`
+
+	// RFC3339 time of the next attempt while the delivery is pending
+	NextAttemptAt string `json:"next_attempt_at,omitempty"`
+
+	// Event body, only returned for a single delivery
+	Payload interface{} `json:"payload,omitempty"`
+
+	// status
+	// Enum: [pending delivered failed]
+	Status string `json:"status,omitempty"`
+
+	// webhook id
+	WebhookID uint64 `json:"webhook_id,omitempty"`
+}
+
+// Validate validates this webhook delivery response
+func (m *WebhookDeliveryResponse) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateAttemptLog(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateStatus(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *WebhookDeliveryResponse) validateAttemptLog(formats strfmt.Registry) error {
+	if swag.IsZero(m.AttemptLog) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.AttemptLog); i++ {
+		if swag.IsZero(m.AttemptLog[i]) { // not required
+			continue
+		}
+
+		if m.AttemptLog[i] != nil {
+			if err := m.AttemptLog[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("attempt_log" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+var webhookDeliveryResponseTypeStatusPropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["pending","delivered","failed"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		webhookDeliveryResponseTypeStatusPropEnum = append(webhookDeliveryResponseTypeStatusPropEnum, v)
+	}
+}
+
+const (
+
+	// WebhookDeliveryResponseStatusPending captures enum value "pending"
+	WebhookDeliveryResponseStatusPending string = "pending"
+
+	// WebhookDeliveryResponseStatusDelivered captures enum value "delivered"
+	WebhookDeliveryResponseStatusDelivered string = "delivered"
+
+	// WebhookDeliveryResponseStatusFailed captures enum value "failed"
+	WebhookDeliveryResponseStatusFailed string = "failed"
+)
+
+// prop value enum
+func (m *WebhookDeliveryResponse) validateStatusEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, webhookDeliveryResponseTypeStatusPropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *WebhookDeliveryResponse) validateStatus(formats strfmt.Registry) error {
+	if swag.IsZero(m.Status) { // not required
+		return nil
+	}
+
+	// value enum
+	if err := m.validateStatusEnum("status", "body", m.Status); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validate this webhook delivery response based on the context it is used
+func (m *WebhookDeliveryResponse) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateAttemptLog(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *WebhookDeliveryResponse) contextValidateAttemptLog(ctx context.Context, formats strfmt.Registry) error {
+
+	for i := 0; i < len(m.AttemptLog); i++ {
+
+		if m.AttemptLog[i] != nil {
+			if err := m.AttemptLog[i].ContextValidate(ctx, formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("attempt_log" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *WebhookDeliveryResponse) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *WebhookDeliveryResponse) UnmarshalBinary(b []byte) error {
+	var res WebhookDeliveryResponse
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -0,0 +1,133 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// WebhookRequest webhook request
+//
+// swagger:model WebhookRequest
+type WebhookRequest struct {
+
+	// Inactive webhooks receive no deliveries, defaults to true
+	Active *bool `json:"active,omitempty"`
+
+	// event types
+	// Required: true
+	// Min Items: 1
+	EventTypes []string `json:"event_types"`
+
+	// HTTP or HTTPS endpoint the events are posted to
+	// Required: true
+	// Min Length: 1
+	URL *string `json:"url"`
+}
+
+// Validate validates this webhook request
+func (m *WebhookRequest) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateEventTypes(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateURL(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+var webhookRequestEventTypesItemsEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["transaction.bet","transaction.result","balance.updated"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		webhookRequestEventTypesItemsEnum = append(webhookRequestEventTypesItemsEnum, v)
+	}
+}
+
+func (m *WebhookRequest) validateEventTypesItemsEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, webhookRequestEventTypesItemsEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *WebhookRequest) validateEventTypes(formats strfmt.Registry) error {
+
+	if err := validate.Required("event_types", "body", m.EventTypes); err != nil {
+		return err
+	}
+
+	iEventTypesSize := int64(len(m.EventTypes))
+
+	if err := validate.MinItems("event_types", "body", iEventTypesSize, 1); err != nil {
+		return err
+	}
+
+	for i := 0; i < len(m.EventTypes); i++ {
+
+		// value enum
+		if err := m.validateEventTypesItemsEnum("event_types"+"."+strconv.Itoa(i), "body", m.EventTypes[i]); err != nil {
+			return err
+		}
+
+	}
+
+	return nil
+}
+
+func (m *WebhookRequest) validateURL(formats strfmt.Registry) error {
+
+	if err := validate.Required("url", "body", m.URL); err != nil {
+		return err
+	}
+
+	if err := validate.MinLength("url", "body", *m.URL, 1); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validates this webhook request based on context it is used
+func (m *WebhookRequest) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *WebhookRequest) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *WebhookRequest) UnmarshalBinary(b []byte) error {
+	var res WebhookRequest
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
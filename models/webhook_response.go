@@ -0,0 +1,68 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// WebhookResponse webhook response
+//
+// swagger:model WebhookResponse
+type WebhookResponse struct {
+
+	// active
+	Active bool `json:"active"`
+
+	// RFC3339 creation time
+	CreatedAt string `json:"created_at,omitempty"`
+
+	// event types
+	EventTypes []string `json:"event_types"`
+
+	// id
+	ID uint64 `json:"id,omitempty"`
+
+	// operator id
+	OperatorID string `json:"operator_id,omitempty"`
+
+	// RFC3339 update time
+	UpdatedAt string `json:"updated_at,omitempty"`
+
+	// url
+	URL string `json:"url,omitempty"`
+}
+
+// Validate validates this webhook response
+func (m *WebhookResponse) Validate(formats strfmt.Registry) error {
+	return nil
+}
+
+// ContextValidate validates this webhook response based on context it is used
+func (m *WebhookResponse) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *WebhookResponse) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *WebhookResponse) UnmarshalBinary(b []byte) error {
+	var res WebhookResponse
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}